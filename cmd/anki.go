@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sar-michal/dictionary-app/pkg/anki"
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+)
+
+// runAnki writes an Anki deck with the selected words to a file.
+func runAnki(repo repository.Repository, args []string) error {
+	flags := flag.NewFlagSet("anki", flag.ContinueOnError)
+	output := flags.String("o", "dictionary.apkg", "output file")
+	deckName := flags.String("deck", anki.DefaultDeckName, "deck name")
+	reverse := flags.Bool("reverse", false, "add English to Polish cards")
+	audio := flags.Bool("audio", false, "include audio recordings")
+	wordIDs := flags.String("words", "", "comma-separated IDs of the words to include")
+	startsWith := flags.String("starts-with", "", "only include words beginning with the given text")
+	partOfSpeech := flags.String("part-of-speech", "", "only include words with the given part of speech, e.g. noun")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: anki [-o FILE] [-deck NAME] [-reverse] [-audio] [-words IDS] [filters]")
+		flags.PrintDefaults()
+	}
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	filter := repository.WordFilter{StartsWith: *startsWith}
+	if *partOfSpeech != "" {
+		pos := models.PartOfSpeech(*partOfSpeech)
+		filter.PartOfSpeech = &pos
+	}
+	if *wordIDs != "" {
+		ids, err := repository.ParseWordIDs(*wordIDs)
+		if err != nil {
+			return err
+		}
+		filter.WordIDs = ids
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer file.Close()
+
+	opts := anki.Options{DeckName: *deckName, Reverse: *reverse, Audio: *audio}
+	if err := anki.Export(file, repo, filter, opts); err != nil {
+		return err
+	}
+	return file.Close()
+}
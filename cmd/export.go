@@ -0,0 +1,89 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sar-michal/dictionary-app/pkg/dictd"
+	"github.com/sar-michal/dictionary-app/pkg/export"
+	"github.com/sar-michal/dictionary-app/pkg/glossary"
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+	"github.com/sar-michal/dictionary-app/pkg/stardict"
+)
+
+// runExport writes the dictionary, or the words matching the filter flags, to a file or standard output.
+// The stardict and dictd formats write a Polish-English and an English-Polish dictionary to a directory.
+func runExport(repo repository.Repository, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	formatName := flags.String("format", "jsonl", "file format: jsonl, csv, tei, stardict or dictd")
+	output := flags.String("o", "", "output file (default: standard output), or directory for stardict and dictd")
+	startsWith := flags.String("starts-with", "", "only export words beginning with the given text")
+	partOfSpeech := flags.String("part-of-speech", "", "only export words with the given part of speech, e.g. noun")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: export [-format jsonl|csv|tei|stardict|dictd] [-o FILE|DIR] [filters]")
+		flags.PrintDefaults()
+	}
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	filter := repository.WordFilter{StartsWith: *startsWith}
+	if *partOfSpeech != "" {
+		pos := models.PartOfSpeech(*partOfSpeech)
+		filter.PartOfSpeech = &pos
+	}
+	switch name := strings.ToLower(*formatName); name {
+	case "stardict", "dictd":
+		return exportDictionaries(repo, filter, name, *output)
+	}
+	format, err := export.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return export.Export(os.Stdout, repo, filter, format)
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer file.Close()
+	if err := export.Export(file, repo, filter, format); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// exportDictionaries writes both directions of the dictionary in the StarDict or dictd format to dir.
+func exportDictionaries(repo repository.Repository, filter repository.WordFilter, format, dir string) error {
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	for _, direction := range glossary.Directions {
+		g, err := glossary.Build(repo, filter, direction)
+		if err != nil {
+			return err
+		}
+		if format == "stardict" {
+			err = stardict.Write(dir, g, time.Now())
+		} else {
+			err = dictd.Write(dir, g)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write %s dictionary: %w", direction.Code(), err)
+		}
+	}
+	return nil
+}
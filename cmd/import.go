@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sar-michal/dictionary-app/graph"
+	"github.com/sar-michal/dictionary-app/pkg/importer"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+)
+
+// runImport imports a CSV or TSV file and prints the rejected rows and a summary.
+// The format defaults to the file extension.
+func runImport(repo repository.Repository, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	formatName := flags.String("format", "", "file format, csv or tsv (default: file extension)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: import [-format csv|tsv] FILE")
+		flags.PrintDefaults()
+	}
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errUsage
+	}
+	path := flags.Arg(0)
+
+	if *formatName == "" {
+		*formatName = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	format, err := importer.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	resolver := &graph.Resolver{Repo: repo}
+	report, err := resolver.Importer(context.Background()).Import(file, format)
+	if err != nil {
+		return fmt.Errorf("failed to import %s: %w", path, err)
+	}
+
+	for _, row := range report.Rows {
+		if row.Status == importer.RowRejected {
+			fmt.Printf("line %d: rejected: %s\n", row.Line, strings.Join(row.Errors, "; "))
+		}
+	}
+	fmt.Printf("%d created, %d skipped, %d rejected\n", report.Created, report.Skipped, report.Rejected)
+	return nil
+}
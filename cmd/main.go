@@ -1,6 +1,10 @@
 package main
 
 import (
+	"crypto/rand"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -11,30 +15,73 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/sar-michal/dictionary-app/graph"
+	"github.com/sar-michal/dictionary-app/pkg/auth"
 	"github.com/sar-michal/dictionary-app/pkg/config"
+	"github.com/sar-michal/dictionary-app/pkg/dictserver"
+	"github.com/sar-michal/dictionary-app/pkg/handlers"
 	"github.com/sar-michal/dictionary-app/pkg/models"
 	"github.com/sar-michal/dictionary-app/pkg/repository"
 	"github.com/sar-michal/dictionary-app/pkg/storage"
 	"github.com/vektah/gqlparser/v2/ast"
+	"gorm.io/gorm"
 )
 
-const defaultPort = "8080"
+const (
+	defaultPort   = "8080"
+	maxUploadSize = 16 << 20
+)
 
+// Without arguments the GraphQL server is started, together with a DICT protocol
+// server on DICT_PORT (default 2628). The server purges entries that have been in the trash
+// for longer than TRASH_RETENTION (default 720h). Other commands:
+//
+//	import [-format csv|tsv] FILE
+//	export [-format jsonl|csv|tei|stardict|dictd] [-o FILE|DIR] [-starts-with TEXT] [-part-of-speech POS]
+//	anki [-o FILE] [-deck NAME] [-reverse] [-audio] [-words IDS] [-starts-with TEXT] [-part-of-speech POS]
+//	role USERNAME viewer|editor|admin
+//	purge [-retention DURATION]
 func main() {
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = defaultPort
+	os.Setenv("GO_ENV", "development")
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1], os.Args[2:]))
 	}
 
-	os.Setenv("GO_ENV", "development")
-	cfg, err := config.LoadConfig()
+	db, err := connect()
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		log.Fatal(err)
 	}
+	defer func() {
+		if err := storage.CloseDB(db); err != nil {
+			log.Printf("Error closing database: %v", err)
+		}
+	}()
+	serve(&repository.GormRepository{DB: db})
+}
 
-	db, err := storage.NewConnection(cfg)
+// errUsage is returned by commands called with invalid arguments, after printing their usage.
+var errUsage = errors.New("invalid arguments")
+
+// commands maps the command names to the functions running them.
+var commands = map[string]func(repo repository.Repository, args []string) error{
+	"import": runImport,
+	"export": runExport,
+	"anki":   runAnki,
+	"role":   runRole,
+	"purge":  runPurge,
+}
+
+// runCommand runs a command and returns the exit code: 0 on success, 1 if the command failed
+// and 2 if it was called with invalid arguments. The database is closed before returning.
+func runCommand(name string, args []string) int {
+	run, ok := commands[name]
+	if !ok {
+		log.Printf("Unknown command: %s", name)
+		return 2
+	}
+	db, err := connect()
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		log.Print(err)
+		return 1
 	}
 	defer func() {
 		if err := storage.CloseDB(db); err != nil {
@@ -42,18 +89,73 @@ func main() {
 		}
 	}()
 
+	err = run(&repository.GormRepository{DB: db}, args)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	default:
+		log.Print(err)
+		return 1
+	}
+}
+
+// parseFlags parses the arguments of a command, returning errUsage if they are invalid.
+// The flag set is expected to continue on errors; it prints the error and the usage itself.
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	err := flags.Parse(args)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		return errUsage
+	}
+	return err
+}
+
+// connect opens the database and migrates it.
+func connect() (*gorm.DB, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	db, err := storage.NewConnection(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
 	if err := models.Migrate(db); err != nil {
-		log.Fatalf("Failed to migrate database: %v", err)
+		storage.CloseDB(db)
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
+	return db, nil
+}
 
-	repo := &repository.GormRepository{DB: db}
+func serve(repo repository.Repository) {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = defaultPort
+	}
+
+	tokens := &auth.Tokens{Secret: []byte(os.Getenv("JWT_SECRET"))}
+	if len(tokens.Secret) == 0 {
+		tokens.Secret = make([]byte, 32)
+		rand.Read(tokens.Secret)
+		log.Printf("JWT_SECRET is not set; using a random secret, so tokens will not survive a restart")
+	}
 
-	resolver := &graph.Resolver{Repo: repo}
-	srv := handler.New(graph.NewExecutableSchema(graph.Config{Resolvers: resolver}))
+	resolver := &graph.Resolver{Repo: repo, Tokens: tokens}
+	srv := handler.New(graph.NewExecutableSchema(graph.Config{
+		Resolvers:  resolver,
+		Directives: graph.DirectiveRoot{HasRole: graph.HasRole},
+	}))
 
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{
+		MaxUploadSize: maxUploadSize,
+		MaxMemory:     maxUploadSize,
+	})
 
 	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
 
@@ -63,7 +165,26 @@ func main() {
 	})
 
 	http.Handle("/", playground.Handler("GraphQL playground", "/query"))
-	http.Handle("/query", srv)
+	http.Handle("/query", auth.Middleware(repo, tokens)(srv))
+	http.Handle(handlers.AudioRoute, auth.Middleware(repo, tokens)(handlers.Audio(repo)))
+	http.Handle(handlers.ExportRoute, handlers.Export(repo))
+	http.Handle(handlers.AnkiRoute, handlers.Anki(repo))
+
+	dictPort := os.Getenv("DICT_PORT")
+	if dictPort == "" {
+		dictPort = dictserver.DefaultPort
+	}
+	dictServer := &dictserver.Server{Repo: repo}
+	go func() {
+		log.Fatal(dictServer.ListenAndServe(":" + dictPort))
+	}()
+	log.Printf("DICT server listening on port %s", dictPort)
+
+	retention, err := trashRetention()
+	if err != nil {
+		log.Fatal(err)
+	}
+	go purgeTrash(repo, retention, purgeInterval)
 
 	log.Printf("connect to http://localhost:%s/ for GraphQL playground", port)
 	log.Fatal(http.ListenAndServe(":"+port, nil))
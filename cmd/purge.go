@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+)
+
+const (
+	// defaultTrashRetention is how long deleted entries can be restored when TRASH_RETENTION is not set.
+	defaultTrashRetention = 30 * 24 * time.Hour
+	// purgeInterval is how often the server purges the trash.
+	purgeInterval = time.Hour
+)
+
+// trashRetention returns the retention of deleted entries set by TRASH_RETENTION, e.g. "720h".
+func trashRetention() (time.Duration, error) {
+	value := os.Getenv("TRASH_RETENTION")
+	if value == "" {
+		return defaultTrashRetention, nil
+	}
+	retention, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid TRASH_RETENTION: %w", err)
+	}
+	if retention < 0 {
+		return 0, fmt.Errorf("invalid TRASH_RETENTION: %s is negative", value)
+	}
+	return retention, nil
+}
+
+// runPurge permanently deletes the entries that have been in the trash for longer than the retention.
+// The retention defaults to TRASH_RETENTION.
+func runPurge(repo repository.Repository, args []string) error {
+	retention, err := trashRetention()
+	if err != nil {
+		return err
+	}
+	flags := flag.NewFlagSet("purge", flag.ContinueOnError)
+	flags.DurationVar(&retention, "retention", retention, "how long deleted entries are kept")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: purge [-retention DURATION]")
+		flags.PrintDefaults()
+	}
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	purged, err := repo.PurgeDeleted(time.Now().Add(-retention))
+	if err != nil {
+		return fmt.Errorf("failed to purge trash: %w", err)
+	}
+	fmt.Printf("Purged %d entries\n", purged)
+	return nil
+}
+
+// purgeTrash purges the entries older than the retention from the trash at every interval.
+// Failures are logged and retried at the next interval.
+func purgeTrash(repo repository.Repository, retention time.Duration, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		purged, err := repo.PurgeDeleted(time.Now().Add(-retention))
+		if err != nil {
+			log.Printf("Error purging trash: %v", err)
+		} else if purged > 0 {
+			log.Printf("Purged %d entries from the trash", purged)
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+)
+
+// runRole sets the role of a user. It is how the first admin is appointed;
+// later, admins can change roles with the setUserRole mutation.
+func runRole(repo repository.Repository, args []string) error {
+	flags := flag.NewFlagSet("role", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: role USERNAME viewer|editor|admin")
+	}
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return errUsage
+	}
+
+	user, err := repo.GetUserByUsername(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("failed to find user %q: %w", flags.Arg(0), err)
+	}
+	user, err = repo.UpdateUserRole(user.UserID, models.Role(flags.Arg(1)))
+	if err != nil {
+		return fmt.Errorf("failed to set role: %w", err)
+	}
+	fmt.Printf("%s is now %s\n", user.Username, user.Role)
+	return nil
+}
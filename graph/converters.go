@@ -2,18 +2,41 @@ package graph
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/sar-michal/dictionary-app/graph/model"
+	"github.com/sar-michal/dictionary-app/pkg/handlers"
+	"github.com/sar-michal/dictionary-app/pkg/importer"
+	"github.com/sar-michal/dictionary-app/pkg/merge"
 	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/quiz"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
 )
 
-// Convert models Word to a GraphQL Word
+// Convert models Word to a GraphQL Word.
+// Translations are left nil if they were not preloaded, so the field resolver loads them on demand.
 func convertWord(word *models.Word) *model.Word {
-	return &model.Word{
-		WordID:       strconv.FormatUint(uint64(word.WordID), 10),
-		PolishWord:   word.PolishWord,
-		Translations: convertTranslations(word.Translations),
+	gqlWord := &model.Word{
+		WordID:     strconv.FormatUint(uint64(word.WordID), 10),
+		PolishWord: word.PolishWord,
+		Visibility: convertVisibility(word.Owner),
 	}
+	gqlWord.PartOfSpeech = enumToGraph[model.PartOfSpeech](word.PartOfSpeech)
+	gqlWord.Gender = enumToGraph[model.Gender](word.Gender)
+	gqlWord.Aspect = enumToGraph[model.Aspect](word.Aspect)
+	if pronunciation := word.Transcription(); pronunciation != "" {
+		gqlWord.Pronunciation = &pronunciation
+	}
+	if word.PronunciationOverride != "" {
+		gqlWord.PronunciationOverride = &word.PronunciationOverride
+	}
+	if word.Translations != nil {
+		gqlWord.Translations = convertTranslations(word.Translations)
+	}
+	if word.DeletedAt.Valid {
+		gqlWord.DeletedAt = &word.DeletedAt.Time
+	}
+	return gqlWord
 }
 
 // Converts a slice of models Word to a slice of GraphQL Word
@@ -25,14 +48,42 @@ func convertWords(words []models.Word) []*model.Word {
 	return gqlWords
 }
 
-// Convert a single models Translation to a GraphQL Translation
+// Convert a models AudioRecording to a GraphQL AudioRecording
+func convertAudioRecording(recording *models.AudioRecording) *model.AudioRecording {
+	return &model.AudioRecording{
+		RecordingID: strconv.FormatUint(uint64(recording.RecordingID), 10),
+		WordID:      strconv.FormatUint(uint64(recording.WordID), 10),
+		ContentType: recording.ContentType,
+		Size:        int32(recording.Size),
+		URL:         handlers.AudioURL(recording.RecordingID),
+	}
+}
+
+// Convert a slice of models AudioRecording to GraphQL AudioRecording
+func convertAudioRecordings(recordings []models.AudioRecording) []*model.AudioRecording {
+	result := make([]*model.AudioRecording, len(recordings))
+	for i := range recordings {
+		result[i] = convertAudioRecording(&recordings[i])
+	}
+	return result
+}
+
+// Convert a single models Translation to a GraphQL Translation.
+// Example sentences are left nil if they were not preloaded.
 func convertTranslation(translation *models.Translation) *model.Translation {
-	return &model.Translation{
+	gqlTranslation := &model.Translation{
 		TranslationID:      strconv.FormatUint(uint64(translation.TranslationID), 10),
 		EnglishTranslation: translation.EnglishTranslation,
 		WordID:             strconv.FormatUint(uint64(translation.WordID), 10),
-		ExampleSentences:   convertExampleSentences(translation.ExampleSentences),
+		Visibility:         convertVisibility(translation.Owner),
+	}
+	if translation.ExampleSentences != nil {
+		gqlTranslation.ExampleSentences = convertExampleSentences(translation.ExampleSentences)
 	}
+	if translation.DeletedAt.Valid {
+		gqlTranslation.DeletedAt = &translation.DeletedAt.Time
+	}
+	return gqlTranslation
 }
 
 // Convert a slice of models Translation to a GraphQL Translation
@@ -46,11 +97,16 @@ func convertTranslations(translations []models.Translation) []*model.Translation
 
 // Convert a single models ExampleSentence to a GraphQL ExampleSentence
 func convertExampleSentence(sentence *models.ExampleSentence) *model.ExampleSentence {
-	return &model.ExampleSentence{
+	gqlSentence := &model.ExampleSentence{
 		SentenceID:    strconv.FormatUint(uint64(sentence.SentenceID), 10),
 		SentenceText:  sentence.SentenceText,
 		TranslationID: strconv.FormatUint(uint64(sentence.TranslationID), 10),
+		Visibility:    convertVisibility(sentence.Owner),
+	}
+	if sentence.DeletedAt.Valid {
+		gqlSentence.DeletedAt = &sentence.DeletedAt.Time
 	}
+	return gqlSentence
 }
 
 // Convert a slice of models ExampleSentence to GraphQL ExampleSentence
@@ -61,3 +117,671 @@ func convertExampleSentences(sentences []models.ExampleSentence) []*model.Exampl
 	}
 	return gqlSentences
 }
+
+// Convert a models enum value to an optional GraphQL enum. Empty values become nil.
+func enumToGraph[G ~string, M ~string](value M) *G {
+	if value == "" {
+		return nil
+	}
+	result := G(strings.ToUpper(string(value)))
+	return &result
+}
+
+// Convert an optional GraphQL enum to a models enum value. Nil becomes an empty value.
+func enumToModels[M ~string, G ~string](value *G) M {
+	if value == nil {
+		return ""
+	}
+	return M(strings.ToLower(string(*value)))
+}
+
+// Convert a single models Inflection to a GraphQL Inflection
+func convertInflection(inflection *models.Inflection) *model.Inflection {
+	return &model.Inflection{
+		InflectionID: strconv.FormatUint(uint64(inflection.InflectionID), 10),
+		WordID:       strconv.FormatUint(uint64(inflection.WordID), 10),
+		Form:         inflection.Form,
+		Case:         enumToGraph[model.GrammaticalCase](inflection.Case),
+		Number:       enumToGraph[model.GrammaticalNumber](inflection.Number),
+		Person:       enumToGraph[model.Person](inflection.Person),
+		Tense:        enumToGraph[model.Tense](inflection.Tense),
+		Gender:       enumToGraph[model.Gender](inflection.Gender),
+	}
+}
+
+// Convert a models WordRelation to a GraphQL WordRelation seen from the word with the given ID
+func convertWordRelation(relation *models.WordRelation, wordID uint) *model.WordRelation {
+	result := &model.WordRelation{
+		RelationID: strconv.FormatUint(uint64(relation.RelationID), 10),
+		Type:       *enumToGraph[model.RelationType](relation.Type),
+		Word:       convertWord(&relation.RelatedWord),
+	}
+	if relation.WordID != wordID {
+		result.Word = convertWord(&relation.Word)
+		result.Inverse = !relation.Type.Symmetric()
+	}
+	return result
+}
+
+// Convert a slice of models WordRelation to GraphQL WordRelation seen from the word with the given ID
+func convertWordRelations(relations []models.WordRelation, wordID uint) []*model.WordRelation {
+	result := make([]*model.WordRelation, len(relations))
+	for i := range relations {
+		result[i] = convertWordRelation(&relations[i], wordID)
+	}
+	return result
+}
+
+// Convert a GraphQL RelationType to a models RelationType. Nil stays nil.
+func convertRelationType(relationType *model.RelationType) *models.RelationType {
+	if relationType == nil {
+		return nil
+	}
+	result := enumToModels[models.RelationType](relationType)
+	return &result
+}
+
+// Convert optional GraphQL grammatical categories to models InflectionTags
+func convertInflectionTags(
+	grammaticalCase *model.GrammaticalCase,
+	number *model.GrammaticalNumber,
+	person *model.Person,
+	tense *model.Tense,
+	gender *model.Gender,
+) models.InflectionTags {
+	return models.InflectionTags{
+		Case:   enumToModels[models.GrammaticalCase](grammaticalCase),
+		Number: enumToModels[models.GrammaticalNumber](number),
+		Person: enumToModels[models.Person](person),
+		Tense:  enumToModels[models.Tense](tense),
+		Gender: enumToModels[models.Gender](gender),
+	}
+}
+
+// Canonical orders of grammatical categories in inflection tables. Unset values come first.
+var (
+	caseOrder   = []models.GrammaticalCase{"", models.CaseNominative, models.CaseGenitive, models.CaseDative, models.CaseAccusative, models.CaseInstrumental, models.CaseLocative, models.CaseVocative}
+	personOrder = []models.Person{"", models.PersonFirst, models.PersonSecond, models.PersonThird}
+	tenseOrder  = []models.Tense{"", models.TensePresent, models.TensePast, models.TenseFuture}
+	genderOrder = []models.Gender{"", models.GenderMasculinePersonal, models.GenderMasculineAnimate, models.GenderMasculineInanimate, models.GenderFeminine, models.GenderNeuter}
+)
+
+// Arrange inflected forms into a GraphQL InflectionTable.
+// Sections are keyed by tense and gender, rows by case and person, and columns by number.
+func buildInflectionTable(inflections []models.Inflection) *model.InflectionTable {
+	type sectionKey struct {
+		tense  models.Tense
+		gender models.Gender
+	}
+	type rowKey struct {
+		grammaticalCase models.GrammaticalCase
+		person          models.Person
+	}
+	sections := make(map[sectionKey]map[rowKey]*model.InflectionRow)
+	for _, inflection := range inflections {
+		sk := sectionKey{inflection.Tense, inflection.Gender}
+		if sections[sk] == nil {
+			sections[sk] = make(map[rowKey]*model.InflectionRow)
+		}
+		rk := rowKey{inflection.Case, inflection.Person}
+		row := sections[sk][rk]
+		if row == nil {
+			row = &model.InflectionRow{
+				Case:        enumToGraph[model.GrammaticalCase](inflection.Case),
+				Person:      enumToGraph[model.Person](inflection.Person),
+				Singular:    []*model.Inflection{},
+				Plural:      []*model.Inflection{},
+				Unspecified: []*model.Inflection{},
+			}
+			sections[sk][rk] = row
+		}
+		gqlInflection := convertInflection(&inflection)
+		switch inflection.Number {
+		case models.NumberSingular:
+			row.Singular = append(row.Singular, gqlInflection)
+		case models.NumberPlural:
+			row.Plural = append(row.Plural, gqlInflection)
+		default:
+			row.Unspecified = append(row.Unspecified, gqlInflection)
+		}
+	}
+
+	table := &model.InflectionTable{Sections: []*model.InflectionSection{}}
+	for _, tense := range tenseOrder {
+		for _, gender := range genderOrder {
+			rows, ok := sections[sectionKey{tense, gender}]
+			if !ok {
+				continue
+			}
+			section := &model.InflectionSection{
+				Tense:  enumToGraph[model.Tense](tense),
+				Gender: enumToGraph[model.Gender](gender),
+				Rows:   []*model.InflectionRow{},
+			}
+			for _, grammaticalCase := range caseOrder {
+				for _, person := range personOrder {
+					if row, ok := rows[rowKey{grammaticalCase, person}]; ok {
+						section.Rows = append(section.Rows, row)
+					}
+				}
+			}
+			table.Sections = append(table.Sections, section)
+		}
+	}
+	return table
+}
+
+// Convert a GraphQL PartOfSpeech to a models PartOfSpeech. Nil stays nil.
+func convertPartOfSpeech(partOfSpeech *model.PartOfSpeech) *models.PartOfSpeech {
+	if partOfSpeech == nil {
+		return nil
+	}
+	result := models.PartOfSpeech(strings.ToLower(string(*partOfSpeech)))
+	return &result
+}
+
+// Convert a GraphQL Gender to a models Gender. Nil stays nil.
+func convertGender(gender *model.Gender) *models.Gender {
+	if gender == nil {
+		return nil
+	}
+	result := models.Gender(strings.ToLower(string(*gender)))
+	return &result
+}
+
+// Convert a GraphQL Aspect to a models Aspect. Nil stays nil.
+func convertAspect(aspect *model.Aspect) *models.Aspect {
+	if aspect == nil {
+		return nil
+	}
+	result := models.Aspect(strings.ToLower(string(*aspect)))
+	return &result
+}
+
+// Convert optional GraphQL grammatical metadata to models Grammar. Unset values are left empty.
+func convertGrammar(partOfSpeech *model.PartOfSpeech, gender *model.Gender, aspect *model.Aspect) models.Grammar {
+	var grammar models.Grammar
+	if p := convertPartOfSpeech(partOfSpeech); p != nil {
+		grammar.PartOfSpeech = *p
+	}
+	if g := convertGender(gender); g != nil {
+		grammar.Gender = *g
+	}
+	if a := convertAspect(aspect); a != nil {
+		grammar.Aspect = *a
+	}
+	return grammar
+}
+
+// Convert a GraphQL WordOrder to a repository WordOrder
+func convertWordOrder(order *model.WordOrder) repository.WordOrder {
+	if order == nil {
+		return repository.WordOrder{}
+	}
+	result := repository.WordOrder{
+		Descending: order.Direction != nil && *order.Direction == model.OrderDirectionDesc,
+	}
+	switch order.Field {
+	case model.WordOrderFieldPolishWord:
+		result.Field = repository.WordOrderByPolishWord
+	case model.WordOrderFieldCreatedAt:
+		result.Field = repository.WordOrderByCreatedAt
+	case model.WordOrderFieldTranslationCount:
+		result.Field = repository.WordOrderByTranslationCount
+	default:
+		result.Field = repository.WordOrderByID
+	}
+	return result
+}
+
+// Convert a GraphQL WordFilter to a repository WordFilter
+func convertWordFilter(filter *model.WordFilter) repository.WordFilter {
+	if filter == nil {
+		return repository.WordFilter{}
+	}
+	result := repository.WordFilter{
+		HasTranslations:     filter.HasTranslations,
+		HasExampleSentences: filter.HasExampleSentences,
+		PartOfSpeech:        convertPartOfSpeech(filter.PartOfSpeech),
+		Gender:              convertGender(filter.Gender),
+		Aspect:              convertAspect(filter.Aspect),
+	}
+	if filter.StartsWith != nil {
+		result.StartsWith = *filter.StartsWith
+	}
+	return result
+}
+
+// Convert a GraphQL TranslationOrder to a repository TranslationOrder
+func convertTranslationOrder(order *model.TranslationOrder) repository.TranslationOrder {
+	if order == nil {
+		return repository.TranslationOrder{}
+	}
+	result := repository.TranslationOrder{
+		Descending: order.Direction != nil && *order.Direction == model.OrderDirectionDesc,
+	}
+	switch order.Field {
+	case model.TranslationOrderFieldEnglishTranslation:
+		result.Field = repository.TranslationOrderByEnglishTranslation
+	case model.TranslationOrderFieldCreatedAt:
+		result.Field = repository.TranslationOrderByCreatedAt
+	case model.TranslationOrderFieldExampleSentenceCount:
+		result.Field = repository.TranslationOrderByExampleSentenceCount
+	default:
+		result.Field = repository.TranslationOrderByID
+	}
+	return result
+}
+
+// Convert a GraphQL TranslationFilter to a repository TranslationFilter
+func convertTranslationFilter(filter *model.TranslationFilter) repository.TranslationFilter {
+	if filter == nil {
+		return repository.TranslationFilter{}
+	}
+	result := repository.TranslationFilter{
+		HasExampleSentences: filter.HasExampleSentences,
+	}
+	if filter.StartsWith != nil {
+		result.StartsWith = *filter.StartsWith
+	}
+	return result
+}
+
+// Builds GraphQL PageInfo from a repository Page
+func convertPageInfo[T any](page *repository.Page[T]) *model.PageInfo {
+	info := &model.PageInfo{
+		HasNextPage:     page.HasNextPage,
+		HasPreviousPage: page.HasPreviousPage,
+	}
+	if len(page.Cursors) > 0 {
+		info.StartCursor = &page.Cursors[0]
+		info.EndCursor = &page.Cursors[len(page.Cursors)-1]
+	}
+	return info
+}
+
+// Convert a repository Page of words to a GraphQL WordConnection
+func convertWordConnection(page *repository.Page[models.Word]) *model.WordConnection {
+	edges := make([]*model.WordEdge, len(page.Items))
+	for i, w := range page.Items {
+		edges[i] = &model.WordEdge{Cursor: page.Cursors[i], Node: convertWord(&w)}
+	}
+	return &model.WordConnection{
+		Edges:      edges,
+		PageInfo:   convertPageInfo(page),
+		TotalCount: int32(page.TotalCount),
+	}
+}
+
+// Convert a repository Page of translations to a GraphQL TranslationConnection
+func convertTranslationConnection(page *repository.Page[models.Translation]) *model.TranslationConnection {
+	edges := make([]*model.TranslationEdge, len(page.Items))
+	for i, t := range page.Items {
+		edges[i] = &model.TranslationEdge{Cursor: page.Cursors[i], Node: convertTranslation(&t)}
+	}
+	return &model.TranslationConnection{
+		Edges:      edges,
+		PageInfo:   convertPageInfo(page),
+		TotalCount: int32(page.TotalCount),
+	}
+}
+
+// Convert a repository Page of example sentences to a GraphQL ExampleSentenceConnection
+func convertExampleSentenceConnection(page *repository.Page[models.ExampleSentence]) *model.ExampleSentenceConnection {
+	edges := make([]*model.ExampleSentenceEdge, len(page.Items))
+	for i, s := range page.Items {
+		edges[i] = &model.ExampleSentenceEdge{Cursor: page.Cursors[i], Node: convertExampleSentence(&s)}
+	}
+	return &model.ExampleSentenceConnection{
+		Edges:      edges,
+		PageInfo:   convertPageInfo(page),
+		TotalCount: int32(page.TotalCount),
+	}
+}
+
+// Convert a slice of repository FormMatch to GraphQL LookupResult
+func convertFormMatches(matches []repository.FormMatch) []*model.LookupResult {
+	results := make([]*model.LookupResult, len(matches))
+	for i, m := range matches {
+		result := &model.LookupResult{
+			Word:   convertWord(&m.Word),
+			Case:   enumToGraph[model.GrammaticalCase](m.Tags.Case),
+			Number: enumToGraph[model.GrammaticalNumber](m.Tags.Number),
+			Person: enumToGraph[model.Person](m.Tags.Person),
+			Tense:  enumToGraph[model.Tense](m.Tags.Tense),
+			Gender: enumToGraph[model.Gender](m.Tags.Gender),
+		}
+		switch m.Method {
+		case repository.LookupInflection:
+			result.Method = model.LookupMethodInflection
+		case repository.LookupSuffixRule:
+			result.Method = model.LookupMethodSuffixRule
+		default:
+			result.Method = model.LookupMethodHeadword
+		}
+		if m.Inflection != nil {
+			result.Inflection = convertInflection(m.Inflection)
+		}
+		results[i] = result
+	}
+	return results
+}
+
+// Convert a GraphQL ImportFormat to an importer Format
+func convertImportFormat(format model.ImportFormat) importer.Format {
+	if format == model.ImportFormatTsv {
+		return importer.TSV
+	}
+	return importer.CSV
+}
+
+// Convert an importer Report to a GraphQL ImportReport
+func convertImportReport(report *importer.Report) *model.ImportReport {
+	rows := make([]*model.ImportRowResult, len(report.Rows))
+	for i, row := range report.Rows {
+		result := &model.ImportRowResult{
+			Line:   int32(row.Line),
+			Errors: row.Errors,
+		}
+		if result.Errors == nil {
+			result.Errors = []string{}
+		}
+		switch row.Status {
+		case importer.RowSkipped:
+			result.Status = model.ImportRowStatusSkipped
+		case importer.RowRejected:
+			result.Status = model.ImportRowStatusRejected
+		default:
+			result.Status = model.ImportRowStatusCreated
+		}
+		if row.PolishWord != "" {
+			result.PolishWord = &row.PolishWord
+		}
+		if row.EnglishTranslation != "" {
+			result.EnglishTranslation = &row.EnglishTranslation
+		}
+		rows[i] = result
+	}
+	return &model.ImportReport{
+		Created:  int32(report.Created),
+		Skipped:  int32(report.Skipped),
+		Rejected: int32(report.Rejected),
+		Rows:     rows,
+	}
+}
+
+// Convert a GraphQL SearchMode to a repository SearchMode
+func convertSearchMode(mode model.SearchMode) repository.SearchMode {
+	switch mode {
+	case model.SearchModeSubstring:
+		return repository.SearchSubstring
+	case model.SearchModeFuzzy:
+		return repository.SearchFuzzy
+	default:
+		return repository.SearchPrefix
+	}
+}
+
+// Convert a slice of repository WordSearchHit to GraphQL WordSearchHit
+func convertWordSearchHits(hits []repository.WordSearchHit) []*model.WordSearchHit {
+	gqlHits := make([]*model.WordSearchHit, len(hits))
+	for i, h := range hits {
+		gqlHits[i] = &model.WordSearchHit{
+			Word:       convertWord(&h.Word),
+			Similarity: h.Similarity,
+		}
+	}
+	return gqlHits
+}
+
+// Convert a single models ReviewCard to a GraphQL ReviewCard
+func convertReviewCard(card *models.ReviewCard) *model.ReviewCard {
+	return &model.ReviewCard{
+		CardID:       strconv.FormatUint(uint64(card.CardID), 10),
+		Learner:      card.Learner,
+		Translation:  convertTranslation(&card.Translation),
+		Repetitions:  int32(card.Repetitions),
+		IntervalDays: int32(card.IntervalDays),
+		EaseFactor:   card.EaseFactor,
+		Due:          card.Due,
+		LastReviewed: card.LastReviewed,
+	}
+}
+
+// Convert a slice of models ReviewCard to a GraphQL ReviewCard
+func convertReviewCards(cards []models.ReviewCard) []*model.ReviewCard {
+	gqlCards := make([]*model.ReviewCard, len(cards))
+	for i := range cards {
+		gqlCards[i] = convertReviewCard(&cards[i])
+	}
+	return gqlCards
+}
+
+// Convert a GraphQL QuizDirection to a quiz Direction
+func convertQuizDirection(direction model.QuizDirection) quiz.Direction {
+	if direction == model.QuizDirectionEnglishToPolish {
+		return quiz.EnglishToPolish
+	}
+	return quiz.PolishToEnglish
+}
+
+// Convert a GraphQL QuizType to a quiz Type
+func convertQuizType(questionType model.QuizType) quiz.Type {
+	switch questionType {
+	case model.QuizTypeTypedAnswer:
+		return quiz.TypedAnswer
+	case model.QuizTypeCloze:
+		return quiz.Cloze
+	default:
+		return quiz.MultipleChoice
+	}
+}
+
+// Convert a slice of quiz Question to GraphQL QuizQuestion
+func convertQuizQuestions(questions []quiz.Question) []*model.QuizQuestion {
+	gqlQuestions := make([]*model.QuizQuestion, len(questions))
+	for i, q := range questions {
+		gqlQuestion := &model.QuizQuestion{
+			QuestionID: q.ID,
+			Prompt:     q.Prompt,
+			Choices:    q.Choices,
+		}
+		switch q.Type {
+		case quiz.TypedAnswer:
+			gqlQuestion.Type = model.QuizTypeTypedAnswer
+		case quiz.Cloze:
+			gqlQuestion.Type = model.QuizTypeCloze
+		default:
+			gqlQuestion.Type = model.QuizTypeMultipleChoice
+		}
+		if q.Type != quiz.Cloze {
+			direction := model.QuizDirectionPolishToEnglish
+			if q.Direction == quiz.EnglishToPolish {
+				direction = model.QuizDirectionEnglishToPolish
+			}
+			gqlQuestion.Direction = &direction
+		}
+		if q.Hint != "" {
+			gqlQuestion.Hint = &q.Hint
+		}
+		if gqlQuestion.Choices == nil {
+			gqlQuestion.Choices = []string{}
+		}
+		gqlQuestions[i] = gqlQuestion
+	}
+	return gqlQuestions
+}
+
+// Convert a single models User to a GraphQL User
+func convertUser(user *models.User) *model.User {
+	gqlUser := &model.User{
+		UserID:   strconv.FormatUint(uint64(user.UserID), 10),
+		Username: user.Username,
+		Role:     *enumToGraph[model.Role](user.Role),
+	}
+	if user.Group != "" {
+		gqlUser.Group = &user.Group
+	}
+	return gqlUser
+}
+
+// Convert the owner of an entry to its GraphQL Visibility
+func convertVisibility(owner models.Owner) model.Visibility {
+	switch {
+	case owner.Shared():
+		return model.VisibilityPublic
+	case owner.Group():
+		return model.VisibilityGroup
+	}
+	return model.VisibilityPrivate
+}
+
+// Convert a single models ChangeProposal to a GraphQL ChangeProposal
+func convertChangeProposal(proposal *models.ChangeProposal) *model.ChangeProposal {
+	gqlProposal := &model.ChangeProposal{
+		ProposalID: strconv.FormatUint(uint64(proposal.ProposalID), 10),
+		Action:     *enumToGraph[model.ProposalAction](proposal.Action),
+		EntryType:  *enumToGraph[model.EntryType](proposal.EntryType),
+		Diff:       make([]*model.FieldChange, len(proposal.Diff)),
+		ProposedBy: convertUser(&proposal.Proposer),
+		Status:     *enumToGraph[model.ProposalStatus](proposal.Status),
+		CreatedAt:  proposal.CreatedAt,
+		ReviewedAt: proposal.ReviewedAt,
+	}
+	if proposal.EntryID != 0 {
+		entryID := strconv.FormatUint(uint64(proposal.EntryID), 10)
+		gqlProposal.EntryID = &entryID
+	}
+	for i, change := range proposal.Diff {
+		gqlChange := &model.FieldChange{Field: change.Field}
+		if change.Before != "" {
+			gqlChange.Before = &change.Before
+		}
+		if change.After != "" {
+			gqlChange.After = &change.After
+		}
+		gqlProposal.Diff[i] = gqlChange
+	}
+	if proposal.Comment != "" {
+		gqlProposal.Comment = &proposal.Comment
+	}
+	if proposal.Reviewer != nil {
+		gqlProposal.ReviewedBy = convertUser(proposal.Reviewer)
+	}
+	return gqlProposal
+}
+
+// Convert a repository Page of change proposals to a GraphQL ChangeProposalConnection
+func convertChangeProposalConnection(page *repository.Page[models.ChangeProposal]) *model.ChangeProposalConnection {
+	edges := make([]*model.ChangeProposalEdge, len(page.Items))
+	for i, p := range page.Items {
+		edges[i] = &model.ChangeProposalEdge{Cursor: page.Cursors[i], Node: convertChangeProposal(&p)}
+	}
+	return &model.ChangeProposalConnection{
+		Edges:      edges,
+		PageInfo:   convertPageInfo(page),
+		TotalCount: int32(page.TotalCount),
+	}
+}
+
+// Convert a single models Revision to a GraphQL Revision
+func convertRevision(revision *models.Revision) *model.Revision {
+	gqlRevision := &model.Revision{
+		RevisionID: strconv.FormatUint(uint64(revision.RevisionID), 10),
+		EntryType:  *enumToGraph[model.EntryType](revision.EntryType),
+		EntryID:    strconv.FormatUint(uint64(revision.EntryID), 10),
+		Action:     *enumToGraph[model.RevisionAction](revision.Action),
+		Changes:    convertSnapshotChanges(revision.Before, revision.After),
+		CreatedAt:  revision.CreatedAt,
+	}
+	if revision.Author != nil {
+		gqlRevision.Author = convertUser(revision.Author)
+	}
+	return gqlRevision
+}
+
+// Convert a slice of models Revisions to GraphQL Revisions
+func convertRevisions(revisions []models.Revision) []*model.Revision {
+	gqlRevisions := make([]*model.Revision, len(revisions))
+	for i := range revisions {
+		gqlRevisions[i] = convertRevision(&revisions[i])
+	}
+	return gqlRevisions
+}
+
+// Convert a repository Trash to a GraphQL Trash
+func convertTrash(trash *repository.Trash) *model.Trash {
+	return &model.Trash{
+		Words:            convertWords(trash.Words),
+		Translations:     convertTranslations(trash.Translations),
+		ExampleSentences: convertExampleSentences(trash.ExampleSentences),
+	}
+}
+
+// Convert a merge Summary and the target word to a GraphQL MergeSummary
+func convertMergeSummary(summary *merge.Summary, word *models.Word) *model.MergeSummary {
+	return &model.MergeSummary{
+		Word:                   convertWord(word),
+		MovedTranslations:      int32(summary.MovedTranslations),
+		MergedTranslations:     int32(summary.MergedTranslations),
+		MovedExampleSentences:  int32(summary.MovedExampleSentences),
+		MergedExampleSentences: int32(summary.MergedExampleSentences),
+	}
+}
+
+// Convert the snapshots of a revision to the GraphQL FieldChanges of the fields that differ
+func convertSnapshotChanges(before, after *models.EntrySnapshot) []*model.FieldChange {
+	beforeFields, afterFields := snapshotFields(before), snapshotFields(after)
+	fields := afterFields
+	if after == nil {
+		fields = beforeFields
+	}
+	changes := []*model.FieldChange{}
+	for i, field := range fields {
+		var change model.FieldChange
+		change.Field = field[0]
+		if before != nil && beforeFields[i][1] != "" {
+			change.Before = &beforeFields[i][1]
+		}
+		if after != nil && afterFields[i][1] != "" {
+			change.After = &afterFields[i][1]
+		}
+		if (change.Before == nil && change.After == nil) ||
+			(change.Before != nil && change.After != nil && *change.Before == *change.After) {
+			continue
+		}
+		changes = append(changes, &change)
+	}
+	return changes
+}
+
+// snapshotFields lists the field names and values of an entry snapshot in a fixed order
+func snapshotFields(snapshot *models.EntrySnapshot) [][2]string {
+	switch {
+	case snapshot == nil:
+		return nil
+	case snapshot.Word != nil:
+		w := snapshot.Word
+		return [][2]string{
+			{"polishWord", w.PolishWord},
+			{"partOfSpeech", string(w.PartOfSpeech)},
+			{"gender", string(w.Gender)},
+			{"aspect", string(w.Aspect)},
+			{"pronunciationOverride", w.PronunciationOverride},
+			{"visibility", string(convertVisibility(w.Owner))},
+		}
+	case snapshot.Translation != nil:
+		return [][2]string{
+			{"englishTranslation", snapshot.Translation.EnglishTranslation},
+			{"wordID", strconv.FormatUint(uint64(snapshot.Translation.WordID), 10)},
+			{"visibility", string(convertVisibility(snapshot.Translation.Owner))},
+		}
+	case snapshot.ExampleSentence != nil:
+		return [][2]string{
+			{"sentenceText", snapshot.ExampleSentence.SentenceText},
+			{"translationID", strconv.FormatUint(uint64(snapshot.ExampleSentence.TranslationID), 10)},
+			{"visibility", string(convertVisibility(snapshot.ExampleSentence.Owner))},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/sar-michal/dictionary-app/graph/model"
+	"github.com/sar-michal/dictionary-app/pkg/auth"
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/proposal"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+)
+
+// ErrorCodeForbidden is the extensions.code of errors returned for unauthorized calls.
+const ErrorCodeForbidden = "FORBIDDEN"
+
+// ErrorCodeProposalPending is the extensions.code of errors returned for changes proposed to editors
+// instead of being made. The proposalID extension identifies the proposal.
+const ErrorCodeProposalPending = "PROPOSAL_PENDING"
+
+// HasRole implements the @hasRole directive. It lets the call through when the signed-in
+// user has the required role or a higher one.
+func HasRole(ctx context.Context, obj any, next graphql.Resolver, role model.Role) (any, error) {
+	if err := requireRole(ctx, enumToModels[models.Role](&role)); err != nil {
+		return nil, err
+	}
+	return next(ctx)
+}
+
+// requireRole returns a FORBIDDEN error unless the signed-in user has the required role or a higher one.
+func requireRole(ctx context.Context, required models.Role) error {
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return forbidden(ctx, "authentication required")
+	}
+	if !user.Role.Includes(required) {
+		return forbidden(ctx, "requires the "+string(required)+" role")
+	}
+	return nil
+}
+
+// authorizeLearner returns a FORBIDDEN error unless the learner is the username of the signed-in user.
+func authorizeLearner(ctx context.Context, learner string) error {
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return forbidden(ctx, "authentication required")
+	}
+	if user.Username != learner {
+		return forbidden(ctx, "review cards of other learners cannot be accessed")
+	}
+	return nil
+}
+
+// authorizeChange checks that the signed-in user may make a change to an entry with the given owner.
+// Private and group entries can be changed by anyone who sees them, shared ones require the role of the change.
+// Without it the change is proposed to editors, and a PROPOSAL_PENDING error is returned.
+func authorizeChange(ctx context.Context, repo repository.Repository, change proposal.Change, owner models.Owner) error {
+	if !owner.Shared() {
+		return nil
+	}
+	required := proposal.RequiredRole(change)
+	user := auth.UserFromContext(ctx)
+	if user == nil || user.Role.Includes(required) || !user.Role.Includes(models.RoleViewer) {
+		return requireRole(ctx, required)
+	}
+	proposed, err := proposal.Propose(repo, change, user.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to propose change: %w", err)
+	}
+	return &gqlerror.Error{
+		Message: "requires the " + string(required) + " role, the change was proposed to editors",
+		Path:    graphql.GetPath(ctx),
+		Extensions: map[string]any{
+			"code":       ErrorCodeProposalPending,
+			"proposalID": strconv.FormatUint(uint64(proposed.ProposalID), 10),
+		},
+	}
+}
+
+// authorizeEntryChange checks that the signed-in user may make a change to the existing entry it names.
+// See authorizeChange.
+func authorizeEntryChange(ctx context.Context, repo repository.Repository, change proposal.Change) error {
+	owner, err := entryOwner(repo, change.EntryType, change.EntryID)
+	if err != nil {
+		return err
+	}
+	return authorizeChange(ctx, repo, change, owner)
+}
+
+// entryOwner returns the owner of an entry visible in repo.
+func entryOwner(repo repository.Repository, entryType models.EntryType, entryID uint) (models.Owner, error) {
+	switch entryType {
+	case models.EntryWord:
+		word, err := repo.GetWordByID(entryID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find word: %w", err)
+		}
+		return word.Owner, nil
+	case models.EntryTranslation:
+		translation, err := repo.GetTranslationByID(entryID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find translation: %w", err)
+		}
+		return translation.Owner, nil
+	case models.EntryExampleSentence:
+		sentence, err := repo.GetExampleSentenceByID(entryID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find example sentence: %w", err)
+		}
+		return sentence.Owner, nil
+	}
+	return "", fmt.Errorf("unknown entry type: %q", entryType)
+}
+
+// revisionEntryOwner returns the current owner of the entry of a revision visible in repo, and whether
+// the entry is in the trash or purged from it. Such entries have the owner recorded by their newest revision.
+func revisionEntryOwner(repo repository.Repository, revision *models.Revision) (models.Owner, bool, error) {
+	if owner, err := entryOwner(repo, revision.EntryType, revision.EntryID); err == nil {
+		return owner, false, nil
+	}
+	revisions, err := repo.ListRevisions(revision.EntryType, revision.EntryID)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to list revisions: %w", err)
+	}
+	return revisions[0].State().Owner(), true, nil
+}
+
+// forbidden returns an error with the FORBIDDEN code at the current field.
+func forbidden(ctx context.Context, message string) error {
+	return &gqlerror.Error{
+		Message:    message,
+		Path:       graphql.GetPath(ctx),
+		Extensions: map[string]any{"code": ErrorCodeForbidden},
+	}
+}
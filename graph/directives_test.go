@@ -0,0 +1,154 @@
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/sar-michal/dictionary-app/graph/model"
+	"github.com/sar-michal/dictionary-app/pkg/auth"
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/proposal"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+)
+
+func TestHasRole(t *testing.T) {
+	next := func(ctx context.Context) (any, error) {
+		return "resolved", nil
+	}
+	cases := []struct {
+		name     string
+		user     *models.User
+		required model.Role
+		allowed  bool
+	}{
+		{"Anonymous", nil, model.RoleViewer, false},
+		{"Viewer Studies", &models.User{Role: models.RoleViewer}, model.RoleViewer, true},
+		{"Viewer Edits", &models.User{Role: models.RoleViewer}, model.RoleEditor, false},
+		{"Editor Edits", &models.User{Role: models.RoleEditor}, model.RoleEditor, true},
+		{"Editor Deletes", &models.User{Role: models.RoleEditor}, model.RoleAdmin, false},
+		{"Admin Edits", &models.User{Role: models.RoleAdmin}, model.RoleEditor, true},
+		{"Unknown Role", &models.User{Role: "owner"}, model.RoleViewer, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.Background()
+			if c.user != nil {
+				ctx = auth.WithUser(ctx, c.user)
+			}
+			result, err := HasRole(ctx, nil, next, c.required)
+			if c.allowed {
+				require.NoError(t, err, "Expected Call To Be Allowed")
+				assert.Equal(t, "resolved", result, "Expected Resolver To Run")
+				return
+			}
+			var gqlErr *gqlerror.Error
+			require.ErrorAs(t, err, &gqlErr, "Expected A GraphQL Error")
+			assert.Equal(t, ErrorCodeForbidden, gqlErr.Extensions["code"], "Expected FORBIDDEN Code")
+			assert.Nil(t, result, "Expected Resolver Not To Run")
+		})
+	}
+}
+
+// scopeRepo records the scope it was created with and the proposals made through it. It holds a single word.
+type scopeRepo struct {
+	repository.Repository
+	scope     repository.Scope
+	word      models.Word
+	proposals []models.ChangeProposal
+}
+
+func (r *scopeRepo) WithScope(scope repository.Scope) repository.Repository {
+	return &scopeRepo{scope: scope, word: r.word}
+}
+
+func (r *scopeRepo) GetWordByID(wordID uint) (*models.Word, error) {
+	return &r.word, nil
+}
+
+func (r *scopeRepo) CreateChangeProposal(proposal models.ChangeProposal) (*models.ChangeProposal, error) {
+	proposal.ProposalID = uint(len(r.proposals) + 1)
+	r.proposals = append(r.proposals, proposal)
+	return &proposal, nil
+}
+
+func TestOwnedRepo(t *testing.T) {
+	viewer := &models.User{UserID: 7, Username: "ania", Role: models.RoleViewer, Group: "3b"}
+	resolver := &Resolver{Repo: &scopeRepo{}}
+	ctx := auth.WithUser(context.Background(), viewer)
+
+	private := model.VisibilityPrivate
+	owner, err := resolver.owner(ctx, &private)
+	require.NoError(t, err, "Expected Viewer To Create Private Entries")
+	assert.Equal(t, models.UserOwner(7), owner, "Expected Entries Owned By The User")
+	repo := resolver.OwnedRepo(ctx, owner)
+	assert.Equal(t, models.UserOwner(7), repo.(*scopeRepo).scope.Owner, "Expected Entries Owned By The User")
+	assert.Equal(t, viewer.Owners(), repo.(*scopeRepo).scope.Owners, "Expected The User And Group Entries To Be Visible")
+
+	group := model.VisibilityGroup
+	owner, err = resolver.owner(ctx, &group)
+	require.NoError(t, err, "Expected Viewer To Create Group Entries")
+	assert.Equal(t, models.GroupOwner("3b"), owner, "Expected Entries Owned By The Group")
+
+	owner, err = resolver.owner(ctx, nil)
+	require.NoError(t, err, "Expected Public Entries To Default To The Shared Dictionary")
+	assert.True(t, owner.Shared(), "Expected Shared Entries")
+
+	_, err = resolver.owner(auth.WithUser(context.Background(), &models.User{Role: models.RoleViewer}), &group)
+	assert.Error(t, err, "Expected Group Entries To Require A Group")
+}
+
+func TestAuthorizeChange(t *testing.T) {
+	ctx := auth.WithUser(context.Background(), &models.User{UserID: 7, Role: models.RoleViewer})
+	word := "kotek"
+	update := proposal.Change{
+		Action:    models.ActionUpdate,
+		EntryType: models.EntryWord,
+		EntryID:   1,
+		Fields:    proposal.Fields{PolishWord: &word},
+	}
+	remove := proposal.Change{Action: models.ActionDelete, EntryType: models.EntryWord, EntryID: 1}
+
+	private := &scopeRepo{word: models.Word{WordID: 1, PolishWord: "kot", Owner: models.UserOwner(7)}}
+	err := authorizeEntryChange(ctx, private, remove)
+	assert.NoError(t, err, "Expected Viewer To Delete Own Entries")
+	assert.Empty(t, private.proposals, "Expected No Proposal For Own Entries")
+
+	shared := &scopeRepo{word: models.Word{WordID: 1, PolishWord: "kot", Owner: models.SharedOwner}}
+	err = authorizeEntryChange(ctx, shared, update)
+	var gqlErr *gqlerror.Error
+	require.ErrorAs(t, err, &gqlErr, "Expected Viewer Not To Edit Shared Entries")
+	assert.Equal(t, ErrorCodeProposalPending, gqlErr.Extensions["code"], "Expected PROPOSAL_PENDING Code")
+	assert.Equal(t, "1", gqlErr.Extensions["proposalID"], "Expected The Proposal ID")
+	require.Equal(t, 1, len(shared.proposals), "Expected The Change To Be Proposed")
+	assert.Equal(t, []models.FieldChange{{Field: "polishWord", Before: "kot", After: "kotek"}}, shared.proposals[0].Diff,
+		"Expected The Diff Against The Current Word")
+
+	editor := auth.WithUser(context.Background(), &models.User{UserID: 8, Role: models.RoleEditor})
+	err = authorizeEntryChange(editor, shared, update)
+	assert.NoError(t, err, "Expected Editor To Edit Shared Entries")
+	err = authorizeEntryChange(editor, shared, remove)
+	require.ErrorAs(t, err, &gqlErr, "Expected Editor Not To Delete Shared Entries")
+	assert.Equal(t, ErrorCodeProposalPending, gqlErr.Extensions["code"], "Expected PROPOSAL_PENDING Code")
+
+	err = authorizeChange(context.Background(), shared, update, models.SharedOwner)
+	require.ErrorAs(t, err, &gqlErr, "Expected Anonymous Users Not To Propose Changes")
+	assert.Equal(t, ErrorCodeForbidden, gqlErr.Extensions["code"], "Expected FORBIDDEN Code")
+}
+
+func TestAuthorizeLearner(t *testing.T) {
+	ctx := auth.WithUser(context.Background(), &models.User{UserID: 7, Username: "ania", Role: models.RoleViewer})
+	assert.NoError(t, authorizeLearner(ctx, "ania"), "Expected Own Cards To Be Allowed")
+
+	for name, err := range map[string]error{
+		"Other Learner": authorizeLearner(ctx, "bartek"),
+		"Anonymous":     authorizeLearner(context.Background(), "ania"),
+	} {
+		var gqlErr *gqlerror.Error
+		require.ErrorAs(t, err, &gqlErr, "Expected A GraphQL Error For %s", name)
+		assert.Equal(t, ErrorCodeForbidden, gqlErr.Extensions["code"], "Expected FORBIDDEN Code For %s", name)
+	}
+}
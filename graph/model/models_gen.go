@@ -2,27 +2,1306 @@
 
 package model
 
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type AnswerResult struct {
+	Correct  bool     `json:"correct"`
+	Accepted []string `json:"accepted"`
+}
+
+type AudioRecording struct {
+	RecordingID string `json:"recordingID"`
+	WordID      string `json:"wordID"`
+	ContentType string `json:"contentType"`
+	Size        int32  `json:"size"`
+	URL         string `json:"url"`
+}
+
+type AuthPayload struct {
+	Token string `json:"token"`
+	User  *User  `json:"user"`
+}
+
+type ChangeProposal struct {
+	ProposalID string         `json:"proposalID"`
+	Action     ProposalAction `json:"action"`
+	EntryType  EntryType      `json:"entryType"`
+	EntryID    *string        `json:"entryID,omitempty"`
+	Diff       []*FieldChange `json:"diff"`
+	ProposedBy *User          `json:"proposedBy"`
+	Status     ProposalStatus `json:"status"`
+	Comment    *string        `json:"comment,omitempty"`
+	ReviewedBy *User          `json:"reviewedBy,omitempty"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	ReviewedAt *time.Time     `json:"reviewedAt,omitempty"`
+}
+
+type ChangeProposalConnection struct {
+	Edges      []*ChangeProposalEdge `json:"edges"`
+	PageInfo   *PageInfo             `json:"pageInfo"`
+	TotalCount int32                 `json:"totalCount"`
+}
+
+type ChangeProposalEdge struct {
+	Cursor string          `json:"cursor"`
+	Node   *ChangeProposal `json:"node"`
+}
+
 type ExampleSentence struct {
-	SentenceID    string `json:"sentenceID"`
-	SentenceText  string `json:"sentenceText"`
-	TranslationID string `json:"translationID"`
+	SentenceID    string      `json:"sentenceID"`
+	SentenceText  string      `json:"sentenceText"`
+	TranslationID string      `json:"translationID"`
+	Visibility    Visibility  `json:"visibility"`
+	History       []*Revision `json:"history"`
+	DeletedAt     *time.Time  `json:"deletedAt,omitempty"`
+}
+
+type ExampleSentenceConnection struct {
+	Edges      []*ExampleSentenceEdge `json:"edges"`
+	PageInfo   *PageInfo              `json:"pageInfo"`
+	TotalCount int32                  `json:"totalCount"`
+}
+
+type ExampleSentenceEdge struct {
+	Cursor string           `json:"cursor"`
+	Node   *ExampleSentence `json:"node"`
+}
+
+type FieldChange struct {
+	Field  string  `json:"field"`
+	Before *string `json:"before,omitempty"`
+	After  *string `json:"after,omitempty"`
+}
+
+type ImportReport struct {
+	Created  int32              `json:"created"`
+	Skipped  int32              `json:"skipped"`
+	Rejected int32              `json:"rejected"`
+	Rows     []*ImportRowResult `json:"rows"`
+}
+
+type ImportRowResult struct {
+	Line               int32           `json:"line"`
+	Status             ImportRowStatus `json:"status"`
+	PolishWord         *string         `json:"polishWord,omitempty"`
+	EnglishTranslation *string         `json:"englishTranslation,omitempty"`
+	Errors             []string        `json:"errors"`
+}
+
+type Inflection struct {
+	InflectionID string             `json:"inflectionID"`
+	WordID       string             `json:"wordID"`
+	Form         string             `json:"form"`
+	Case         *GrammaticalCase   `json:"case,omitempty"`
+	Number       *GrammaticalNumber `json:"number,omitempty"`
+	Person       *Person            `json:"person,omitempty"`
+	Tense        *Tense             `json:"tense,omitempty"`
+	Gender       *Gender            `json:"gender,omitempty"`
+}
+
+type InflectionRow struct {
+	Case        *GrammaticalCase `json:"case,omitempty"`
+	Person      *Person          `json:"person,omitempty"`
+	Singular    []*Inflection    `json:"singular"`
+	Plural      []*Inflection    `json:"plural"`
+	Unspecified []*Inflection    `json:"unspecified"`
+}
+
+type InflectionSection struct {
+	Tense  *Tense           `json:"tense,omitempty"`
+	Gender *Gender          `json:"gender,omitempty"`
+	Rows   []*InflectionRow `json:"rows"`
+}
+
+type InflectionTable struct {
+	Sections []*InflectionSection `json:"sections"`
+}
+
+type LookupResult struct {
+	Word       *Word              `json:"word"`
+	Method     LookupMethod       `json:"method"`
+	Inflection *Inflection        `json:"inflection,omitempty"`
+	Case       *GrammaticalCase   `json:"case,omitempty"`
+	Number     *GrammaticalNumber `json:"number,omitempty"`
+	Person     *Person            `json:"person,omitempty"`
+	Tense      *Tense             `json:"tense,omitempty"`
+	Gender     *Gender            `json:"gender,omitempty"`
+}
+
+type MergeSummary struct {
+	Word                   *Word `json:"word"`
+	MovedTranslations      int32 `json:"movedTranslations"`
+	MergedTranslations     int32 `json:"mergedTranslations"`
+	MovedExampleSentences  int32 `json:"movedExampleSentences"`
+	MergedExampleSentences int32 `json:"mergedExampleSentences"`
 }
 
 type Mutation struct {
 }
 
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
+}
+
 type Query struct {
 }
 
+type QuizQuestion struct {
+	QuestionID string         `json:"questionID"`
+	Type       QuizType       `json:"type"`
+	Direction  *QuizDirection `json:"direction,omitempty"`
+	Prompt     string         `json:"prompt"`
+	Hint       *string        `json:"hint,omitempty"`
+	Choices    []string       `json:"choices"`
+}
+
+type ReviewCard struct {
+	CardID       string       `json:"cardID"`
+	Learner      string       `json:"learner"`
+	Translation  *Translation `json:"translation"`
+	Word         *Word        `json:"word"`
+	Repetitions  int32        `json:"repetitions"`
+	IntervalDays int32        `json:"intervalDays"`
+	EaseFactor   float64      `json:"easeFactor"`
+	Due          time.Time    `json:"due"`
+	LastReviewed *time.Time   `json:"lastReviewed,omitempty"`
+}
+
+type Revision struct {
+	RevisionID string         `json:"revisionID"`
+	EntryType  EntryType      `json:"entryType"`
+	EntryID    string         `json:"entryID"`
+	Action     RevisionAction `json:"action"`
+	Changes    []*FieldChange `json:"changes"`
+	Author     *User          `json:"author,omitempty"`
+	CreatedAt  time.Time      `json:"createdAt"`
+}
+
 type Translation struct {
-	TranslationID      string             `json:"translationID"`
-	EnglishTranslation string             `json:"englishTranslation"`
-	WordID             string             `json:"wordID"`
-	ExampleSentences   []*ExampleSentence `json:"exampleSentences"`
+	TranslationID              string                     `json:"translationID"`
+	EnglishTranslation         string                     `json:"englishTranslation"`
+	WordID                     string                     `json:"wordID"`
+	Visibility                 Visibility                 `json:"visibility"`
+	ExampleSentences           []*ExampleSentence         `json:"exampleSentences"`
+	ExampleSentencesConnection *ExampleSentenceConnection `json:"exampleSentencesConnection"`
+	History                    []*Revision                `json:"history"`
+	DeletedAt                  *time.Time                 `json:"deletedAt,omitempty"`
+}
+
+type TranslationConnection struct {
+	Edges      []*TranslationEdge `json:"edges"`
+	PageInfo   *PageInfo          `json:"pageInfo"`
+	TotalCount int32              `json:"totalCount"`
+}
+
+type TranslationEdge struct {
+	Cursor string       `json:"cursor"`
+	Node   *Translation `json:"node"`
+}
+
+type TranslationFilter struct {
+	StartsWith          *string `json:"startsWith,omitempty"`
+	HasExampleSentences *bool   `json:"hasExampleSentences,omitempty"`
+}
+
+type TranslationOrder struct {
+	Field     TranslationOrderField `json:"field"`
+	Direction *OrderDirection       `json:"direction,omitempty"`
+}
+
+type Trash struct {
+	Words            []*Word            `json:"words"`
+	Translations     []*Translation     `json:"translations"`
+	ExampleSentences []*ExampleSentence `json:"exampleSentences"`
+}
+
+type User struct {
+	UserID   string  `json:"userID"`
+	Username string  `json:"username"`
+	Role     Role    `json:"role"`
+	Group    *string `json:"group,omitempty"`
 }
 
 type Word struct {
-	WordID       string         `json:"wordID"`
-	PolishWord   string         `json:"polishWord"`
-	Translations []*Translation `json:"translations"`
+	WordID                 string                 `json:"wordID"`
+	PolishWord             string                 `json:"polishWord"`
+	PartOfSpeech           *PartOfSpeech          `json:"partOfSpeech,omitempty"`
+	Gender                 *Gender                `json:"gender,omitempty"`
+	Aspect                 *Aspect                `json:"aspect,omitempty"`
+	Visibility             Visibility             `json:"visibility"`
+	Pronunciation          *string                `json:"pronunciation,omitempty"`
+	PronunciationOverride  *string                `json:"pronunciationOverride,omitempty"`
+	Audio                  []*AudioRecording      `json:"audio"`
+	Translations           []*Translation         `json:"translations"`
+	TranslationsConnection *TranslationConnection `json:"translationsConnection"`
+	Inflections            *InflectionTable       `json:"inflections"`
+	Related                []*WordRelation        `json:"related"`
+	History                []*Revision            `json:"history"`
+	DeletedAt              *time.Time             `json:"deletedAt,omitempty"`
+}
+
+type WordConnection struct {
+	Edges      []*WordEdge `json:"edges"`
+	PageInfo   *PageInfo   `json:"pageInfo"`
+	TotalCount int32       `json:"totalCount"`
+}
+
+type WordEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *Word  `json:"node"`
+}
+
+type WordFilter struct {
+	StartsWith          *string       `json:"startsWith,omitempty"`
+	HasTranslations     *bool         `json:"hasTranslations,omitempty"`
+	HasExampleSentences *bool         `json:"hasExampleSentences,omitempty"`
+	PartOfSpeech        *PartOfSpeech `json:"partOfSpeech,omitempty"`
+	Gender              *Gender       `json:"gender,omitempty"`
+	Aspect              *Aspect       `json:"aspect,omitempty"`
+}
+
+type WordOrder struct {
+	Field     WordOrderField  `json:"field"`
+	Direction *OrderDirection `json:"direction,omitempty"`
+}
+
+type WordRelation struct {
+	RelationID string       `json:"relationID"`
+	Type       RelationType `json:"type"`
+	Word       *Word        `json:"word"`
+	Inverse    bool         `json:"inverse"`
+}
+
+type WordSearchHit struct {
+	Word       *Word   `json:"word"`
+	Similarity float64 `json:"similarity"`
+}
+
+type Aspect string
+
+const (
+	AspectPerfective   Aspect = "PERFECTIVE"
+	AspectImperfective Aspect = "IMPERFECTIVE"
+)
+
+var AllAspect = []Aspect{
+	AspectPerfective,
+	AspectImperfective,
+}
+
+func (e Aspect) IsValid() bool {
+	switch e {
+	case AspectPerfective, AspectImperfective:
+		return true
+	}
+	return false
+}
+
+func (e Aspect) String() string {
+	return string(e)
+}
+
+func (e *Aspect) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Aspect(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Aspect", str)
+	}
+	return nil
+}
+
+func (e Aspect) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type EntryType string
+
+const (
+	EntryTypeWord            EntryType = "WORD"
+	EntryTypeTranslation     EntryType = "TRANSLATION"
+	EntryTypeExampleSentence EntryType = "EXAMPLE_SENTENCE"
+)
+
+var AllEntryType = []EntryType{
+	EntryTypeWord,
+	EntryTypeTranslation,
+	EntryTypeExampleSentence,
+}
+
+func (e EntryType) IsValid() bool {
+	switch e {
+	case EntryTypeWord, EntryTypeTranslation, EntryTypeExampleSentence:
+		return true
+	}
+	return false
+}
+
+func (e EntryType) String() string {
+	return string(e)
+}
+
+func (e *EntryType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EntryType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid EntryType", str)
+	}
+	return nil
+}
+
+func (e EntryType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Gender string
+
+const (
+	GenderMasculinePersonal  Gender = "MASCULINE_PERSONAL"
+	GenderMasculineAnimate   Gender = "MASCULINE_ANIMATE"
+	GenderMasculineInanimate Gender = "MASCULINE_INANIMATE"
+	GenderFeminine           Gender = "FEMININE"
+	GenderNeuter             Gender = "NEUTER"
+)
+
+var AllGender = []Gender{
+	GenderMasculinePersonal,
+	GenderMasculineAnimate,
+	GenderMasculineInanimate,
+	GenderFeminine,
+	GenderNeuter,
+}
+
+func (e Gender) IsValid() bool {
+	switch e {
+	case GenderMasculinePersonal, GenderMasculineAnimate, GenderMasculineInanimate, GenderFeminine, GenderNeuter:
+		return true
+	}
+	return false
+}
+
+func (e Gender) String() string {
+	return string(e)
+}
+
+func (e *Gender) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Gender(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Gender", str)
+	}
+	return nil
+}
+
+func (e Gender) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type GrammaticalCase string
+
+const (
+	GrammaticalCaseNominative   GrammaticalCase = "NOMINATIVE"
+	GrammaticalCaseGenitive     GrammaticalCase = "GENITIVE"
+	GrammaticalCaseDative       GrammaticalCase = "DATIVE"
+	GrammaticalCaseAccusative   GrammaticalCase = "ACCUSATIVE"
+	GrammaticalCaseInstrumental GrammaticalCase = "INSTRUMENTAL"
+	GrammaticalCaseLocative     GrammaticalCase = "LOCATIVE"
+	GrammaticalCaseVocative     GrammaticalCase = "VOCATIVE"
+)
+
+var AllGrammaticalCase = []GrammaticalCase{
+	GrammaticalCaseNominative,
+	GrammaticalCaseGenitive,
+	GrammaticalCaseDative,
+	GrammaticalCaseAccusative,
+	GrammaticalCaseInstrumental,
+	GrammaticalCaseLocative,
+	GrammaticalCaseVocative,
+}
+
+func (e GrammaticalCase) IsValid() bool {
+	switch e {
+	case GrammaticalCaseNominative, GrammaticalCaseGenitive, GrammaticalCaseDative, GrammaticalCaseAccusative, GrammaticalCaseInstrumental, GrammaticalCaseLocative, GrammaticalCaseVocative:
+		return true
+	}
+	return false
+}
+
+func (e GrammaticalCase) String() string {
+	return string(e)
+}
+
+func (e *GrammaticalCase) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = GrammaticalCase(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid GrammaticalCase", str)
+	}
+	return nil
+}
+
+func (e GrammaticalCase) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type GrammaticalNumber string
+
+const (
+	GrammaticalNumberSingular GrammaticalNumber = "SINGULAR"
+	GrammaticalNumberPlural   GrammaticalNumber = "PLURAL"
+)
+
+var AllGrammaticalNumber = []GrammaticalNumber{
+	GrammaticalNumberSingular,
+	GrammaticalNumberPlural,
+}
+
+func (e GrammaticalNumber) IsValid() bool {
+	switch e {
+	case GrammaticalNumberSingular, GrammaticalNumberPlural:
+		return true
+	}
+	return false
+}
+
+func (e GrammaticalNumber) String() string {
+	return string(e)
+}
+
+func (e *GrammaticalNumber) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = GrammaticalNumber(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid GrammaticalNumber", str)
+	}
+	return nil
+}
+
+func (e GrammaticalNumber) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ImportFormat string
+
+const (
+	ImportFormatCSV ImportFormat = "CSV"
+	ImportFormatTsv ImportFormat = "TSV"
+)
+
+var AllImportFormat = []ImportFormat{
+	ImportFormatCSV,
+	ImportFormatTsv,
+}
+
+func (e ImportFormat) IsValid() bool {
+	switch e {
+	case ImportFormatCSV, ImportFormatTsv:
+		return true
+	}
+	return false
+}
+
+func (e ImportFormat) String() string {
+	return string(e)
+}
+
+func (e *ImportFormat) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ImportFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ImportFormat", str)
+	}
+	return nil
+}
+
+func (e ImportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ImportRowStatus string
+
+const (
+	ImportRowStatusCreated  ImportRowStatus = "CREATED"
+	ImportRowStatusSkipped  ImportRowStatus = "SKIPPED"
+	ImportRowStatusRejected ImportRowStatus = "REJECTED"
+)
+
+var AllImportRowStatus = []ImportRowStatus{
+	ImportRowStatusCreated,
+	ImportRowStatusSkipped,
+	ImportRowStatusRejected,
+}
+
+func (e ImportRowStatus) IsValid() bool {
+	switch e {
+	case ImportRowStatusCreated, ImportRowStatusSkipped, ImportRowStatusRejected:
+		return true
+	}
+	return false
+}
+
+func (e ImportRowStatus) String() string {
+	return string(e)
+}
+
+func (e *ImportRowStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ImportRowStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ImportRowStatus", str)
+	}
+	return nil
+}
+
+func (e ImportRowStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type LookupMethod string
+
+const (
+	LookupMethodHeadword   LookupMethod = "HEADWORD"
+	LookupMethodInflection LookupMethod = "INFLECTION"
+	LookupMethodSuffixRule LookupMethod = "SUFFIX_RULE"
+)
+
+var AllLookupMethod = []LookupMethod{
+	LookupMethodHeadword,
+	LookupMethodInflection,
+	LookupMethodSuffixRule,
+}
+
+func (e LookupMethod) IsValid() bool {
+	switch e {
+	case LookupMethodHeadword, LookupMethodInflection, LookupMethodSuffixRule:
+		return true
+	}
+	return false
+}
+
+func (e LookupMethod) String() string {
+	return string(e)
+}
+
+func (e *LookupMethod) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LookupMethod(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LookupMethod", str)
+	}
+	return nil
+}
+
+func (e LookupMethod) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type OrderDirection string
+
+const (
+	OrderDirectionAsc  OrderDirection = "ASC"
+	OrderDirectionDesc OrderDirection = "DESC"
+)
+
+var AllOrderDirection = []OrderDirection{
+	OrderDirectionAsc,
+	OrderDirectionDesc,
+}
+
+func (e OrderDirection) IsValid() bool {
+	switch e {
+	case OrderDirectionAsc, OrderDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e OrderDirection) String() string {
+	return string(e)
+}
+
+func (e *OrderDirection) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderDirection", str)
+	}
+	return nil
+}
+
+func (e OrderDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type PartOfSpeech string
+
+const (
+	PartOfSpeechNoun         PartOfSpeech = "NOUN"
+	PartOfSpeechVerb         PartOfSpeech = "VERB"
+	PartOfSpeechAdjective    PartOfSpeech = "ADJECTIVE"
+	PartOfSpeechAdverb       PartOfSpeech = "ADVERB"
+	PartOfSpeechPronoun      PartOfSpeech = "PRONOUN"
+	PartOfSpeechNumeral      PartOfSpeech = "NUMERAL"
+	PartOfSpeechPreposition  PartOfSpeech = "PREPOSITION"
+	PartOfSpeechConjunction  PartOfSpeech = "CONJUNCTION"
+	PartOfSpeechParticle     PartOfSpeech = "PARTICLE"
+	PartOfSpeechInterjection PartOfSpeech = "INTERJECTION"
+)
+
+var AllPartOfSpeech = []PartOfSpeech{
+	PartOfSpeechNoun,
+	PartOfSpeechVerb,
+	PartOfSpeechAdjective,
+	PartOfSpeechAdverb,
+	PartOfSpeechPronoun,
+	PartOfSpeechNumeral,
+	PartOfSpeechPreposition,
+	PartOfSpeechConjunction,
+	PartOfSpeechParticle,
+	PartOfSpeechInterjection,
+}
+
+func (e PartOfSpeech) IsValid() bool {
+	switch e {
+	case PartOfSpeechNoun, PartOfSpeechVerb, PartOfSpeechAdjective, PartOfSpeechAdverb, PartOfSpeechPronoun, PartOfSpeechNumeral, PartOfSpeechPreposition, PartOfSpeechConjunction, PartOfSpeechParticle, PartOfSpeechInterjection:
+		return true
+	}
+	return false
+}
+
+func (e PartOfSpeech) String() string {
+	return string(e)
+}
+
+func (e *PartOfSpeech) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PartOfSpeech(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PartOfSpeech", str)
+	}
+	return nil
+}
+
+func (e PartOfSpeech) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Person string
+
+const (
+	PersonFirst  Person = "FIRST"
+	PersonSecond Person = "SECOND"
+	PersonThird  Person = "THIRD"
+)
+
+var AllPerson = []Person{
+	PersonFirst,
+	PersonSecond,
+	PersonThird,
+}
+
+func (e Person) IsValid() bool {
+	switch e {
+	case PersonFirst, PersonSecond, PersonThird:
+		return true
+	}
+	return false
+}
+
+func (e Person) String() string {
+	return string(e)
+}
+
+func (e *Person) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Person(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Person", str)
+	}
+	return nil
+}
+
+func (e Person) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ProposalAction string
+
+const (
+	ProposalActionCreate  ProposalAction = "CREATE"
+	ProposalActionUpdate  ProposalAction = "UPDATE"
+	ProposalActionDelete  ProposalAction = "DELETE"
+	ProposalActionPromote ProposalAction = "PROMOTE"
+	ProposalActionRevert  ProposalAction = "REVERT"
+)
+
+var AllProposalAction = []ProposalAction{
+	ProposalActionCreate,
+	ProposalActionUpdate,
+	ProposalActionDelete,
+	ProposalActionPromote,
+	ProposalActionRevert,
+}
+
+func (e ProposalAction) IsValid() bool {
+	switch e {
+	case ProposalActionCreate, ProposalActionUpdate, ProposalActionDelete, ProposalActionPromote, ProposalActionRevert:
+		return true
+	}
+	return false
+}
+
+func (e ProposalAction) String() string {
+	return string(e)
+}
+
+func (e *ProposalAction) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProposalAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ProposalAction", str)
+	}
+	return nil
+}
+
+func (e ProposalAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ProposalStatus string
+
+const (
+	ProposalStatusPending  ProposalStatus = "PENDING"
+	ProposalStatusApproved ProposalStatus = "APPROVED"
+	ProposalStatusRejected ProposalStatus = "REJECTED"
+)
+
+var AllProposalStatus = []ProposalStatus{
+	ProposalStatusPending,
+	ProposalStatusApproved,
+	ProposalStatusRejected,
+}
+
+func (e ProposalStatus) IsValid() bool {
+	switch e {
+	case ProposalStatusPending, ProposalStatusApproved, ProposalStatusRejected:
+		return true
+	}
+	return false
+}
+
+func (e ProposalStatus) String() string {
+	return string(e)
+}
+
+func (e *ProposalStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProposalStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ProposalStatus", str)
+	}
+	return nil
+}
+
+func (e ProposalStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type QuizDirection string
+
+const (
+	QuizDirectionPolishToEnglish QuizDirection = "POLISH_TO_ENGLISH"
+	QuizDirectionEnglishToPolish QuizDirection = "ENGLISH_TO_POLISH"
+)
+
+var AllQuizDirection = []QuizDirection{
+	QuizDirectionPolishToEnglish,
+	QuizDirectionEnglishToPolish,
+}
+
+func (e QuizDirection) IsValid() bool {
+	switch e {
+	case QuizDirectionPolishToEnglish, QuizDirectionEnglishToPolish:
+		return true
+	}
+	return false
+}
+
+func (e QuizDirection) String() string {
+	return string(e)
+}
+
+func (e *QuizDirection) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = QuizDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid QuizDirection", str)
+	}
+	return nil
+}
+
+func (e QuizDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type QuizType string
+
+const (
+	QuizTypeMultipleChoice QuizType = "MULTIPLE_CHOICE"
+	QuizTypeTypedAnswer    QuizType = "TYPED_ANSWER"
+	QuizTypeCloze          QuizType = "CLOZE"
+)
+
+var AllQuizType = []QuizType{
+	QuizTypeMultipleChoice,
+	QuizTypeTypedAnswer,
+	QuizTypeCloze,
+}
+
+func (e QuizType) IsValid() bool {
+	switch e {
+	case QuizTypeMultipleChoice, QuizTypeTypedAnswer, QuizTypeCloze:
+		return true
+	}
+	return false
+}
+
+func (e QuizType) String() string {
+	return string(e)
+}
+
+func (e *QuizType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = QuizType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid QuizType", str)
+	}
+	return nil
+}
+
+func (e QuizType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type RelationType string
+
+const (
+	RelationTypeSynonym      RelationType = "SYNONYM"
+	RelationTypeAntonym      RelationType = "ANTONYM"
+	RelationTypeDiminutive   RelationType = "DIMINUTIVE"
+	RelationTypeAugmentative RelationType = "AUGMENTATIVE"
+	RelationTypeDerivedFrom  RelationType = "DERIVED_FROM"
+	RelationTypeSeeAlso      RelationType = "SEE_ALSO"
+)
+
+var AllRelationType = []RelationType{
+	RelationTypeSynonym,
+	RelationTypeAntonym,
+	RelationTypeDiminutive,
+	RelationTypeAugmentative,
+	RelationTypeDerivedFrom,
+	RelationTypeSeeAlso,
+}
+
+func (e RelationType) IsValid() bool {
+	switch e {
+	case RelationTypeSynonym, RelationTypeAntonym, RelationTypeDiminutive, RelationTypeAugmentative, RelationTypeDerivedFrom, RelationTypeSeeAlso:
+		return true
+	}
+	return false
+}
+
+func (e RelationType) String() string {
+	return string(e)
+}
+
+func (e *RelationType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RelationType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RelationType", str)
+	}
+	return nil
+}
+
+func (e RelationType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type RevisionAction string
+
+const (
+	RevisionActionCreate  RevisionAction = "CREATE"
+	RevisionActionUpdate  RevisionAction = "UPDATE"
+	RevisionActionDelete  RevisionAction = "DELETE"
+	RevisionActionRestore RevisionAction = "RESTORE"
+)
+
+var AllRevisionAction = []RevisionAction{
+	RevisionActionCreate,
+	RevisionActionUpdate,
+	RevisionActionDelete,
+	RevisionActionRestore,
+}
+
+func (e RevisionAction) IsValid() bool {
+	switch e {
+	case RevisionActionCreate, RevisionActionUpdate, RevisionActionDelete, RevisionActionRestore:
+		return true
+	}
+	return false
+}
+
+func (e RevisionAction) String() string {
+	return string(e)
+}
+
+func (e *RevisionAction) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RevisionAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RevisionAction", str)
+	}
+	return nil
+}
+
+func (e RevisionAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Role string
+
+const (
+	RoleViewer Role = "VIEWER"
+	RoleEditor Role = "EDITOR"
+	RoleAdmin  Role = "ADMIN"
+)
+
+var AllRole = []Role{
+	RoleViewer,
+	RoleEditor,
+	RoleAdmin,
+}
+
+func (e Role) IsValid() bool {
+	switch e {
+	case RoleViewer, RoleEditor, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+func (e *Role) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Role(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Role", str)
+	}
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SearchMode string
+
+const (
+	SearchModePrefix    SearchMode = "PREFIX"
+	SearchModeSubstring SearchMode = "SUBSTRING"
+	SearchModeFuzzy     SearchMode = "FUZZY"
+)
+
+var AllSearchMode = []SearchMode{
+	SearchModePrefix,
+	SearchModeSubstring,
+	SearchModeFuzzy,
+}
+
+func (e SearchMode) IsValid() bool {
+	switch e {
+	case SearchModePrefix, SearchModeSubstring, SearchModeFuzzy:
+		return true
+	}
+	return false
+}
+
+func (e SearchMode) String() string {
+	return string(e)
+}
+
+func (e *SearchMode) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SearchMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SearchMode", str)
+	}
+	return nil
+}
+
+func (e SearchMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Tense string
+
+const (
+	TensePresent Tense = "PRESENT"
+	TensePast    Tense = "PAST"
+	TenseFuture  Tense = "FUTURE"
+)
+
+var AllTense = []Tense{
+	TensePresent,
+	TensePast,
+	TenseFuture,
+}
+
+func (e Tense) IsValid() bool {
+	switch e {
+	case TensePresent, TensePast, TenseFuture:
+		return true
+	}
+	return false
+}
+
+func (e Tense) String() string {
+	return string(e)
+}
+
+func (e *Tense) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Tense(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Tense", str)
+	}
+	return nil
+}
+
+func (e Tense) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TranslationOrderField string
+
+const (
+	TranslationOrderFieldID                   TranslationOrderField = "ID"
+	TranslationOrderFieldEnglishTranslation   TranslationOrderField = "ENGLISH_TRANSLATION"
+	TranslationOrderFieldCreatedAt            TranslationOrderField = "CREATED_AT"
+	TranslationOrderFieldExampleSentenceCount TranslationOrderField = "EXAMPLE_SENTENCE_COUNT"
+)
+
+var AllTranslationOrderField = []TranslationOrderField{
+	TranslationOrderFieldID,
+	TranslationOrderFieldEnglishTranslation,
+	TranslationOrderFieldCreatedAt,
+	TranslationOrderFieldExampleSentenceCount,
+}
+
+func (e TranslationOrderField) IsValid() bool {
+	switch e {
+	case TranslationOrderFieldID, TranslationOrderFieldEnglishTranslation, TranslationOrderFieldCreatedAt, TranslationOrderFieldExampleSentenceCount:
+		return true
+	}
+	return false
+}
+
+func (e TranslationOrderField) String() string {
+	return string(e)
+}
+
+func (e *TranslationOrderField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TranslationOrderField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TranslationOrderField", str)
+	}
+	return nil
+}
+
+func (e TranslationOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Visibility string
+
+const (
+	VisibilityPublic  Visibility = "PUBLIC"
+	VisibilityPrivate Visibility = "PRIVATE"
+	VisibilityGroup   Visibility = "GROUP"
+)
+
+var AllVisibility = []Visibility{
+	VisibilityPublic,
+	VisibilityPrivate,
+	VisibilityGroup,
+}
+
+func (e Visibility) IsValid() bool {
+	switch e {
+	case VisibilityPublic, VisibilityPrivate, VisibilityGroup:
+		return true
+	}
+	return false
+}
+
+func (e Visibility) String() string {
+	return string(e)
+}
+
+func (e *Visibility) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Visibility(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Visibility", str)
+	}
+	return nil
+}
+
+func (e Visibility) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type WordOrderField string
+
+const (
+	WordOrderFieldID               WordOrderField = "ID"
+	WordOrderFieldPolishWord       WordOrderField = "POLISH_WORD"
+	WordOrderFieldCreatedAt        WordOrderField = "CREATED_AT"
+	WordOrderFieldTranslationCount WordOrderField = "TRANSLATION_COUNT"
+)
+
+var AllWordOrderField = []WordOrderField{
+	WordOrderFieldID,
+	WordOrderFieldPolishWord,
+	WordOrderFieldCreatedAt,
+	WordOrderFieldTranslationCount,
+}
+
+func (e WordOrderField) IsValid() bool {
+	switch e {
+	case WordOrderFieldID, WordOrderFieldPolishWord, WordOrderFieldCreatedAt, WordOrderFieldTranslationCount:
+		return true
+	}
+	return false
+}
+
+func (e WordOrderField) String() string {
+	return string(e)
+}
+
+func (e *WordOrderField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WordOrderField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WordOrderField", str)
+	}
+	return nil
+}
+
+func (e WordOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
@@ -1,7 +1,20 @@
 package graph
 
 import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/sar-michal/dictionary-app/graph/model"
+	"github.com/sar-michal/dictionary-app/pkg/auth"
+	"github.com/sar-michal/dictionary-app/pkg/importer"
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/proposal"
+	"github.com/sar-michal/dictionary-app/pkg/quiz"
 	"github.com/sar-michal/dictionary-app/pkg/repository"
+	"github.com/sar-michal/dictionary-app/pkg/srs"
 )
 
 // This file will not be regenerated automatically.
@@ -10,4 +23,124 @@ import (
 
 type Resolver struct {
 	Repo repository.Repository
+	// Tokens signs the tokens issued at login.
+	Tokens *auth.Tokens
+	// Clock schedules reviews. The system clock is used when it is nil.
+	Clock srs.Clock
+}
+
+// CurrentUser returns the user signed in for the request, or nil for anonymous requests.
+// The user is put into the context by auth.Middleware.
+func (r *Resolver) CurrentUser(ctx context.Context) *models.User {
+	return auth.UserFromContext(ctx)
+}
+
+// ScopedRepo returns the dictionary seen by the signed-in user: the shared dictionary merged with
+// the private entries of the user and their group. Anonymous users only see the shared dictionary.
+func (r *Resolver) ScopedRepo(ctx context.Context) repository.Repository {
+	return r.Repo.WithScope(r.scope(ctx))
+}
+
+// OwnedRepo returns the dictionary seen by the signed-in user that creates entries with the given owner.
+func (r *Resolver) OwnedRepo(ctx context.Context, owner models.Owner) repository.Repository {
+	scope := r.scope(ctx)
+	scope.Owner = owner
+	return r.Repo.WithScope(scope)
+}
+
+// owner returns the owner of entries created by the signed-in user with the given visibility.
+// PUBLIC entries belong to the shared dictionary.
+func (r *Resolver) owner(ctx context.Context, visibility *model.Visibility) (models.Owner, error) {
+	user := r.CurrentUser(ctx)
+	switch {
+	case visibility == nil || *visibility == model.VisibilityPublic:
+		return models.SharedOwner, nil
+	case user == nil:
+		return "", requireRole(ctx, models.RoleViewer)
+	case *visibility == model.VisibilityPrivate:
+		return models.UserOwner(user.UserID), nil
+	case *visibility == model.VisibilityGroup:
+		if user.Group == "" {
+			return "", fmt.Errorf("user %q does not belong to a group", user.Username)
+		}
+		return models.GroupOwner(user.Group), nil
+	}
+	return "", fmt.Errorf("unknown visibility: %q", *visibility)
+}
+
+// scope returns the repository scope of the signed-in user, who is the author of the changes made through it.
+func (r *Resolver) scope(ctx context.Context) repository.Scope {
+	user := r.CurrentUser(ctx)
+	if user == nil {
+		return repository.Scope{}
+	}
+	return repository.Scope{Owners: user.Owners(), Author: user.UserID}
+}
+
+// reviewProposal approves or rejects a change proposal on behalf of the signed-in editor.
+// Approving requires the role needed to make the change directly.
+func (r *Resolver) reviewProposal(ctx context.Context, proposalID string, approve bool, comment *string) (*model.ChangeProposal, error) {
+	id, err := strconv.ParseUint(proposalID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proposalID: %w", err)
+	}
+
+	var validComment string
+	if comment != nil {
+		validComment, err = validateInput(*comment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate comment: %w", err)
+		}
+	}
+
+	if approve {
+		pending, err := r.Repo.GetChangeProposalByID(uint(id))
+		if err != nil {
+			return nil, fmt.Errorf("failed to find proposal: %w", err)
+		}
+		change, err := proposal.Decode(pending)
+		if err != nil {
+			return nil, err
+		}
+		if err := requireRole(ctx, proposal.RequiredRole(change)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Approved changes are made to the shared dictionary on behalf of the reviewer.
+	reviewer := r.CurrentUser(ctx)
+	repo := r.Repo.WithScope(repository.Scope{Author: reviewer.UserID})
+	reviewed, err := proposal.Review(repo, uint(id), reviewer.UserID, approve, validComment, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to review proposal: %w", err)
+	}
+	return convertChangeProposal(reviewed), nil
+}
+
+// Scheduler returns the spaced repetition scheduler of study reviews.
+func (r *Resolver) Scheduler() srs.Scheduler {
+	clock := r.Clock
+	if clock == nil {
+		clock = srs.SystemClock
+	}
+	return srs.Scheduler{Clock: clock}
+}
+
+// defaultQuizSize is the number of quiz questions generated when no size is given.
+const defaultQuizSize = 10
+
+// QuizGenerator returns a quiz generator asking about the dictionary seen by the signed-in user.
+// The same seed gives the same quiz; without one the quiz is random.
+func (r *Resolver) QuizGenerator(ctx context.Context, seed *int32) *quiz.Generator {
+	source := rand.Uint64()
+	if seed != nil {
+		source = uint64(*seed)
+	}
+	return &quiz.Generator{Repo: r.ScopedRepo(ctx), Rand: rand.New(rand.NewPCG(source, source))}
+}
+
+// Importer returns a dictionary importer that validates values the same way as the mutations.
+// Entries are imported into the shared dictionary seen by the signed-in user, who is recorded as their author.
+func (r *Resolver) Importer(ctx context.Context) *importer.Importer {
+	return &importer.Importer{Repo: r.ScopedRepo(ctx), Validate: validateInput}
 }
@@ -9,19 +9,149 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/sar-michal/dictionary-app/graph/model"
+	"github.com/sar-michal/dictionary-app/pkg/auth"
+	"github.com/sar-michal/dictionary-app/pkg/g2p"
+	"github.com/sar-michal/dictionary-app/pkg/merge"
 	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/proposal"
+	"github.com/sar-michal/dictionary-app/pkg/quiz"
 	"github.com/sar-michal/dictionary-app/pkg/repository"
+	"github.com/sar-michal/dictionary-app/pkg/srs"
 )
 
+// History is the resolver for the history field.
+func (r *exampleSentenceResolver) History(ctx context.Context, obj *model.ExampleSentence) ([]*model.Revision, error) {
+	id, err := strconv.ParseUint(obj.SentenceID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sentenceID: %w", err)
+	}
+
+	revisions, err := r.ScopedRepo(ctx).ListRevisions(models.EntryExampleSentence, uint(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", err)
+	}
+	return convertRevisions(revisions), nil
+}
+
+// Register is the resolver for the register field.
+func (r *mutationResolver) Register(ctx context.Context, username string, password string) (*model.AuthPayload, error) {
+	validUsername, err := validateInput(username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate username: %w", err)
+	}
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *models.User
+	err = r.Repo.Transaction(func(txRepo repository.Repository) error {
+		if _, err := txRepo.GetUserByUsername(validUsername); err == nil {
+			return fmt.Errorf("username %q is already taken", validUsername)
+		}
+		user, err = txRepo.CreateUser(validUsername, hash)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register: %w", err)
+	}
+	token, err := r.Tokens.Issue(user)
+	if err != nil {
+		return nil, err
+	}
+	return &model.AuthPayload{Token: token, User: convertUser(user)}, nil
+}
+
+// Login is the resolver for the login field.
+func (r *mutationResolver) Login(ctx context.Context, username string, password string) (*model.AuthPayload, error) {
+	user, token, err := auth.Login(r.Repo, r.Tokens, sanitizeInput(username), password)
+	if err != nil {
+		return nil, err
+	}
+	return &model.AuthPayload{Token: token, User: convertUser(user)}, nil
+}
+
+// SetUserRole is the resolver for the setUserRole field.
+func (r *mutationResolver) SetUserRole(ctx context.Context, userID string, role model.Role) (*model.User, error) {
+	id, err := strconv.ParseUint(userID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid userID: %w", err)
+	}
+
+	user, err := r.Repo.UpdateUserRole(uint(id), enumToModels[models.Role](&role))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set user role: %w", err)
+	}
+	return convertUser(user), nil
+}
+
+// SetUserGroup is the resolver for the setUserGroup field.
+func (r *mutationResolver) SetUserGroup(ctx context.Context, userID string, group *string) (*model.User, error) {
+	id, err := strconv.ParseUint(userID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid userID: %w", err)
+	}
+
+	validGroup := ""
+	if group != nil {
+		validGroup, err = validateInput(*group)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate group: %w", err)
+		}
+	}
+
+	user, err := r.Repo.UpdateUserGroup(uint(id), validGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set user group: %w", err)
+	}
+	return convertUser(user), nil
+}
+
 // CreateWord is the resolver for the createWord field.
-func (r *mutationResolver) CreateWord(ctx context.Context, polishWord string) (*model.Word, error) {
+func (r *mutationResolver) CreateWord(ctx context.Context, polishWord string, ignoreDiacritics *bool, partOfSpeech *model.PartOfSpeech, gender *model.Gender, aspect *model.Aspect, visibility *model.Visibility) (*model.Word, error) {
 	validWord, err := validateInput(polishWord)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate polish word: %w", err)
 	}
 
-	word, err := r.Repo.GetOrCreateWord(validWord)
+	grammar, err := validateGrammar(convertGrammar(partOfSpeech, gender, aspect))
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate grammar: %w", err)
+	}
+
+	owner, err := r.owner(ctx, visibility)
+	if err != nil {
+		return nil, err
+	}
+	repo := r.OwnedRepo(ctx, owner)
+	change := proposal.Change{
+		Action:    models.ActionCreate,
+		EntryType: models.EntryWord,
+		Fields: proposal.Fields{
+			PolishWord:       &validWord,
+			IgnoreDiacritics: ignoreDiacritics != nil && *ignoreDiacritics,
+			Grammar:          &grammar,
+		},
+	}
+	if err := authorizeChange(ctx, repo, change, owner); err != nil {
+		return nil, err
+	}
+
+	if change.Fields.IgnoreDiacritics {
+		candidates, err := repo.GetOrCreateWordFolded(validWord, grammar)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create word: %w", err)
+		}
+		word, err := pickFoldedCandidate(validWord, candidates)
+		if err != nil {
+			return nil, err
+		}
+		return convertWord(word), nil
+	}
+
+	word, err := repo.GetOrCreateWord(validWord, grammar)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create word: %w", err)
 	}
@@ -40,13 +170,84 @@ func (r *mutationResolver) UpdateWord(ctx context.Context, wordID string, newPol
 		return nil, fmt.Errorf("failed to validate new polish word: %w", err)
 	}
 
-	word, err := r.Repo.UpdateWord(uint(id), validWord)
+	repo := r.ScopedRepo(ctx)
+	change := proposal.Change{
+		Action:    models.ActionUpdate,
+		EntryType: models.EntryWord,
+		EntryID:   uint(id),
+		Fields:    proposal.Fields{PolishWord: &validWord},
+	}
+	if err := authorizeEntryChange(ctx, repo, change); err != nil {
+		return nil, err
+	}
+
+	word, err := repo.UpdateWord(uint(id), validWord)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update word: %w", err)
 	}
 	return convertWord(word), nil
 }
 
+// UpdateWordGrammar is the resolver for the updateWordGrammar field.
+func (r *mutationResolver) UpdateWordGrammar(ctx context.Context, wordID string, partOfSpeech *model.PartOfSpeech, gender *model.Gender, aspect *model.Aspect) (*model.Word, error) {
+	id, err := strconv.ParseUint(wordID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wordID: %w", err)
+	}
+
+	grammar, err := validateGrammar(convertGrammar(partOfSpeech, gender, aspect))
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate grammar: %w", err)
+	}
+
+	repo := r.ScopedRepo(ctx)
+	change := proposal.Change{
+		Action:    models.ActionUpdate,
+		EntryType: models.EntryWord,
+		EntryID:   uint(id),
+		Fields:    proposal.Fields{Grammar: &grammar},
+	}
+	if err := authorizeEntryChange(ctx, repo, change); err != nil {
+		return nil, err
+	}
+
+	word, err := repo.UpdateWordGrammar(uint(id), grammar)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update word grammar: %w", err)
+	}
+	return convertWord(word), nil
+}
+
+// UpdateWordPronunciation is the resolver for the updateWordPronunciation field.
+func (r *mutationResolver) UpdateWordPronunciation(ctx context.Context, wordID string, pronunciation *string) (*model.Word, error) {
+	id, err := strconv.ParseUint(wordID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wordID: %w", err)
+	}
+
+	validPronunciation, err := validatePronunciation(pronunciation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate pronunciation: %w", err)
+	}
+
+	repo := r.ScopedRepo(ctx)
+	change := proposal.Change{
+		Action:    models.ActionUpdate,
+		EntryType: models.EntryWord,
+		EntryID:   uint(id),
+		Fields:    proposal.Fields{Pronunciation: &validPronunciation},
+	}
+	if err := authorizeEntryChange(ctx, repo, change); err != nil {
+		return nil, err
+	}
+
+	word, err := repo.UpdateWordPronunciation(uint(id), validPronunciation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pronunciation: %w", err)
+	}
+	return convertWord(word), nil
+}
+
 // DeleteWord is the resolver for the deleteWord field.
 func (r *mutationResolver) DeleteWord(ctx context.Context, wordID string) (bool, error) {
 	id, err := strconv.ParseUint(wordID, 10, 64)
@@ -54,14 +255,65 @@ func (r *mutationResolver) DeleteWord(ctx context.Context, wordID string) (bool,
 		return false, fmt.Errorf("invaild wordID: %w", err)
 	}
 
-	if err := r.Repo.DeleteWord(uint(id)); err != nil {
+	repo := r.ScopedRepo(ctx)
+	change := proposal.Change{
+		Action:    models.ActionDelete,
+		EntryType: models.EntryWord,
+		EntryID:   uint(id),
+	}
+	if err := authorizeEntryChange(ctx, repo, change); err != nil {
+		return false, err
+	}
+
+	if err := repo.DeleteWord(uint(id)); err != nil {
 		return false, fmt.Errorf("failed to delete word: %w", err)
 	}
 	return true, nil
 }
 
+// UploadAudio is the resolver for the uploadAudio field.
+func (r *mutationResolver) UploadAudio(ctx context.Context, wordID string, file graphql.Upload) (*model.AudioRecording, error) {
+	id, err := strconv.ParseUint(wordID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wordID: %w", err)
+	}
+
+	data, contentType, err := validateAudio(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate audio: %w", err)
+	}
+
+	recording, err := r.ScopedRepo(ctx).CreateAudioRecording(uint(id), contentType, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save audio: %w", err)
+	}
+	return convertAudioRecording(recording), nil
+}
+
+// ImportDictionary is the resolver for the importDictionary field.
+func (r *mutationResolver) ImportDictionary(ctx context.Context, file graphql.Upload, format model.ImportFormat) (*model.ImportReport, error) {
+	report, err := r.Importer(ctx).Import(file.File, convertImportFormat(format))
+	if err != nil {
+		return nil, fmt.Errorf("failed to import dictionary: %w", err)
+	}
+	return convertImportReport(report), nil
+}
+
+// DeleteAudio is the resolver for the deleteAudio field.
+func (r *mutationResolver) DeleteAudio(ctx context.Context, recordingID string) (bool, error) {
+	id, err := strconv.ParseUint(recordingID, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid recordingID: %w", err)
+	}
+
+	if err := r.ScopedRepo(ctx).DeleteAudioRecording(uint(id)); err != nil {
+		return false, fmt.Errorf("failed to delete audio: %w", err)
+	}
+	return true, nil
+}
+
 // CreateTranslationWithWord is the resolver for the CreateTranslationWithWord field.
-func (r *mutationResolver) CreateTranslationWithWord(ctx context.Context, polishWord string, englishTranslation string, exampleSentences []string) (*model.Translation, error) {
+func (r *mutationResolver) CreateTranslationWithWord(ctx context.Context, polishWord string, englishTranslation string, exampleSentences []string, partOfSpeech *model.PartOfSpeech, gender *model.Gender, aspect *model.Aspect, visibility *model.Visibility) (*model.Translation, error) {
 	validWord, err := validateInput(polishWord)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate polish word: %w", err)
@@ -71,6 +323,12 @@ func (r *mutationResolver) CreateTranslationWithWord(ctx context.Context, polish
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate english translation: %w", err)
 	}
+
+	grammar, err := validateGrammar(convertGrammar(partOfSpeech, gender, aspect))
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate grammar: %w", err)
+	}
+
 	validSentences := make([]string, 0, len(exampleSentences))
 	for _, sentence := range exampleSentences {
 		validSentence, err := validateInput(sentence)
@@ -80,9 +338,28 @@ func (r *mutationResolver) CreateTranslationWithWord(ctx context.Context, polish
 		validSentences = append(validSentences, validSentence)
 	}
 
+	owner, err := r.owner(ctx, visibility)
+	if err != nil {
+		return nil, err
+	}
+	repo := r.OwnedRepo(ctx, owner)
+	change := proposal.Change{
+		Action:    models.ActionCreate,
+		EntryType: models.EntryTranslation,
+		Fields: proposal.Fields{
+			PolishWord:         &validWord,
+			Grammar:            &grammar,
+			EnglishTranslation: &validTranslation,
+			ExampleSentences:   validSentences,
+		},
+	}
+	if err := authorizeChange(ctx, repo, change, owner); err != nil {
+		return nil, err
+	}
+
 	var resultTranslation *models.Translation
-	err = r.Repo.Transaction(func(txRepo repository.Repository) error {
-		word, err := txRepo.GetOrCreateWord(validWord)
+	err = repo.Transaction(func(txRepo repository.Repository) error {
+		word, err := txRepo.GetOrCreateWord(validWord, grammar)
 		if err != nil {
 			return fmt.Errorf("failed to get or create word: %w", err)
 		}
@@ -114,7 +391,7 @@ func (r *mutationResolver) CreateTranslationWithWord(ctx context.Context, polish
 }
 
 // CreateTranslation is the resolver for the CreateTranslation field.
-func (r *mutationResolver) CreateTranslation(ctx context.Context, wordID string, englishTranslation string, exampleSentences []string) (*model.Translation, error) {
+func (r *mutationResolver) CreateTranslation(ctx context.Context, wordID string, englishTranslation string, exampleSentences []string, visibility *model.Visibility) (*model.Translation, error) {
 	id, err := strconv.ParseUint(wordID, 10, 64)
 	if err != nil {
 		return nil, fmt.Errorf("invalid wordID: %w", err)
@@ -134,8 +411,23 @@ func (r *mutationResolver) CreateTranslation(ctx context.Context, wordID string,
 		validSentences = append(validSentences, validSentence)
 	}
 
+	owner, err := r.owner(ctx, visibility)
+	if err != nil {
+		return nil, err
+	}
+	repo := r.OwnedRepo(ctx, owner)
+	change := proposal.Change{
+		Action:    models.ActionCreate,
+		EntryType: models.EntryTranslation,
+		EntryID:   uint(id),
+		Fields:    proposal.Fields{EnglishTranslation: &validTranslation, ExampleSentences: validSentences},
+	}
+	if err := authorizeChange(ctx, repo, change, owner); err != nil {
+		return nil, err
+	}
+
 	var resultTranslation *models.Translation
-	err = r.Repo.Transaction(func(txRepo repository.Repository) error {
+	err = repo.Transaction(func(txRepo repository.Repository) error {
 		translation, err := txRepo.GetOrCreateTranslation(uint(id), validTranslation)
 		if err != nil {
 			return fmt.Errorf("failed to create translation: %w", err)
@@ -174,7 +466,18 @@ func (r *mutationResolver) UpdateTranslation(ctx context.Context, translationID
 		return nil, fmt.Errorf("failed to validate english translation: %w", err)
 	}
 
-	translation, err := r.Repo.UpdateTranslation(uint(id), validTranslation)
+	repo := r.ScopedRepo(ctx)
+	change := proposal.Change{
+		Action:    models.ActionUpdate,
+		EntryType: models.EntryTranslation,
+		EntryID:   uint(id),
+		Fields:    proposal.Fields{EnglishTranslation: &validTranslation},
+	}
+	if err := authorizeEntryChange(ctx, repo, change); err != nil {
+		return nil, err
+	}
+
+	translation, err := repo.UpdateTranslation(uint(id), validTranslation)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update translation: %w", err)
 	}
@@ -188,14 +491,24 @@ func (r *mutationResolver) DeleteTranslation(ctx context.Context, translationID
 		return false, fmt.Errorf("invalid translationID: %w", err)
 	}
 
-	if err := r.Repo.DeleteTranslation(uint(id)); err != nil {
+	repo := r.ScopedRepo(ctx)
+	change := proposal.Change{
+		Action:    models.ActionDelete,
+		EntryType: models.EntryTranslation,
+		EntryID:   uint(id),
+	}
+	if err := authorizeEntryChange(ctx, repo, change); err != nil {
+		return false, err
+	}
+
+	if err := repo.DeleteTranslation(uint(id)); err != nil {
 		return false, fmt.Errorf("failed to delete translation: %w", err)
 	}
 	return true, nil
 }
 
 // CreateExampleSentence is the resolver for the CreateExampleSentence field.
-func (r *mutationResolver) CreateExampleSentence(ctx context.Context, translationID string, sentenceText string) (*model.ExampleSentence, error) {
+func (r *mutationResolver) CreateExampleSentence(ctx context.Context, translationID string, sentenceText string, visibility *model.Visibility) (*model.ExampleSentence, error) {
 	id, err := strconv.ParseUint(translationID, 10, 64)
 	if err != nil {
 		return nil, fmt.Errorf("invalid translationID: %w", err)
@@ -206,7 +519,22 @@ func (r *mutationResolver) CreateExampleSentence(ctx context.Context, translatio
 		return nil, fmt.Errorf("failed to validate example sentence: %w", err)
 	}
 
-	sentence, err := r.Repo.GetOrCreateExampleSentence(uint(id), validSentence)
+	owner, err := r.owner(ctx, visibility)
+	if err != nil {
+		return nil, err
+	}
+	repo := r.OwnedRepo(ctx, owner)
+	change := proposal.Change{
+		Action:    models.ActionCreate,
+		EntryType: models.EntryExampleSentence,
+		EntryID:   uint(id),
+		Fields:    proposal.Fields{SentenceText: &validSentence},
+	}
+	if err := authorizeChange(ctx, repo, change, owner); err != nil {
+		return nil, err
+	}
+
+	sentence, err := repo.GetOrCreateExampleSentence(uint(id), validSentence)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create example sentence: %w", err)
 	}
@@ -225,7 +553,18 @@ func (r *mutationResolver) UpdateExampleSentence(ctx context.Context, sentenceID
 		return nil, fmt.Errorf("failed to validate example sentence: %w", err)
 	}
 
-	updatedSentence, err := r.Repo.UpdateExampleSentence(uint(id), validSentence)
+	repo := r.ScopedRepo(ctx)
+	change := proposal.Change{
+		Action:    models.ActionUpdate,
+		EntryType: models.EntryExampleSentence,
+		EntryID:   uint(id),
+		Fields:    proposal.Fields{SentenceText: &validSentence},
+	}
+	if err := authorizeEntryChange(ctx, repo, change); err != nil {
+		return nil, err
+	}
+
+	updatedSentence, err := repo.UpdateExampleSentence(uint(id), validSentence)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update example sentence: %w", err)
 	}
@@ -240,116 +579,871 @@ func (r *mutationResolver) DeleteExampleSentence(ctx context.Context, sentenceID
 		return false, fmt.Errorf("invalid sentenceID: %w", err)
 	}
 
-	if err := r.Repo.DeleteExampleSentence(uint(id)); err != nil {
+	repo := r.ScopedRepo(ctx)
+	change := proposal.Change{
+		Action:    models.ActionDelete,
+		EntryType: models.EntryExampleSentence,
+		EntryID:   uint(id),
+	}
+	if err := authorizeEntryChange(ctx, repo, change); err != nil {
+		return false, err
+	}
+
+	if err := repo.DeleteExampleSentence(uint(id)); err != nil {
 		return false, fmt.Errorf("failed to delete example sentence: %w", err)
 	}
 
 	return true, nil
 }
 
-// Words is the resolver for the words field.
-func (r *queryResolver) Words(ctx context.Context) ([]*model.Word, error) {
-	words, err := r.Repo.ListWords()
+// ProposePromotion is the resolver for the proposePromotion field.
+func (r *mutationResolver) ProposePromotion(ctx context.Context, entryType model.EntryType, entryID string) (*model.ChangeProposal, error) {
+	id, err := strconv.ParseUint(entryID, 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list words: %w", err)
+		return nil, fmt.Errorf("invalid entryID: %w", err)
 	}
 
-	return convertWords(words), nil
+	change := proposal.Change{
+		Action:    models.ActionPromote,
+		EntryType: enumToModels[models.EntryType](&entryType),
+		EntryID:   uint(id),
+	}
+	user := r.CurrentUser(ctx)
+	proposed, err := proposal.Propose(r.ScopedRepo(ctx), change, user.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to propose promotion: %w", err)
+	}
+	return convertChangeProposal(proposed), nil
 }
 
-// WordByPolish is the resolver for the wordByPolish field.
-func (r *queryResolver) WordByPolish(ctx context.Context, polishWord string) (*model.Word, error) {
-	validWord, err := validateInput(polishWord)
+// ApproveProposal is the resolver for the approveProposal field.
+func (r *mutationResolver) ApproveProposal(ctx context.Context, proposalID string, comment *string) (*model.ChangeProposal, error) {
+	return r.reviewProposal(ctx, proposalID, true, comment)
+}
+
+// RejectProposal is the resolver for the rejectProposal field.
+func (r *mutationResolver) RejectProposal(ctx context.Context, proposalID string, comment *string) (*model.ChangeProposal, error) {
+	return r.reviewProposal(ctx, proposalID, false, comment)
+}
+
+// RestoreWord is the resolver for the restoreWord field.
+func (r *mutationResolver) RestoreWord(ctx context.Context, wordID string) (*model.Word, error) {
+	id, err := strconv.ParseUint(wordID, 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate polish word: %w", err)
+		return nil, fmt.Errorf("invalid wordID: %w", err)
 	}
 
-	word, err := r.Repo.GetWordByPolish(validWord)
+	// Restoring a PUBLIC word requires the admin role. Its owner is only known once it is restored.
+	var restored *models.Word
+	err = r.ScopedRepo(ctx).Transaction(func(txRepo repository.Repository) error {
+		restored, err = txRepo.RestoreWord(uint(id))
+		if err != nil {
+			return fmt.Errorf("failed to restore word: %w", err)
+		}
+		if restored.Owner.Shared() {
+			return requireRole(ctx, models.RoleAdmin)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get word by polish: %w", err)
+		return nil, err
 	}
-	return convertWord(word), nil
+	return convertWord(restored), nil
 }
 
-// WordByID is the resolver for the wordByID field.
-func (r *queryResolver) WordByID(ctx context.Context, wordID string) (*model.Word, error) {
-	id, err := strconv.ParseUint(wordID, 10, 64)
+// RestoreTranslation is the resolver for the restoreTranslation field.
+func (r *mutationResolver) RestoreTranslation(ctx context.Context, translationID string) (*model.Translation, error) {
+	id, err := strconv.ParseUint(translationID, 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("invalid wordID: %w", err)
+		return nil, fmt.Errorf("invalid translationID: %w", err)
 	}
 
-	word, err := r.Repo.GetWordByID(uint(id))
+	// Restoring a PUBLIC translation requires the admin role. Its owner is only known once it is restored.
+	var restored *models.Translation
+	err = r.ScopedRepo(ctx).Transaction(func(txRepo repository.Repository) error {
+		restored, err = txRepo.RestoreTranslation(uint(id))
+		if err != nil {
+			return fmt.Errorf("failed to restore translation: %w", err)
+		}
+		if restored.Owner.Shared() {
+			return requireRole(ctx, models.RoleAdmin)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get word by id: %w", err)
+		return nil, err
 	}
-	return convertWord(word), nil
+	return convertTranslation(restored), nil
 }
 
-// Translations is the resolver for the translations field.
-func (r *queryResolver) Translations(ctx context.Context, wordID string) ([]*model.Translation, error) {
-	id, err := strconv.ParseUint(wordID, 10, 64)
+// RestoreExampleSentence is the resolver for the restoreExampleSentence field.
+func (r *mutationResolver) RestoreExampleSentence(ctx context.Context, sentenceID string) (*model.ExampleSentence, error) {
+	id, err := strconv.ParseUint(sentenceID, 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("invalid wordID: %w", err)
+		return nil, fmt.Errorf("invalid sentenceID: %w", err)
 	}
 
-	translations, err := r.Repo.ListTranslations(uint(id))
+	// Restoring a PUBLIC example sentence requires the admin role. Its owner is only known once it is restored.
+	var restored *models.ExampleSentence
+	err = r.ScopedRepo(ctx).Transaction(func(txRepo repository.Repository) error {
+		restored, err = txRepo.RestoreExampleSentence(uint(id))
+		if err != nil {
+			return fmt.Errorf("failed to restore example sentence: %w", err)
+		}
+		if restored.Owner.Shared() {
+			return requireRole(ctx, models.RoleAdmin)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list translations: %w", err)
+		return nil, err
 	}
-
-	return convertTranslations(translations), nil
+	return convertExampleSentence(restored), nil
 }
 
-// TranslationByID is the resolver for the translationByID field.
-func (r *queryResolver) TranslationByID(ctx context.Context, translationID string) (*model.Translation, error) {
-	id, err := strconv.ParseUint(translationID, 10, 64)
+// MergeWords is the resolver for the mergeWords field.
+func (r *mutationResolver) MergeWords(ctx context.Context, sourceID string, targetID string) (*model.MergeSummary, error) {
+	source, err := strconv.ParseUint(sourceID, 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("invalid translationID: %w", err)
+		return nil, fmt.Errorf("invalid sourceID: %w", err)
+	}
+	target, err := strconv.ParseUint(targetID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid targetID: %w", err)
 	}
 
-	translation, err := r.Repo.GetTranslationByID(uint(id))
+	repo := r.ScopedRepo(ctx)
+	word, err := repo.GetWordByID(uint(source))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get translation by ID: %w", err)
+		return nil, fmt.Errorf("failed to find source word: %w", err)
+	}
+	// The source word is deleted by the merge.
+	if word.Owner.Shared() {
+		if err := requireRole(ctx, models.RoleAdmin); err != nil {
+			return nil, err
+		}
 	}
 
-	return convertTranslation(translation), nil
+	summary, err := merge.MergeWords(repo, uint(source), uint(target))
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge words: %w", err)
+	}
+	merged, err := repo.GetWordByID(uint(target))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find target word: %w", err)
+	}
+	return convertMergeSummary(summary, merged), nil
 }
 
-// ExampleSentences is the resolver for the exampleSentences field.
-func (r *queryResolver) ExampleSentences(ctx context.Context, translationID string) ([]*model.ExampleSentence, error) {
+// MoveTranslation is the resolver for the moveTranslation field.
+func (r *mutationResolver) MoveTranslation(ctx context.Context, translationID string, toWordID string) (*model.MergeSummary, error) {
 	id, err := strconv.ParseUint(translationID, 10, 64)
 	if err != nil {
 		return nil, fmt.Errorf("invalid translationID: %w", err)
 	}
+	target, err := strconv.ParseUint(toWordID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid toWordID: %w", err)
+	}
 
-	sentences, err := r.Repo.ListExampleSentences(uint(id))
+	repo := r.ScopedRepo(ctx)
+	translation, err := repo.GetTranslationByID(uint(id))
 	if err != nil {
-		return nil, fmt.Errorf("failed to list example sentences: %w", err)
+		return nil, fmt.Errorf("failed to find translation: %w", err)
+	}
+	if translation.Owner.Shared() {
+		if err := requireRole(ctx, models.RoleEditor); err != nil {
+			return nil, err
+		}
+		word, err := repo.GetWordByID(uint(target))
+		if err != nil {
+			return nil, fmt.Errorf("failed to find target word: %w", err)
+		}
+		// Merging into an equal translation deletes the moved one.
+		if merge.MergesInto(translation, word) {
+			if err := requireRole(ctx, models.RoleAdmin); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	return convertExampleSentences(sentences), nil
+	summary, err := merge.MoveTranslation(repo, uint(id), uint(target))
+	if err != nil {
+		return nil, fmt.Errorf("failed to move translation: %w", err)
+	}
+	word, err := repo.GetWordByID(uint(target))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find target word: %w", err)
+	}
+	return convertMergeSummary(summary, word), nil
 }
 
-// ExampleSentenceByID is the resolver for the exampleSentenceByID field.
-func (r *queryResolver) ExampleSentenceByID(ctx context.Context, sentenceID string) (*model.ExampleSentence, error) {
-	id, err := strconv.ParseUint(sentenceID, 10, 64)
+// RevertToRevision is the resolver for the revertToRevision field.
+func (r *mutationResolver) RevertToRevision(ctx context.Context, revisionID string) (*model.Revision, error) {
+	id, err := strconv.ParseUint(revisionID, 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("invalid sentenceID: %w", err)
+		return nil, fmt.Errorf("invalid revisionID: %w", err)
 	}
 
-	sentence, err := r.Repo.GetExampleSentenceByID(uint(id))
+	repo := r.ScopedRepo(ctx)
+	revision, err := repo.GetRevisionByID(uint(id))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get example sentence by ID: %w", err)
+		return nil, fmt.Errorf("failed to find revision: %w", err)
+	}
+	owner, deleted, err := revisionEntryOwner(repo, revision)
+	if err != nil {
+		return nil, err
+	}
+	// Restoring a PUBLIC entry from the trash requires the admin role, as in the restore mutations.
+	if deleted && owner.Shared() {
+		if err := requireRole(ctx, models.RoleAdmin); err != nil {
+			return nil, err
+		}
+	}
+	change := proposal.Change{
+		Action:     models.ActionRevert,
+		EntryType:  revision.EntryType,
+		EntryID:    revision.EntryID,
+		RevisionID: revision.RevisionID,
+	}
+	if err := authorizeChange(ctx, repo, change, owner); err != nil {
+		return nil, err
 	}
 
-	return convertExampleSentence(sentence), nil
+	reverted, err := repo.RevertToRevision(uint(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to revert to revision: %w", err)
+	}
+	return convertRevision(reverted), nil
 }
 
-// Mutation returns MutationResolver implementation.
-func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+// CreateInflection is the resolver for the createInflection field.
+func (r *mutationResolver) CreateInflection(ctx context.Context, wordID string, form string, caseArg *model.GrammaticalCase, number *model.GrammaticalNumber, person *model.Person, tense *model.Tense, gender *model.Gender) (*model.Inflection, error) {
+	id, err := strconv.ParseUint(wordID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wordID: %w", err)
+	}
 
-// Query returns QueryResolver implementation.
-func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+	validForm, err := validateInput(form)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate form: %w", err)
+	}
+
+	tags := convertInflectionTags(caseArg, number, person, tense, gender)
+	inflection, err := r.ScopedRepo(ctx).GetOrCreateInflection(uint(id), validForm, tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inflection: %w", err)
+	}
+	return convertInflection(inflection), nil
+}
+
+// UpdateInflection is the resolver for the updateInflection field.
+func (r *mutationResolver) UpdateInflection(ctx context.Context, inflectionID string, newForm string, caseArg *model.GrammaticalCase, number *model.GrammaticalNumber, person *model.Person, tense *model.Tense, gender *model.Gender) (*model.Inflection, error) {
+	id, err := strconv.ParseUint(inflectionID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid inflectionID: %w", err)
+	}
+
+	validForm, err := validateInput(newForm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate form: %w", err)
+	}
+
+	tags := convertInflectionTags(caseArg, number, person, tense, gender)
+	inflection, err := r.ScopedRepo(ctx).UpdateInflection(uint(id), validForm, tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update inflection: %w", err)
+	}
+	return convertInflection(inflection), nil
+}
+
+// DeleteInflection is the resolver for the deleteInflection field.
+func (r *mutationResolver) DeleteInflection(ctx context.Context, inflectionID string) (bool, error) {
+	id, err := strconv.ParseUint(inflectionID, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid inflectionID: %w", err)
+	}
+
+	if err := r.ScopedRepo(ctx).DeleteInflection(uint(id)); err != nil {
+		return false, fmt.Errorf("failed to delete inflection: %w", err)
+	}
+	return true, nil
+}
+
+// CreateWordRelation is the resolver for the createWordRelation field.
+func (r *mutationResolver) CreateWordRelation(ctx context.Context, wordID string, relatedWordID string, typeArg model.RelationType) (*model.WordRelation, error) {
+	id, err := strconv.ParseUint(wordID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wordID: %w", err)
+	}
+	relatedID, err := strconv.ParseUint(relatedWordID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid relatedWordID: %w", err)
+	}
+
+	relation, err := r.ScopedRepo(ctx).GetOrCreateWordRelation(uint(id), uint(relatedID), *convertRelationType(&typeArg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create word relation: %w", err)
+	}
+	return convertWordRelation(relation, uint(id)), nil
+}
+
+// UpdateWordRelation is the resolver for the updateWordRelation field.
+func (r *mutationResolver) UpdateWordRelation(ctx context.Context, relationID string, newType model.RelationType) (*model.WordRelation, error) {
+	id, err := strconv.ParseUint(relationID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid relationID: %w", err)
+	}
+
+	relation, err := r.ScopedRepo(ctx).UpdateWordRelation(uint(id), *convertRelationType(&newType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update word relation: %w", err)
+	}
+	return convertWordRelation(relation, relation.WordID), nil
+}
+
+// DeleteWordRelation is the resolver for the deleteWordRelation field.
+func (r *mutationResolver) DeleteWordRelation(ctx context.Context, relationID string) (bool, error) {
+	id, err := strconv.ParseUint(relationID, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid relationID: %w", err)
+	}
+
+	if err := r.ScopedRepo(ctx).DeleteWordRelation(uint(id)); err != nil {
+		return false, fmt.Errorf("failed to delete word relation: %w", err)
+	}
+	return true, nil
+}
+
+// AddToStudy is the resolver for the addToStudy field.
+func (r *mutationResolver) AddToStudy(ctx context.Context, translationID string) (*model.ReviewCard, error) {
+	id, err := strconv.ParseUint(translationID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid translationID: %w", err)
+	}
+
+	repo := r.ScopedRepo(ctx)
+	if _, err := repo.GetTranslationByID(uint(id)); err != nil {
+		return nil, fmt.Errorf("failed to find translation: %w", err)
+	}
+	learner := r.CurrentUser(ctx).Username
+	card, err := repo.GetOrCreateReviewCard(learner, uint(id), r.Scheduler().New())
+	if err != nil {
+		return nil, fmt.Errorf("failed to add translation to study: %w", err)
+	}
+	return convertReviewCard(card), nil
+}
+
+// SubmitReview is the resolver for the submitReview field.
+func (r *mutationResolver) SubmitReview(ctx context.Context, cardID string, grade int32) (*model.ReviewCard, error) {
+	id, err := strconv.ParseUint(cardID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cardID: %w", err)
+	}
+	if !srs.Grade(grade).Valid() {
+		return nil, fmt.Errorf("grade must be between %d and %d", srs.MinGrade, srs.MaxGrade)
+	}
+
+	repo := r.ScopedRepo(ctx)
+	current, err := repo.GetReviewCardByID(uint(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find card: %w", err)
+	}
+	if err := authorizeLearner(ctx, current.Learner); err != nil {
+		return nil, err
+	}
+
+	scheduler := r.Scheduler()
+	var card *models.ReviewCard
+	err = repo.Transaction(func(txRepo repository.Repository) error {
+		current, err := txRepo.GetReviewCardByID(uint(id))
+		if err != nil {
+			return err
+		}
+		state, err := scheduler.Review(current.State(), srs.Grade(grade))
+		if err != nil {
+			return err
+		}
+		card, err = txRepo.RecordReview(uint(id), srs.Grade(grade), state, scheduler.Clock.Now())
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit review: %w", err)
+	}
+	return convertReviewCard(card), nil
+}
+
+// CheckAnswer is the resolver for the checkAnswer field.
+func (r *mutationResolver) CheckAnswer(ctx context.Context, questionID string, answer string) (*model.AnswerResult, error) {
+	result, err := quiz.Check(r.ScopedRepo(ctx), questionID, answer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check answer: %w", err)
+	}
+	return &model.AnswerResult{Correct: result.Correct, Accepted: result.Accepted}, nil
+}
+
+// Me is the resolver for the me field.
+func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
+	user := r.CurrentUser(ctx)
+	if user == nil {
+		return nil, nil
+	}
+	return convertUser(user), nil
+}
+
+// Users is the resolver for the users field.
+func (r *queryResolver) Users(ctx context.Context) ([]*model.User, error) {
+	users, err := r.Repo.ListUsers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	gqlUsers := make([]*model.User, len(users))
+	for i := range users {
+		gqlUsers[i] = convertUser(&users[i])
+	}
+	return gqlUsers, nil
+}
+
+// PendingProposals is the resolver for the pendingProposals field.
+func (r *queryResolver) PendingProposals(ctx context.Context, first *int32, after *string, last *int32, before *string) (*model.ChangeProposalConnection, error) {
+	page, err := validatePageArgs(first, after, last, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate pagination: %w", err)
+	}
+
+	proposals, err := r.Repo.ListChangeProposalsPage(models.ProposalPending, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending proposals: %w", err)
+	}
+	return convertChangeProposalConnection(proposals), nil
+}
+
+// Trash is the resolver for the trash field.
+func (r *queryResolver) Trash(ctx context.Context) (*model.Trash, error) {
+	trash, err := r.ScopedRepo(ctx).ListTrash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trash: %w", err)
+	}
+	return convertTrash(trash), nil
+}
+
+// Words is the resolver for the words field.
+func (r *queryResolver) Words(ctx context.Context, orderBy *model.WordOrder, filter *model.WordFilter) ([]*model.Word, error) {
+	words, err := r.ScopedRepo(ctx).ListWords(convertWordOrder(orderBy), convertWordFilter(filter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list words: %w", err)
+	}
+
+	return convertWords(words), nil
+}
+
+// WordsConnection is the resolver for the wordsConnection field.
+func (r *queryResolver) WordsConnection(ctx context.Context, first *int32, after *string, last *int32, before *string, orderBy *model.WordOrder, filter *model.WordFilter) (*model.WordConnection, error) {
+	page, err := validatePageArgs(first, after, last, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate pagination: %w", err)
+	}
+
+	words, err := r.ScopedRepo(ctx).ListWordsPage(convertWordOrder(orderBy), convertWordFilter(filter), page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list words: %w", err)
+	}
+	return convertWordConnection(words), nil
+}
+
+// WordByPolish is the resolver for the wordByPolish field.
+func (r *queryResolver) WordByPolish(ctx context.Context, polishWord string, partOfSpeech *model.PartOfSpeech) (*model.Word, error) {
+	validWord, err := validateInput(polishWord)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate polish word: %w", err)
+	}
+
+	word, err := r.ScopedRepo(ctx).GetWordByPolish(validWord, convertPartOfSpeech(partOfSpeech))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word by polish: %w", err)
+	}
+	return convertWord(word), nil
+}
+
+// WordsByPolish is the resolver for the wordsByPolish field.
+func (r *queryResolver) WordsByPolish(ctx context.Context, polishWord string) ([]*model.Word, error) {
+	validWord, err := validateInput(polishWord)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate polish word: %w", err)
+	}
+
+	words, err := r.ScopedRepo(ctx).ListWordsByFoldedPolish(validWord)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list words by polish: %w", err)
+	}
+	return convertWords(words), nil
+}
+
+// WordsByEnglish is the resolver for the wordsByEnglish field.
+func (r *queryResolver) WordsByEnglish(ctx context.Context, englishTranslation string) ([]*model.Word, error) {
+	validTranslation, err := validateInput(englishTranslation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate english translation: %w", err)
+	}
+
+	words, err := r.ScopedRepo(ctx).ListWordsByEnglish(validTranslation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list words by english: %w", err)
+	}
+	return convertWords(words), nil
+}
+
+// Lookup is the resolver for the lookup field.
+func (r *queryResolver) Lookup(ctx context.Context, form string) ([]*model.LookupResult, error) {
+	validForm, err := validateInput(form)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate form: %w", err)
+	}
+
+	matches, err := r.ScopedRepo(ctx).LookupForm(validForm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up form: %w", err)
+	}
+	return convertFormMatches(matches), nil
+}
+
+// Transcribe is the resolver for the transcribe field.
+func (r *queryResolver) Transcribe(ctx context.Context, text string) (string, error) {
+	validText, err := validateInput(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to validate text: %w", err)
+	}
+	return g2p.Transcribe(validText), nil
+}
+
+// SearchWords is the resolver for the searchWords field.
+func (r *queryResolver) SearchWords(ctx context.Context, query string, mode *model.SearchMode, limit *int32) ([]*model.WordSearchHit, error) {
+	validQuery, err := validateInput(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate search query: %w", err)
+	}
+
+	searchMode := repository.SearchPrefix
+	if mode != nil {
+		searchMode = convertSearchMode(*mode)
+	}
+
+	validLimit, err := validateLimit(limit, repository.DefaultSearchLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate limit: %w", err)
+	}
+
+	hits, err := r.ScopedRepo(ctx).SearchWords(validQuery, searchMode, validLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search words: %w", err)
+	}
+	return convertWordSearchHits(hits), nil
+}
+
+// WordByID is the resolver for the wordByID field.
+func (r *queryResolver) WordByID(ctx context.Context, wordID string) (*model.Word, error) {
+	id, err := strconv.ParseUint(wordID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wordID: %w", err)
+	}
+
+	word, err := r.ScopedRepo(ctx).GetWordByID(uint(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word by id: %w", err)
+	}
+	return convertWord(word), nil
+}
+
+// Translations is the resolver for the translations field.
+func (r *queryResolver) Translations(ctx context.Context, wordID string, orderBy *model.TranslationOrder, filter *model.TranslationFilter) ([]*model.Translation, error) {
+	id, err := strconv.ParseUint(wordID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wordID: %w", err)
+	}
+
+	translations, err := r.ScopedRepo(ctx).ListTranslations(uint(id), convertTranslationOrder(orderBy), convertTranslationFilter(filter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translations: %w", err)
+	}
+
+	return convertTranslations(translations), nil
+}
+
+// TranslationByID is the resolver for the translationByID field.
+func (r *queryResolver) TranslationByID(ctx context.Context, translationID string) (*model.Translation, error) {
+	id, err := strconv.ParseUint(translationID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid translationID: %w", err)
+	}
+
+	translation, err := r.ScopedRepo(ctx).GetTranslationByID(uint(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translation by ID: %w", err)
+	}
+
+	return convertTranslation(translation), nil
+}
+
+// ExampleSentences is the resolver for the exampleSentences field.
+func (r *queryResolver) ExampleSentences(ctx context.Context, translationID string) ([]*model.ExampleSentence, error) {
+	id, err := strconv.ParseUint(translationID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid translationID: %w", err)
+	}
+
+	sentences, err := r.ScopedRepo(ctx).ListExampleSentences(uint(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list example sentences: %w", err)
+	}
+
+	return convertExampleSentences(sentences), nil
+}
+
+// ExampleSentenceByID is the resolver for the exampleSentenceByID field.
+func (r *queryResolver) ExampleSentenceByID(ctx context.Context, sentenceID string) (*model.ExampleSentence, error) {
+	id, err := strconv.ParseUint(sentenceID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sentenceID: %w", err)
+	}
+
+	sentence, err := r.ScopedRepo(ctx).GetExampleSentenceByID(uint(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get example sentence by ID: %w", err)
+	}
+
+	return convertExampleSentence(sentence), nil
+}
+
+// InflectionByID is the resolver for the inflectionByID field.
+func (r *queryResolver) InflectionByID(ctx context.Context, inflectionID string) (*model.Inflection, error) {
+	id, err := strconv.ParseUint(inflectionID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid inflectionID: %w", err)
+	}
+
+	inflection, err := r.ScopedRepo(ctx).GetInflectionByID(uint(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inflection by ID: %w", err)
+	}
+	return convertInflection(inflection), nil
+}
+
+// DueCards is the resolver for the dueCards field.
+func (r *queryResolver) DueCards(ctx context.Context, limit *int32) ([]*model.ReviewCard, error) {
+	validLimit, err := validateLimit(limit, repository.DefaultDueCardsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate limit: %w", err)
+	}
+
+	learner := r.CurrentUser(ctx).Username
+	cards, err := r.ScopedRepo(ctx).ListDueReviewCards(learner, r.Scheduler().Clock.Now(), validLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due cards: %w", err)
+	}
+	return convertReviewCards(cards), nil
+}
+
+// GenerateQuiz is the resolver for the generateQuiz field.
+func (r *queryResolver) GenerateQuiz(ctx context.Context, size *int32, direction *model.QuizDirection, typeArg *model.QuizType, seed *int32) ([]*model.QuizQuestion, error) {
+	quizSize := defaultQuizSize
+	if size != nil {
+		quizSize = int(*size)
+	}
+	quizDirection := quiz.PolishToEnglish
+	if direction != nil {
+		quizDirection = convertQuizDirection(*direction)
+	}
+	quizType := quiz.MultipleChoice
+	if typeArg != nil {
+		quizType = convertQuizType(*typeArg)
+	}
+
+	questions, err := r.QuizGenerator(ctx, seed).Generate(quizSize, quizDirection, quizType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate quiz: %w", err)
+	}
+	return convertQuizQuestions(questions), nil
+}
+
+// Word is the resolver for the word field.
+func (r *reviewCardResolver) Word(ctx context.Context, obj *model.ReviewCard) (*model.Word, error) {
+	id, err := strconv.ParseUint(obj.Translation.WordID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wordID: %w", err)
+	}
+
+	word, err := r.ScopedRepo(ctx).GetWordByID(uint(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word by ID: %w", err)
+	}
+	return convertWord(word), nil
+}
+
+// ExampleSentences is the resolver for the exampleSentences field.
+func (r *translationResolver) ExampleSentences(ctx context.Context, obj *model.Translation) ([]*model.ExampleSentence, error) {
+	if obj.ExampleSentences != nil {
+		return obj.ExampleSentences, nil
+	}
+
+	id, err := strconv.ParseUint(obj.TranslationID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid translationID: %w", err)
+	}
+
+	sentences, err := r.ScopedRepo(ctx).ListExampleSentences(uint(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list example sentences: %w", err)
+	}
+	return convertExampleSentences(sentences), nil
+}
+
+// ExampleSentencesConnection is the resolver for the exampleSentencesConnection field.
+func (r *translationResolver) ExampleSentencesConnection(ctx context.Context, obj *model.Translation, first *int32, after *string, last *int32, before *string) (*model.ExampleSentenceConnection, error) {
+	id, err := strconv.ParseUint(obj.TranslationID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid translationID: %w", err)
+	}
+
+	page, err := validatePageArgs(first, after, last, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate pagination: %w", err)
+	}
+
+	sentences, err := r.ScopedRepo(ctx).ListExampleSentencesPage(uint(id), page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list example sentences: %w", err)
+	}
+	return convertExampleSentenceConnection(sentences), nil
+}
+
+// History is the resolver for the history field.
+func (r *translationResolver) History(ctx context.Context, obj *model.Translation) ([]*model.Revision, error) {
+	id, err := strconv.ParseUint(obj.TranslationID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid translationID: %w", err)
+	}
+
+	revisions, err := r.ScopedRepo(ctx).ListRevisions(models.EntryTranslation, uint(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", err)
+	}
+	return convertRevisions(revisions), nil
+}
+
+// Audio is the resolver for the audio field.
+func (r *wordResolver) Audio(ctx context.Context, obj *model.Word) ([]*model.AudioRecording, error) {
+	id, err := strconv.ParseUint(obj.WordID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wordID: %w", err)
+	}
+
+	recordings, err := r.ScopedRepo(ctx).ListAudioRecordings(uint(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audio recordings: %w", err)
+	}
+	return convertAudioRecordings(recordings), nil
+}
+
+// Translations is the resolver for the translations field.
+func (r *wordResolver) Translations(ctx context.Context, obj *model.Word) ([]*model.Translation, error) {
+	if obj.Translations != nil {
+		return obj.Translations, nil
+	}
+
+	id, err := strconv.ParseUint(obj.WordID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wordID: %w", err)
+	}
+
+	translations, err := r.ScopedRepo(ctx).ListTranslations(uint(id), repository.TranslationOrder{}, repository.TranslationFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translations: %w", err)
+	}
+	return convertTranslations(translations), nil
+}
+
+// TranslationsConnection is the resolver for the translationsConnection field.
+func (r *wordResolver) TranslationsConnection(ctx context.Context, obj *model.Word, first *int32, after *string, last *int32, before *string) (*model.TranslationConnection, error) {
+	id, err := strconv.ParseUint(obj.WordID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wordID: %w", err)
+	}
+
+	page, err := validatePageArgs(first, after, last, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate pagination: %w", err)
+	}
+
+	translations, err := r.ScopedRepo(ctx).ListTranslationsPage(uint(id), page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translations: %w", err)
+	}
+	return convertTranslationConnection(translations), nil
+}
+
+// Inflections is the resolver for the inflections field.
+func (r *wordResolver) Inflections(ctx context.Context, obj *model.Word) (*model.InflectionTable, error) {
+	id, err := strconv.ParseUint(obj.WordID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wordID: %w", err)
+	}
+
+	inflections, err := r.ScopedRepo(ctx).ListInflections(uint(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inflections: %w", err)
+	}
+	return buildInflectionTable(inflections), nil
+}
+
+// Related is the resolver for the related field.
+func (r *wordResolver) Related(ctx context.Context, obj *model.Word, typeArg *model.RelationType) ([]*model.WordRelation, error) {
+	id, err := strconv.ParseUint(obj.WordID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wordID: %w", err)
+	}
+
+	relations, err := r.ScopedRepo(ctx).ListWordRelations(uint(id), convertRelationType(typeArg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list word relations: %w", err)
+	}
+	return convertWordRelations(relations, uint(id)), nil
+}
+
+// History is the resolver for the history field.
+func (r *wordResolver) History(ctx context.Context, obj *model.Word) ([]*model.Revision, error) {
+	id, err := strconv.ParseUint(obj.WordID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wordID: %w", err)
+	}
+
+	revisions, err := r.ScopedRepo(ctx).ListRevisions(models.EntryWord, uint(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", err)
+	}
+	return convertRevisions(revisions), nil
+}
+
+// ExampleSentence returns ExampleSentenceResolver implementation.
+func (r *Resolver) ExampleSentence() ExampleSentenceResolver { return &exampleSentenceResolver{r} }
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// ReviewCard returns ReviewCardResolver implementation.
+func (r *Resolver) ReviewCard() ReviewCardResolver { return &reviewCardResolver{r} }
+
+// Translation returns TranslationResolver implementation.
+func (r *Resolver) Translation() TranslationResolver { return &translationResolver{r} }
+
+// Word returns WordResolver implementation.
+func (r *Resolver) Word() WordResolver { return &wordResolver{r} }
 
+type exampleSentenceResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type reviewCardResolver struct{ *Resolver }
+type translationResolver struct{ *Resolver }
+type wordResolver struct{ *Resolver }
@@ -2,8 +2,15 @@ package graph
 
 import (
 	"fmt"
+	"io"
+	"net/http"
 	"regexp"
 	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
 )
 
 var whitespaceRegex = regexp.MustCompile(`\s+`)
@@ -46,3 +53,121 @@ func validateInput(input string) (string, error) {
 
 	return sanitized, nil
 }
+
+// validatePronunciation sanitizes an optional IPA transcription. Nil yields an empty string.
+func validatePronunciation(pronunciation *string) (string, error) {
+	if pronunciation == nil {
+		return "", nil
+	}
+	return validateInput(*pronunciation)
+}
+
+// maxAudioSize is the maximal size of an uploaded audio recording in bytes.
+const maxAudioSize = 10 << 20
+
+// validateAudio reads an uploaded audio file and determines its content type.
+// The type sent by the client is used if it is an audio type, otherwise it is sniffed from the data.
+func validateAudio(file graphql.Upload) ([]byte, string, error) {
+	if file.Size > maxAudioSize {
+		return nil, "", fmt.Errorf("audio file must be at most %d bytes", maxAudioSize)
+	}
+	data, err := io.ReadAll(io.LimitReader(file.File, maxAudioSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read audio file: %w", err)
+	}
+	if len(data) > maxAudioSize {
+		return nil, "", fmt.Errorf("audio file must be at most %d bytes", maxAudioSize)
+	}
+	if len(data) == 0 {
+		return nil, "", fmt.Errorf("audio file cannot be empty")
+	}
+
+	contentType := file.ContentType
+	if !strings.HasPrefix(contentType, "audio/") {
+		contentType = http.DetectContentType(data)
+		if contentType == "application/ogg" {
+			contentType = "audio/ogg"
+		}
+	}
+	if !strings.HasPrefix(contentType, "audio/") {
+		return nil, "", fmt.Errorf("unsupported audio type %q", contentType)
+	}
+	return data, contentType, nil
+}
+
+// validateLimit returns the given limit, or the default one if it is not set.
+// It returns an error if the limit is not positive or exceeds the maximum.
+func validateLimit(limit *int32, defaultLimit int) (int, error) {
+	const maxLimit int = 100
+	if limit == nil {
+		return defaultLimit, nil
+	}
+	if *limit <= 0 {
+		return 0, fmt.Errorf("limit must be positive")
+	}
+	if int(*limit) > maxLimit {
+		return 0, fmt.Errorf("limit must be at most %d", maxLimit)
+	}
+	return int(*limit), nil
+}
+
+// pickFoldedCandidate chooses the word matching polishWord among words sharing its search key.
+// An exact match wins; otherwise there must be exactly one candidate.
+func pickFoldedCandidate(polishWord string, candidates []models.Word) (*models.Word, error) {
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no word matches %q", polishWord)
+	}
+	for i := range candidates {
+		if candidates[i].PolishWord == polishWord {
+			return &candidates[i], nil
+		}
+	}
+	if len(candidates) > 1 {
+		names := make([]string, len(candidates))
+		for i, c := range candidates {
+			names[i] = c.PolishWord
+		}
+		return nil, fmt.Errorf("%q is ambiguous, it matches: %s", polishWord, strings.Join(names, ", "))
+	}
+	return &candidates[0], nil
+}
+
+// validatePageArgs checks Relay pagination arguments and converts them to repository PageArgs.
+func validatePageArgs(first *int32, after *string, last *int32, before *string) (repository.PageArgs, error) {
+	var page repository.PageArgs
+	if first != nil && last != nil {
+		return page, fmt.Errorf("first and last cannot be used together")
+	}
+	if first != nil {
+		limit, err := validateLimit(first, repository.DefaultPageSize)
+		if err != nil {
+			return page, fmt.Errorf("invalid first: %w", err)
+		}
+		page.First = limit
+	}
+	if last != nil {
+		limit, err := validateLimit(last, repository.DefaultPageSize)
+		if err != nil {
+			return page, fmt.Errorf("invalid last: %w", err)
+		}
+		page.Last = limit
+	}
+	if after != nil {
+		page.After = *after
+	}
+	if before != nil {
+		page.Before = *before
+	}
+	return page, nil
+}
+
+// validateGrammar checks that the grammatical metadata is consistent with the part of speech.
+func validateGrammar(grammar models.Grammar) (models.Grammar, error) {
+	if grammar.Aspect != "" && grammar.PartOfSpeech != models.PartOfSpeechVerb {
+		return grammar, fmt.Errorf("aspect can only be set for verbs")
+	}
+	if grammar.Gender != "" && grammar.PartOfSpeech == models.PartOfSpeechVerb {
+		return grammar, fmt.Errorf("gender cannot be set for verbs")
+	}
+	return grammar, nil
+}
@@ -0,0 +1,308 @@
+package anki
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+	_ "modernc.org/sqlite"
+)
+
+// DefaultDeckName is used when Options.DeckName is empty.
+const DefaultDeckName = "Polish-English Dictionary"
+
+// ContentType is the MIME type of Anki packages.
+const ContentType = "application/apkg"
+
+// Options control what goes into the deck.
+type Options struct {
+	DeckName string
+	// Reverse adds an English to Polish card for every word.
+	Reverse bool
+	// Audio includes the first audio recording of every word.
+	Audio bool
+	// Now returns the creation time of the deck. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// fieldSeparator separates the fields of a note.
+const fieldSeparator = "\x1f"
+
+// Export writes an Anki package (.apkg) with a note for every word matching the filter.
+// The front of a card is the Polish word, the back lists its translations with example sentences.
+//
+// The collection is built in a temporary SQLite file, while audio files are streamed
+// into the package as the words are read.
+func Export(w io.Writer, repo repository.Repository, filter repository.WordFilter, opts Options) error {
+	if opts.DeckName == "" {
+		opts.DeckName = DefaultDeckName
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	file, err := os.CreateTemp("", "collection-*.anki2")
+	if err != nil {
+		return fmt.Errorf("failed to create collection file: %w", err)
+	}
+	path := file.Name()
+	file.Close()
+	defer os.Remove(path)
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("failed to open collection: %w", err)
+	}
+	defer db.Close()
+
+	pkg := zip.NewWriter(w)
+	b, err := newBuilder(db, pkg, repo, opts)
+	if err != nil {
+		return err
+	}
+	// Does nothing once the collection is committed.
+	defer b.tx.Rollback()
+	if err := repo.StreamWords(filter, b.addWord); err != nil {
+		return fmt.Errorf("failed to export words: %w", err)
+	}
+	if err := b.finish(); err != nil {
+		return err
+	}
+	if err := db.Close(); err != nil {
+		return fmt.Errorf("failed to close collection: %w", err)
+	}
+
+	if err := copyToZip(pkg, "collection.anki2", path); err != nil {
+		return err
+	}
+	return pkg.Close()
+}
+
+// builder adds notes to the collection and media files to the package.
+type builder struct {
+	tx       *sql.Tx
+	pkg      *zip.Writer
+	repo     repository.Repository
+	opts     Options
+	deckID   int64
+	noteType noteType
+	// nextID is used for note and card IDs, which Anki derives from their creation time in milliseconds.
+	nextID int64
+	due    int
+	media  map[string]string
+}
+
+func newBuilder(db *sql.DB, pkg *zip.Writer, repo repository.Repository, opts Options) (*builder, error) {
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("failed to create collection schema: %w", err)
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin collection transaction: %w", err)
+	}
+
+	now := opts.Now()
+	deckID := deckIDFor(opts.DeckName)
+	return &builder{
+		tx:       tx,
+		pkg:      pkg,
+		repo:     repo,
+		opts:     opts,
+		deckID:   deckID,
+		noteType: newNoteType(deckID, opts.Reverse, now.Unix()),
+		nextID:   now.UnixMilli(),
+		media:    make(map[string]string),
+	}, nil
+}
+
+func (b *builder) id() int64 {
+	b.nextID++
+	return b.nextID
+}
+
+func (b *builder) addWord(word *models.Word) error {
+	fields := make([]string, 3)
+	fields[fieldFront] = html.EscapeString(word.PolishWord)
+	fields[fieldBack] = formatBack(word.Translations)
+	if b.opts.Audio {
+		sound, err := b.addAudio(word.WordID)
+		if err != nil {
+			return err
+		}
+		fields[fieldAudio] = sound
+	}
+
+	mod := b.opts.Now().Unix()
+	noteID := b.id()
+	_, err := b.tx.Exec(
+		"INSERT INTO notes VALUES (?, ?, ?, ?, -1, '', ?, ?, ?, 0, '')",
+		noteID, guidFor(word.WordID), b.noteType.ID, mod,
+		strings.Join(fields, fieldSeparator), word.PolishWord, checksum(word.PolishWord),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add note for %q: %w", word.PolishWord, err)
+	}
+
+	b.due++
+	for ord := range b.noteType.Tmpls {
+		_, err := b.tx.Exec(
+			"INSERT INTO cards VALUES (?, ?, ?, ?, ?, -1, 0, 0, ?, 0, 0, 0, 0, 0, 0, 0, 0, '')",
+			b.id(), noteID, b.deckID, ord, mod, b.due,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to add card for %q: %w", word.PolishWord, err)
+		}
+	}
+	return nil
+}
+
+// addAudio writes the first recording of a word to the package and returns the field referencing it.
+func (b *builder) addAudio(wordID uint) (string, error) {
+	recordings, err := b.repo.ListAudioRecordings(wordID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list audio recordings: %w", err)
+	}
+	if len(recordings) == 0 {
+		return "", nil
+	}
+	recording, err := b.repo.GetAudioRecordingByID(recordings[0].RecordingID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get audio recording: %w", err)
+	}
+
+	// Media files are stored under consecutive numbers and mapped to their names in the "media" file.
+	entry := strconv.Itoa(len(b.media))
+	name := fmt.Sprintf("dictionary-app-%d%s", recording.RecordingID, audioExtension(recording.ContentType))
+	fw, err := b.pkg.Create(entry)
+	if err != nil {
+		return "", fmt.Errorf("failed to add media file: %w", err)
+	}
+	if _, err := fw.Write(recording.Data); err != nil {
+		return "", fmt.Errorf("failed to write media file: %w", err)
+	}
+	b.media[entry] = name
+	return "[sound:" + name + "]", nil
+}
+
+// finish writes the collection settings and the media index.
+func (b *builder) finish() error {
+	now := b.opts.Now()
+	decks, err := marshalByID(map[int64]deck{
+		1:        newDeck(1, "Default", now.Unix()),
+		b.deckID: newDeck(b.deckID, b.opts.DeckName, now.Unix()),
+	})
+	if err != nil {
+		return err
+	}
+	noteTypes, err := marshalByID(map[int64]noteType{b.noteType.ID: b.noteType})
+	if err != nil {
+		return err
+	}
+
+	_, err = b.tx.Exec(
+		"INSERT INTO col VALUES (1, ?, ?, ?, 11, 0, 0, 0, ?, ?, ?, ?, '{}')",
+		now.Unix(), now.UnixMilli(), now.UnixMilli(), collectionConf, noteTypes, decks, deckConf,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write collection settings: %w", err)
+	}
+	if err := b.tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit collection: %w", err)
+	}
+
+	fw, err := b.pkg.Create("media")
+	if err != nil {
+		return fmt.Errorf("failed to add media index: %w", err)
+	}
+	return json.NewEncoder(fw).Encode(b.media)
+}
+
+// formatBack lists the translations, each with its example sentences.
+func formatBack(translations []models.Translation) string {
+	if len(translations) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("<ol>")
+	for _, t := range translations {
+		sb.WriteString("<li>")
+		sb.WriteString(html.EscapeString(t.EnglishTranslation))
+		if len(t.ExampleSentences) > 0 {
+			sb.WriteString("<ul>")
+			for _, s := range t.ExampleSentences {
+				sb.WriteString("<li><i>")
+				sb.WriteString(html.EscapeString(s.SentenceText))
+				sb.WriteString("</i></li>")
+			}
+			sb.WriteString("</ul>")
+		}
+		sb.WriteString("</li>")
+	}
+	sb.WriteString("</ol>")
+	return sb.String()
+}
+
+// guidFor returns a stable note GUID, so importing a newer deck updates notes instead of duplicating them.
+func guidFor(wordID uint) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("dictionary-app/word/%d", wordID)))
+	return hex.EncodeToString(sum[:5])
+}
+
+// deckIDFor derives a stable deck ID from the deck name.
+func deckIDFor(name string) int64 {
+	sum := sha256.Sum256([]byte("dictionary-app/deck/" + name))
+	// Keep the ID positive and within the range of JavaScript numbers used by Anki.
+	return int64(binary.BigEndian.Uint64(sum[:8]) >> 12)
+}
+
+// checksum is the first-field checksum Anki uses to find duplicate notes.
+func checksum(text string) int64 {
+	sum := sha1.Sum([]byte(text))
+	return int64(binary.BigEndian.Uint32(sum[:4]))
+}
+
+func audioExtension(contentType string) string {
+	switch contentType {
+	case "audio/mpeg":
+		return ".mp3"
+	case "audio/ogg":
+		return ".ogg"
+	case "audio/wav", "audio/wave", "audio/x-wav":
+		return ".wav"
+	}
+	if exts, _ := mime.ExtensionsByType(contentType); len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+func copyToZip(pkg *zip.Writer, name, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer file.Close()
+
+	fw, err := pkg.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := io.Copy(fw, file); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
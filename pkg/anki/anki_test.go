@@ -0,0 +1,124 @@
+package anki_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sar-michal/dictionary-app/pkg/anki"
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deckRepo streams fixed words with one recording for the first word.
+// Other repository methods are not used by the exporter.
+type deckRepo struct {
+	repository.Repository
+}
+
+func (r *deckRepo) StreamWords(filter repository.WordFilter, fn func(word *models.Word) error) error {
+	words := []models.Word{
+		{WordID: 1, PolishWord: "kot", Translations: []models.Translation{
+			{EnglishTranslation: "cat", ExampleSentences: []models.ExampleSentence{{SentenceText: "Kot & pies."}}},
+		}},
+		{WordID: 2, PolishWord: "dom", Translations: []models.Translation{{EnglishTranslation: "house"}}},
+	}
+	for i := range words {
+		if err := fn(&words[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *deckRepo) ListAudioRecordings(wordID uint) ([]models.AudioRecording, error) {
+	if wordID != 1 {
+		return nil, nil
+	}
+	return []models.AudioRecording{{RecordingID: 7, WordID: 1, ContentType: "audio/ogg"}}, nil
+}
+
+func (r *deckRepo) GetAudioRecordingByID(recordingID uint) (*models.AudioRecording, error) {
+	return &models.AudioRecording{RecordingID: 7, WordID: 1, ContentType: "audio/ogg", Data: []byte("OggS")}, nil
+}
+
+// openPackage extracts the package and opens its collection.
+func openPackage(t *testing.T, data []byte) (*sql.DB, map[string][]byte) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err, "Expected A Valid Zip Archive")
+
+	files := make(map[string][]byte)
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		require.NoError(t, err, "Expected Readable Zip Entry")
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err, "Expected Readable Zip Entry")
+		files[f.Name] = content
+	}
+
+	path := filepath.Join(t.TempDir(), "collection.anki2")
+	require.NoError(t, os.WriteFile(path, files["collection.anki2"], 0o600), "Expected Collection To Be Written")
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err, "Expected Collection To Open")
+	t.Cleanup(func() { db.Close() })
+	return db, files
+}
+
+func count(t *testing.T, db *sql.DB, table string) int {
+	var n int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM "+table).Scan(&n), "Expected Table %s", table)
+	return n
+}
+
+func TestExport(t *testing.T) {
+	var buf bytes.Buffer
+	now := func() time.Time { return time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC) }
+	err := anki.Export(&buf, &deckRepo{}, repository.WordFilter{}, anki.Options{DeckName: "Test", Audio: true, Now: now})
+	require.NoError(t, err, "Export Should Not Error")
+
+	db, files := openPackage(t, buf.Bytes())
+	assert.Equal(t, 2, count(t, db, "notes"), "Expected One Note Per Word")
+	assert.Equal(t, 2, count(t, db, "cards"), "Expected One Card Per Note")
+
+	var flds, sfld string
+	require.NoError(t, db.QueryRow("SELECT flds, sfld FROM notes ORDER BY id LIMIT 1").Scan(&flds, &sfld), "Expected A Note")
+	fields := strings.Split(flds, "\x1f")
+	require.Equal(t, 3, len(fields), "Expected Front, Back And Audio Fields")
+	assert.Equal(t, "kot", fields[0], "Expected Polish Word On The Front")
+	assert.Equal(t, "<ol><li>cat<ul><li><i>Kot &amp; pies.</i></li></ul></li></ol>", fields[1], "Expected Translations On The Back")
+	assert.Equal(t, "[sound:dictionary-app-7.ogg]", fields[2], "Expected Sound Reference")
+	assert.Equal(t, "kot", sfld, "Expected Sort Field")
+
+	var decks string
+	require.NoError(t, db.QueryRow("SELECT decks FROM col").Scan(&decks), "Expected Collection Settings")
+	assert.Contains(t, decks, `"name":"Test"`, "Expected Named Deck")
+
+	var media map[string]string
+	require.NoError(t, json.Unmarshal(files["media"], &media), "Expected Media Index")
+	assert.Equal(t, map[string]string{"0": "dictionary-app-7.ogg"}, media, "Expected One Media File")
+	assert.Equal(t, []byte("OggS"), files["0"], "Expected Audio Data")
+}
+
+func TestExportReverse(t *testing.T) {
+	var buf bytes.Buffer
+	err := anki.Export(&buf, &deckRepo{}, repository.WordFilter{}, anki.Options{Reverse: true})
+	require.NoError(t, err, "Export Should Not Error")
+
+	db, files := openPackage(t, buf.Bytes())
+	assert.Equal(t, 4, count(t, db, "cards"), "Expected Two Cards Per Note")
+
+	var reversed int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM cards WHERE ord = 1").Scan(&reversed), "Expected Cards")
+	assert.Equal(t, 2, reversed, "Expected A Reverse Card For Every Note")
+	assert.Equal(t, "{}\n", string(files["media"]), "Expected No Media Without Audio")
+}
@@ -0,0 +1,190 @@
+package anki
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// schema creates the tables of an Anki collection (schema version 11).
+const schema = `
+CREATE TABLE col (
+	id integer PRIMARY KEY, crt integer NOT NULL, mod integer NOT NULL, scm integer NOT NULL,
+	ver integer NOT NULL, dty integer NOT NULL, usn integer NOT NULL, ls integer NOT NULL,
+	conf text NOT NULL, models text NOT NULL, decks text NOT NULL, dconf text NOT NULL, tags text NOT NULL
+);
+CREATE TABLE notes (
+	id integer PRIMARY KEY, guid text NOT NULL, mid integer NOT NULL, mod integer NOT NULL,
+	usn integer NOT NULL, tags text NOT NULL, flds text NOT NULL, sfld integer NOT NULL,
+	csum integer NOT NULL, flags integer NOT NULL, data text NOT NULL
+);
+CREATE TABLE cards (
+	id integer PRIMARY KEY, nid integer NOT NULL, did integer NOT NULL, ord integer NOT NULL,
+	mod integer NOT NULL, usn integer NOT NULL, type integer NOT NULL, queue integer NOT NULL,
+	due integer NOT NULL, ivl integer NOT NULL, factor integer NOT NULL, reps integer NOT NULL,
+	lapses integer NOT NULL, left integer NOT NULL, odue integer NOT NULL, odid integer NOT NULL,
+	flags integer NOT NULL, data text NOT NULL
+);
+CREATE TABLE revlog (
+	id integer PRIMARY KEY, cid integer NOT NULL, usn integer NOT NULL, ease integer NOT NULL,
+	ivl integer NOT NULL, lastIvl integer NOT NULL, factor integer NOT NULL, time integer NOT NULL,
+	type integer NOT NULL
+);
+CREATE TABLE graves (usn integer NOT NULL, oid integer NOT NULL, type integer NOT NULL);
+CREATE INDEX ix_notes_usn ON notes (usn);
+CREATE INDEX ix_cards_usn ON cards (usn);
+CREATE INDEX ix_revlog_usn ON revlog (usn);
+CREATE INDEX ix_cards_nid ON cards (nid);
+CREATE INDEX ix_cards_sched ON cards (did, queue, due);
+CREATE INDEX ix_revlog_cid ON revlog (cid);
+CREATE INDEX ix_notes_csum ON notes (csum);
+`
+
+// collectionConf holds the collection settings Anki expects in a new collection.
+const collectionConf = `{"activeDecks":[1],"curDeck":1,"newSpread":0,"collapseTime":1200,"timeLim":0,` +
+	`"estTimes":true,"dueCounts":true,"curModel":null,"nextPos":1,"sortType":"noteFld",` +
+	`"sortBackwards":false,"addToCur":true}`
+
+// deckConf holds the default deck options.
+const deckConf = `{"1":{"id":1,"name":"Default","replayq":true,"timer":0,"maxTaken":60,"usn":0,"mod":0,` +
+	`"autoplay":true,"dyn":false,` +
+	`"lapse":{"delays":[10],"mult":0,"minInt":1,"leechFails":8,"leechAction":0},` +
+	`"rev":{"perDay":100,"ease4":1.3,"fuzz":0.05,"minSpace":1,"ivlFct":1,"maxIvl":36500},` +
+	`"new":{"delays":[1,10],"ints":[1,4,7],"initialFactor":2500,"separate":true,"order":1,"perDay":20,"bury":true}}}`
+
+const latexPre = "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\usepackage[utf8]{inputenc}\n" +
+	"\\usepackage{amssymb,amsmath}\n\\pagestyle{empty}\n\\setlength{\\parindent}{0in}\n\\begin{document}\n"
+
+const cardCSS = `.card {
+  font-family: arial;
+  font-size: 20px;
+  text-align: center;
+  color: black;
+  background-color: white;
+}
+ol, ul {
+  display: inline-block;
+  text-align: left;
+}
+`
+
+type deck struct {
+	ID               int64  `json:"id"`
+	Name             string `json:"name"`
+	Desc             string `json:"desc"`
+	Mod              int64  `json:"mod"`
+	Usn              int    `json:"usn"`
+	Collapsed        bool   `json:"collapsed"`
+	BrowserCollapsed bool   `json:"browserCollapsed"`
+	NewToday         [2]int `json:"newToday"`
+	RevToday         [2]int `json:"revToday"`
+	LrnToday         [2]int `json:"lrnToday"`
+	TimeToday        [2]int `json:"timeToday"`
+	Dyn              int    `json:"dyn"`
+	Conf             int    `json:"conf"`
+	ExtendNew        int    `json:"extendNew"`
+	ExtendRev        int    `json:"extendRev"`
+}
+
+func newDeck(id int64, name string, mod int64) deck {
+	return deck{ID: id, Name: name, Mod: mod, Usn: -1, Conf: 1, ExtendNew: 10, ExtendRev: 50}
+}
+
+type noteType struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Type      int        `json:"type"`
+	Mod       int64      `json:"mod"`
+	Usn       int        `json:"usn"`
+	Sortf     int        `json:"sortf"`
+	Did       int64      `json:"did"`
+	Tmpls     []template `json:"tmpls"`
+	Flds      []field    `json:"flds"`
+	CSS       string     `json:"css"`
+	LatexPre  string     `json:"latexPre"`
+	LatexPost string     `json:"latexPost"`
+	LatexSvg  bool       `json:"latexsvg"`
+	Tags      []string   `json:"tags"`
+	Vers      []int      `json:"vers"`
+	// Req lists the fields each template requires to generate a card.
+	Req [][]any `json:"req"`
+}
+
+type template struct {
+	Name  string `json:"name"`
+	Ord   int    `json:"ord"`
+	Qfmt  string `json:"qfmt"`
+	Afmt  string `json:"afmt"`
+	Did   *int64 `json:"did"`
+	Bqfmt string `json:"bqfmt"`
+	Bafmt string `json:"bafmt"`
+}
+
+type field struct {
+	Name   string `json:"name"`
+	Ord    int    `json:"ord"`
+	Sticky bool   `json:"sticky"`
+	Rtl    bool   `json:"rtl"`
+	Font   string `json:"font"`
+	Size   int    `json:"size"`
+	Media  []any  `json:"media"`
+}
+
+// Note type IDs are fixed, so repeated imports reuse the note type instead of creating copies.
+const (
+	forwardNoteTypeID  int64 = 1718093452001
+	reversedNoteTypeID int64 = 1718093452002
+)
+
+// Fields of the note type, in order.
+const (
+	fieldFront = iota
+	fieldBack
+	fieldAudio
+)
+
+// newNoteType returns a note type with a Polish to English card and optionally an English to Polish one.
+func newNoteType(deckID int64, reverse bool, mod int64) noteType {
+	nt := noteType{
+		ID:        forwardNoteTypeID,
+		Name:      "Dictionary App (Polish-English)",
+		Mod:       mod,
+		Usn:       -1,
+		Did:       deckID,
+		CSS:       cardCSS,
+		LatexPre:  latexPre,
+		LatexPost: "\\end{document}",
+		Tags:      []string{},
+		Vers:      []int{},
+		Tmpls: []template{{
+			Name: "Polish to English",
+			Qfmt: "{{Front}}",
+			Afmt: "{{FrontSide}}\n\n<hr id=answer>\n\n{{Back}}\n{{Audio}}",
+		}},
+		Req: [][]any{{0, "any", []int{fieldFront}}},
+	}
+	for i, name := range []string{"Front", "Back", "Audio"} {
+		nt.Flds = append(nt.Flds, field{Name: name, Ord: i, Font: "Arial", Size: 20, Media: []any{}})
+	}
+	if reverse {
+		nt.ID = reversedNoteTypeID
+		nt.Name = "Dictionary App (Polish-English and reversed)"
+		nt.Tmpls = append(nt.Tmpls, template{
+			Name: "English to Polish",
+			Ord:  1,
+			Qfmt: "{{Back}}",
+			Afmt: "{{FrontSide}}\n\n<hr id=answer>\n\n{{Front}}\n{{Audio}}",
+		})
+		nt.Req = append(nt.Req, []any{1, "any", []int{fieldBack}})
+	}
+	return nt
+}
+
+// marshalByID encodes values as a JSON object keyed by their IDs, as Anki stores decks and note types.
+func marshalByID[T any](values map[int64]T) (string, error) {
+	byID := make(map[string]T, len(values))
+	for id, v := range values {
+		byID[strconv.FormatInt(id, 10)] = v
+	}
+	data, err := json.Marshal(byID)
+	return string(data), err
+}
@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+)
+
+const (
+	// DefaultTokenTTL is how long issued tokens stay valid.
+	DefaultTokenTTL = 24 * time.Hour
+	issuer          = "dictionary-app"
+	// MinPasswordLength is the minimal number of characters of a password.
+	MinPasswordLength = 8
+	// maxPasswordLength is the number of bytes bcrypt takes into account.
+	maxPasswordLength = 72
+)
+
+var (
+	// ErrInvalidCredentials is returned when the username or password is wrong.
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	// ErrInvalidToken is returned for malformed, expired or forged tokens.
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// HashPassword returns the bcrypt hash of a password.
+func HashPassword(password string) (string, error) {
+	if len([]rune(password)) < MinPasswordLength {
+		return "", fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+	}
+	if len(password) > maxPasswordLength {
+		return "", fmt.Errorf("password must be at most %d bytes", maxPasswordLength)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// CheckPassword reports whether the password matches the hash.
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// Tokens issues and verifies JWTs signed with HMAC-SHA256.
+type Tokens struct {
+	Secret []byte
+	// TTL defaults to DefaultTokenTTL.
+	TTL time.Duration
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+func (t *Tokens) now() time.Time {
+	if t.Now == nil {
+		return time.Now()
+	}
+	return t.Now()
+}
+
+// Issue returns a token identifying the user.
+func (t *Tokens) Issue(user *models.User) (string, error) {
+	ttl := t.TTL
+	if ttl == 0 {
+		ttl = DefaultTokenTTL
+	}
+	now := t.now()
+	claims := jwt.RegisteredClaims{
+		Issuer:    issuer,
+		Subject:   strconv.FormatUint(uint64(user.UserID), 10),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(t.Secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return token, nil
+}
+
+// Verify checks the signature and expiry of a token and returns the ID of its user.
+func (t *Tokens) Verify(token string) (uint, error) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return t.Secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(t.now),
+	)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	userID, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	return uint(userID), nil
+}
+
+// Login checks the credentials and returns the user with a new token.
+func Login(repo repository.Repository, tokens *Tokens, username, password string) (*models.User, string, error) {
+	user, err := repo.GetUserByUsername(username)
+	if err != nil || !CheckPassword(user.PasswordHash, password) {
+		return nil, "", ErrInvalidCredentials
+	}
+	token, err := tokens.Issue(user)
+	if err != nil {
+		return nil, "", err
+	}
+	return user, token, nil
+}
+
+type contextKey struct{}
+
+// WithUser returns a context carrying the signed-in user.
+func WithUser(ctx context.Context, user *models.User) context.Context {
+	return context.WithValue(ctx, contextKey{}, user)
+}
+
+// UserFromContext returns the signed-in user, or nil for anonymous requests.
+func UserFromContext(ctx context.Context) *models.User {
+	user, _ := ctx.Value(contextKey{}).(*models.User)
+	return user
+}
+
+// Middleware puts the user identified by the "Authorization: Bearer" token into the request context.
+// Requests without a token pass through anonymously; invalid tokens are rejected with 401 Unauthorized.
+func Middleware(repo repository.Repository, tokens *Tokens) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if header == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			token, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok {
+				http.Error(w, "authorization header must use the Bearer scheme", http.StatusUnauthorized)
+				return
+			}
+			userID, err := tokens.Verify(strings.TrimSpace(token))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			user, err := repo.GetUserByID(userID)
+			if err != nil {
+				// The account was removed after the token was issued.
+				http.Error(w, ErrInvalidToken.Error(), http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithUser(r.Context(), user)))
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package auth_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sar-michal/dictionary-app/pkg/auth"
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// usersRepo stores users in memory. Other repository methods are not used.
+type usersRepo struct {
+	repository.Repository
+	users []models.User
+}
+
+func (r *usersRepo) GetUserByID(userID uint) (*models.User, error) {
+	for i := range r.users {
+		if r.users[i].UserID == userID {
+			return &r.users[i], nil
+		}
+	}
+	return nil, fmt.Errorf("user %d not found", userID)
+}
+
+func (r *usersRepo) GetUserByUsername(username string) (*models.User, error) {
+	for i := range r.users {
+		if r.users[i].Username == username {
+			return &r.users[i], nil
+		}
+	}
+	return nil, fmt.Errorf("user %q not found", username)
+}
+
+func newTokens(now *time.Time) *auth.Tokens {
+	return &auth.Tokens{Secret: []byte("test secret"), Now: func() time.Time { return *now }}
+}
+
+func TestPasswords(t *testing.T) {
+	hash, err := auth.HashPassword("correct horse")
+	require.NoError(t, err, "HashPassword Should Not Error")
+	assert.NotContains(t, hash, "correct horse", "Expected Hash Not To Contain The Password")
+	assert.True(t, auth.CheckPassword(hash, "correct horse"), "Expected Password To Match")
+	assert.False(t, auth.CheckPassword(hash, "Correct horse"), "Expected Wrong Password Not To Match")
+
+	_, err = auth.HashPassword("short")
+	assert.Error(t, err, "Expected Error For Short Password")
+}
+
+func TestTokens(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tokens := newTokens(&now)
+	token, err := tokens.Issue(&models.User{UserID: 7})
+	require.NoError(t, err, "Issue Should Not Error")
+
+	userID, err := tokens.Verify(token)
+	require.NoError(t, err, "Verify Should Not Error")
+	assert.Equal(t, uint(7), userID, "Expected User ID From Token")
+
+	forged := &auth.Tokens{Secret: []byte("other secret"), Now: tokens.Now}
+	_, err = forged.Verify(token)
+	assert.ErrorIs(t, err, auth.ErrInvalidToken, "Expected Token Signed With Another Secret To Be Rejected")
+
+	now = now.Add(auth.DefaultTokenTTL + time.Second)
+	_, err = tokens.Verify(token)
+	assert.ErrorIs(t, err, auth.ErrInvalidToken, "Expected Expired Token To Be Rejected")
+
+	_, err = tokens.Verify("not.a.token")
+	assert.ErrorIs(t, err, auth.ErrInvalidToken, "Expected Malformed Token To Be Rejected")
+}
+
+func TestLogin(t *testing.T) {
+	hash, err := auth.HashPassword("correct horse")
+	require.NoError(t, err, "HashPassword Should Not Error")
+	repo := &usersRepo{users: []models.User{{UserID: 1, Username: "ania", PasswordHash: hash}}}
+	now := time.Now()
+	tokens := newTokens(&now)
+
+	user, token, err := auth.Login(repo, tokens, "ania", "correct horse")
+	require.NoError(t, err, "Login Should Not Error")
+	assert.Equal(t, "ania", user.Username, "Expected Signed In User")
+	assert.NotEmpty(t, token, "Expected Token")
+
+	_, _, err = auth.Login(repo, tokens, "ania", "wrong password")
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials, "Expected Wrong Password To Fail")
+	_, _, err = auth.Login(repo, tokens, "tomek", "correct horse")
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials, "Expected Unknown User To Fail The Same Way")
+}
+
+func TestMiddleware(t *testing.T) {
+	repo := &usersRepo{users: []models.User{{UserID: 1, Username: "ania"}}}
+	now := time.Now()
+	tokens := newTokens(&now)
+	handler := auth.Middleware(repo, tokens)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user := auth.UserFromContext(r.Context()); user != nil {
+			fmt.Fprint(w, user.Username)
+		} else {
+			fmt.Fprint(w, "anonymous")
+		}
+	}))
+	serve := func(authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/query", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("")
+	assert.Equal(t, "anonymous", rec.Body.String(), "Expected Anonymous Request")
+
+	token, err := tokens.Issue(&repo.users[0])
+	require.NoError(t, err, "Issue Should Not Error")
+	rec = serve("Bearer " + token)
+	assert.Equal(t, http.StatusOK, rec.Code, "Expected Status 200")
+	assert.Equal(t, "ania", rec.Body.String(), "Expected User In Context")
+
+	rec = serve("Bearer invalid")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code, "Expected Status 401 For Invalid Token")
+	rec = serve("Basic YW5pYTpwYXNz")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code, "Expected Status 401 For Other Schemes")
+
+	deleted, err := tokens.Issue(&models.User{UserID: 2})
+	require.NoError(t, err, "Issue Should Not Error")
+	rec = serve("Bearer " + deleted)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code, "Expected Status 401 For Removed User")
+}
@@ -0,0 +1,114 @@
+package dictd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/sar-michal/dictionary-app/pkg/glossary"
+)
+
+// b64 is the alphabet dictd uses for offsets and lengths in index files.
+const b64 = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// Write creates the .index and .dict files of a dictd database in dir. The
+// database declares itself as UTF-8 and includes the 00-database-* entries
+// that dictd reports for SHOW DB and SHOW INFO.
+func Write(dir string, g *glossary.Glossary) error {
+	articles := map[string]string{
+		"00-database-short": "   " + g.Direction.Title() + "\n",
+		"00-database-info":  "   " + g.Direction.Title() + " exported from the Polish dictionary app.\n",
+		"00-database-utf8":  "",
+	}
+	for headword, entries := range g.Entries {
+		articles[headword] = FormatArticle(entries)
+	}
+	headwords := make([]string, 0, len(articles))
+	for h := range articles {
+		headwords = append(headwords, h)
+	}
+	slices.SortFunc(headwords, compare)
+
+	var index, dict strings.Builder
+	for _, headword := range headwords {
+		article := headword + "\n" + articles[headword]
+		index.WriteString(headword + "\t" + encode(dict.Len()) + "\t" + encode(len(article)) + "\n")
+		dict.WriteString(article)
+	}
+
+	base := filepath.Join(dir, g.Direction.FileName())
+	if err := os.WriteFile(base+".index", []byte(index.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write index file: %w", err)
+	}
+	if err := os.WriteFile(base+".dict", []byte(dict.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write dict file: %w", err)
+	}
+	return nil
+}
+
+// encode writes a number in dictd's base64 notation.
+func encode(n int) string {
+	if n == 0 {
+		return b64[:1]
+	}
+	var digits []byte
+	for ; n > 0; n /= 64 {
+		digits = append(digits, b64[n%64])
+	}
+	slices.Reverse(digits)
+	return string(digits)
+}
+
+// compare orders headwords like dictfmt does: case-insensitively, ignoring
+// everything except letters, digits and spaces.
+func compare(a, b string) int {
+	if c := strings.Compare(sortKey(a), sortKey(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+func sortKey(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ' {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+// FormatArticle renders the entries of a headword as indented plain text,
+// without the headword line that Write adds for every article.
+func FormatArticle(entries []glossary.Entry) string {
+	var b strings.Builder
+	for i, entry := range entries {
+		if i > 0 {
+			b.WriteString("\n" + entry.Headword + "\n")
+		}
+		var details []string
+		if entry.Pronunciation != "" {
+			details = append(details, "["+entry.Pronunciation+"]")
+		}
+		if entry.Grammar != "" {
+			details = append(details, entry.Grammar)
+		}
+		if len(details) > 0 {
+			b.WriteString("   " + strings.Join(details, " ") + "\n")
+		}
+		for n, sense := range entry.Senses {
+			b.WriteString("   " + strconv.Itoa(n+1) + ". " + sense.Text)
+			if sense.Grammar != "" {
+				b.WriteString(" (" + sense.Grammar + ")")
+			}
+			b.WriteString("\n")
+			for _, example := range sense.Examples {
+				b.WriteString("      " + example + "\n")
+			}
+		}
+	}
+	return b.String()
+}
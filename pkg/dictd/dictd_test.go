@@ -0,0 +1,62 @@
+package dictd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sar-michal/dictionary-app/pkg/glossary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, "A", encode(0), "Expected Zero")
+	assert.Equal(t, "/", encode(63), "Expected Single Digit")
+	assert.Equal(t, "BA", encode(64), "Expected Two Digits")
+	assert.Equal(t, "Bm4", encode(6584), "Expected Three Digits")
+}
+
+func TestWrite(t *testing.T) {
+	g := &glossary.Glossary{Direction: glossary.EnglishPolish, Entries: map[string][]glossary.Entry{
+		"lock": {{Headword: "lock", Senses: []glossary.Sense{
+			{Text: "zamek", Grammar: "noun, masculine inanimate"},
+			{Text: "kłódka", Grammar: "noun, feminine", Examples: []string{"Kłódka jest zamknięta."}},
+		}}},
+		"Castle": {{Headword: "Castle", Senses: []glossary.Sense{{Text: "zamek"}}}},
+	}}
+	dir := t.TempDir()
+	require.NoError(t, Write(dir, g), "Write Should Not Error")
+	base := filepath.Join(dir, "dictionary-en-pl")
+
+	index, err := os.ReadFile(base + ".index")
+	require.NoError(t, err, "Reading index Should Not Error")
+	dict, err := os.ReadFile(base + ".dict")
+	require.NoError(t, err, "Reading dict Should Not Error")
+
+	var headwords []string
+	articles := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSuffix(string(index), "\n"), "\n") {
+		fields := strings.Split(line, "\t")
+		require.Equal(t, 3, len(fields), "Expected Headword, Offset And Length")
+		offset, length := decode(fields[1]), decode(fields[2])
+		headwords = append(headwords, fields[0])
+		articles[fields[0]] = string(dict[offset : offset+length])
+	}
+
+	assert.Equal(t, []string{"00-database-info", "00-database-short", "00-database-utf8", "Castle", "lock"}, headwords, "Expected dictfmt Order")
+	assert.Equal(t, "00-database-short\n   English-Polish Dictionary\n", articles["00-database-short"], "Expected Database Name")
+	assert.Equal(t, "lock\n"+
+		"   1. zamek (noun, masculine inanimate)\n"+
+		"   2. kłódka (noun, feminine)\n"+
+		"      Kłódka jest zamknięta.\n", articles["lock"], "Expected Article Text")
+}
+
+func decode(s string) int {
+	n := 0
+	for _, c := range s {
+		n = n*64 + strings.IndexRune(b64, c)
+	}
+	return n
+}
@@ -0,0 +1,351 @@
+package dictserver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/textproto"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/sar-michal/dictionary-app/pkg/dictd"
+	"github.com/sar-michal/dictionary-app/pkg/glossary"
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+)
+
+// DefaultPort is the port assigned to the DICT protocol.
+const DefaultPort = "2628"
+
+const (
+	serverName = "dictionary-app"
+	// idleTimeout closes connections that send no command for this long.
+	idleTimeout = 10 * time.Minute
+	// maxMatches limits the number of headwords returned by MATCH per database.
+	maxMatches = 1000
+)
+
+type strategy struct {
+	name        string
+	description string
+}
+
+// Matching strategies. The default strategy "." is prefix.
+var strategies = []strategy{
+	{"exact", "Match headwords exactly, ignoring case"},
+	{"prefix", "Match prefixes, ignoring case"},
+}
+
+// Server answers DICT protocol (RFC 2229) requests. It offers a Polish-English
+// and an English-Polish database, named after the direction codes "pl-en" and "en-pl".
+type Server struct {
+	Repo     repository.Repository
+	sessions atomic.Uint64
+}
+
+// ListenAndServe listens on the TCP address and serves connections until listening fails.
+func (s *Server) ListenAndServe(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	return s.Serve(listener)
+}
+
+// Serve accepts connections on the listener, handling each in its own goroutine.
+// It returns when the listener is closed.
+func (s *Server) Serve(listener net.Listener) error {
+	defer listener.Close()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+		go s.handle(conn)
+	}
+}
+
+// definition is a DEFINE result: an article of one headword in one database.
+type definition struct {
+	headword string
+	database glossary.Direction
+	text     string
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	err := tp.PrintfLine("220 %s <> <%d.%d@%s>", serverName, time.Now().Unix(), s.sessions.Add(1), serverName)
+	for err == nil {
+		conn.SetReadDeadline(time.Now().Add(idleTimeout))
+		var line string
+		if line, err = tp.ReadLine(); err != nil {
+			break
+		}
+		args, parseErr := parseCommand(line)
+		if parseErr != nil || len(args) == 0 {
+			err = tp.PrintfLine("501 Syntax error, illegal parameters")
+			continue
+		}
+		switch strings.ToUpper(args[0]) {
+		case "DEFINE":
+			err = s.define(tp, args[1:])
+		case "MATCH":
+			err = s.match(tp, args[1:])
+		case "SHOW":
+			err = s.show(tp, args[1:])
+		case "CLIENT":
+			err = tp.PrintfLine("250 ok")
+		case "QUIT":
+			tp.PrintfLine("221 Closing connection")
+			return
+		default:
+			err = tp.PrintfLine("500 Syntax error, command not recognized")
+		}
+	}
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+		log.Printf("DICT connection from %s: %v", conn.RemoteAddr(), err)
+	}
+}
+
+// define handles DEFINE database word.
+func (s *Server) define(tp *textproto.Conn, args []string) error {
+	if len(args) != 2 {
+		return tp.PrintfLine("501 Syntax error, illegal parameters")
+	}
+	databases, ok := selectDatabases(args[0])
+	if !ok {
+		return tp.PrintfLine(`550 Invalid database, use "SHOW DB" for list of databases`)
+	}
+	var definitions []definition
+	for _, database := range databases {
+		found, err := s.lookup(database, args[1])
+		if err != nil {
+			log.Printf("DICT lookup of %q failed: %v", args[1], err)
+			return tp.PrintfLine("420 Server temporarily unavailable")
+		}
+		definitions = append(definitions, found...)
+		if args[0] == "!" && len(found) > 0 {
+			break
+		}
+	}
+	if len(definitions) == 0 {
+		return tp.PrintfLine("552 No match")
+	}
+
+	if err := tp.PrintfLine("150 %d definitions retrieved", len(definitions)); err != nil {
+		return err
+	}
+	for _, d := range definitions {
+		err := tp.PrintfLine("151 %s %s %s", quote(d.headword), d.database.Code(), quote(d.database.Title()))
+		if err != nil {
+			return err
+		}
+		if err := writeText(tp, d.text); err != nil {
+			return err
+		}
+	}
+	return tp.PrintfLine("250 ok")
+}
+
+// match handles MATCH database strategy word.
+func (s *Server) match(tp *textproto.Conn, args []string) error {
+	if len(args) != 3 {
+		return tp.PrintfLine("501 Syntax error, illegal parameters")
+	}
+	databases, ok := selectDatabases(args[0])
+	if !ok {
+		return tp.PrintfLine(`550 Invalid database, use "SHOW DB" for list of databases`)
+	}
+	name := strings.ToLower(args[1])
+	if name == "." {
+		name = "prefix"
+	}
+	if !slices.ContainsFunc(strategies, func(st strategy) bool { return st.name == name }) {
+		return tp.PrintfLine(`551 Invalid strategy, use "SHOW STRAT" for a list of strategies`)
+	}
+
+	var lines []string
+	for _, database := range databases {
+		headwords, err := s.matchHeadwords(database, name, args[2])
+		if err != nil {
+			log.Printf("DICT match of %q failed: %v", args[2], err)
+			return tp.PrintfLine("420 Server temporarily unavailable")
+		}
+		for _, headword := range headwords {
+			lines = append(lines, database.Code()+" "+quote(headword))
+		}
+		if args[0] == "!" && len(headwords) > 0 {
+			break
+		}
+	}
+	if len(lines) == 0 {
+		return tp.PrintfLine("552 No match")
+	}
+
+	if err := tp.PrintfLine("152 %d matches found", len(lines)); err != nil {
+		return err
+	}
+	if err := writeText(tp, strings.Join(lines, "\n")); err != nil {
+		return err
+	}
+	return tp.PrintfLine("250 ok")
+}
+
+// show handles SHOW DB and SHOW STRAT.
+func (s *Server) show(tp *textproto.Conn, args []string) error {
+	if len(args) != 1 {
+		return tp.PrintfLine("501 Syntax error, illegal parameters")
+	}
+	var lines []string
+	switch strings.ToUpper(args[0]) {
+	case "DB", "DATABASES":
+		for _, database := range glossary.Directions {
+			lines = append(lines, database.Code()+" "+quote(database.Title()))
+		}
+		if err := tp.PrintfLine("110 %d databases present", len(lines)); err != nil {
+			return err
+		}
+	case "STRAT", "STRATEGIES":
+		for _, st := range strategies {
+			lines = append(lines, st.name+" "+quote(st.description))
+		}
+		if err := tp.PrintfLine("111 %d strategies available", len(lines)); err != nil {
+			return err
+		}
+	default:
+		return tp.PrintfLine("501 Syntax error, illegal parameters")
+	}
+	if err := writeText(tp, strings.Join(lines, "\n")); err != nil {
+		return err
+	}
+	return tp.PrintfLine("250 ok")
+}
+
+// lookup returns the articles of the headwords equal to word, ignoring case.
+func (s *Server) lookup(database glossary.Direction, word string) ([]definition, error) {
+	var words []models.Word
+	var err error
+	if database == glossary.EnglishPolish {
+		words, err = s.Repo.ListWordsByFoldedEnglish(word)
+	} else {
+		words, err = s.Repo.ListWordsByFoldedPolish(word)
+	}
+	if err != nil {
+		return nil, err
+	}
+	g := glossary.New(database)
+	for i := range words {
+		g.Add(&words[i])
+	}
+
+	var headwords []string
+	for _, headword := range g.Headwords() {
+		if strings.EqualFold(headword, word) {
+			headwords = append(headwords, headword)
+		}
+	}
+	slices.Sort(headwords)
+	definitions := make([]definition, len(headwords))
+	for i, headword := range headwords {
+		definitions[i] = definition{
+			headword: headword,
+			database: database,
+			text:     headword + "\n" + dictd.FormatArticle(g.Entries[headword]),
+		}
+	}
+	return definitions, nil
+}
+
+func (s *Server) matchHeadwords(database glossary.Direction, strategyName, word string) ([]string, error) {
+	if strategyName == "exact" {
+		definitions, err := s.lookup(database, word)
+		if err != nil {
+			return nil, err
+		}
+		headwords := make([]string, len(definitions))
+		for i, d := range definitions {
+			headwords[i] = d.headword
+		}
+		return headwords, nil
+	}
+	if database == glossary.EnglishPolish {
+		return s.Repo.ListEnglishHeadwords(word, maxMatches)
+	}
+	return s.Repo.ListPolishHeadwords(word, maxMatches)
+}
+
+// selectDatabases resolves a database name. "*" selects all databases and "!"
+// selects them in order until one has a match.
+func selectDatabases(name string) ([]glossary.Direction, bool) {
+	if name == "*" || name == "!" {
+		return glossary.Directions, true
+	}
+	for _, database := range glossary.Directions {
+		if strings.EqualFold(name, database.Code()) {
+			return []glossary.Direction{database}, true
+		}
+	}
+	return nil, false
+}
+
+// writeText sends a text response terminated by a line with a single dot.
+func writeText(tp *textproto.Conn, text string) error {
+	w := tp.DotWriter()
+	if _, err := io.WriteString(w, strings.TrimSuffix(text, "\n")+"\n"); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// parseCommand splits a command line into words. Words are separated by
+// spaces or tabs and may be quoted with single or double quotes; a backslash
+// escapes the next character.
+func parseCommand(line string) ([]string, error) {
+	var args []string
+	for i := 0; i < len(line); {
+		if line[i] == ' ' || line[i] == '\t' {
+			i++
+			continue
+		}
+		var arg strings.Builder
+		for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+			switch c := line[i]; c {
+			case '"', '\'':
+				end := i + 1
+				for ; end < len(line) && line[end] != c; end++ {
+					if line[end] == '\\' && end+1 < len(line) {
+						end++
+					}
+					arg.WriteByte(line[end])
+				}
+				if end == len(line) {
+					return nil, fmt.Errorf("unterminated quoted string")
+				}
+				i = end + 1
+			case '\\':
+				if i+1 < len(line) {
+					arg.WriteByte(line[i+1])
+				}
+				i += 2
+			default:
+				arg.WriteByte(c)
+				i++
+			}
+		}
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
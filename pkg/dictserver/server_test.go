@@ -0,0 +1,168 @@
+package dictserver
+
+import (
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/normalize"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lookupRepo answers lookups from a fixed list of words. Other repository methods are not used.
+type lookupRepo struct {
+	repository.Repository
+	words []models.Word
+}
+
+func (r *lookupRepo) ListWordsByFoldedPolish(polishWord string) ([]models.Word, error) {
+	var words []models.Word
+	for _, w := range r.words {
+		if normalize.Fold(w.PolishWord) == normalize.Fold(polishWord) {
+			words = append(words, w)
+		}
+	}
+	return words, nil
+}
+
+func (r *lookupRepo) ListWordsByFoldedEnglish(englishTranslation string) ([]models.Word, error) {
+	var words []models.Word
+	for _, w := range r.words {
+		for _, t := range w.Translations {
+			if strings.EqualFold(t.EnglishTranslation, englishTranslation) {
+				words = append(words, w)
+				break
+			}
+		}
+	}
+	return words, nil
+}
+
+func (r *lookupRepo) ListPolishHeadwords(prefix string, limit int) ([]string, error) {
+	var headwords []string
+	for _, w := range r.words {
+		if strings.HasPrefix(strings.ToLower(w.PolishWord), strings.ToLower(prefix)) {
+			headwords = append(headwords, w.PolishWord)
+		}
+	}
+	return headwords, nil
+}
+
+func (r *lookupRepo) ListEnglishHeadwords(prefix string, limit int) ([]string, error) {
+	var headwords []string
+	for _, w := range r.words {
+		for _, t := range w.Translations {
+			if strings.HasPrefix(strings.ToLower(t.EnglishTranslation), strings.ToLower(prefix)) {
+				headwords = append(headwords, t.EnglishTranslation)
+			}
+		}
+	}
+	return headwords, nil
+}
+
+// dial starts a server on a random local port and connects to it.
+func dial(t *testing.T) *textproto.Conn {
+	repo := &lookupRepo{words: []models.Word{
+		{PolishWord: "kot", Pronunciation: "kɔt", PartOfSpeech: models.PartOfSpeechNoun, Translations: []models.Translation{
+			{EnglishTranslation: "cat", ExampleSentences: []models.ExampleSentence{{SentenceText: "Kot śpi."}}},
+		}},
+		{PolishWord: "kotek", Translations: []models.Translation{{EnglishTranslation: "kitten"}}},
+		{PolishWord: "pies", Translations: []models.Translation{{EnglishTranslation: "dog"}}},
+	}}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "Listen Should Not Error")
+	go (&Server{Repo: repo}).Serve(listener)
+	t.Cleanup(func() { listener.Close() })
+
+	client, err := textproto.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err, "Dial Should Not Error")
+	t.Cleanup(func() { client.Close() })
+	_, _, err = client.ReadCodeLine(220)
+	require.NoError(t, err, "Expected Banner")
+	return client
+}
+
+// command sends a command and reads the status line, returning its message.
+func command(t *testing.T, client *textproto.Conn, expectCode int, line string) string {
+	require.NoError(t, client.PrintfLine("%s", line), "Sending Command Should Not Error")
+	return status(t, client, expectCode)
+}
+
+func status(t *testing.T, client *textproto.Conn, expectCode int) string {
+	_, message, err := client.ReadCodeLine(expectCode)
+	require.NoError(t, err, "Expected Status %d", expectCode)
+	return message
+}
+
+func readText(t *testing.T, client *textproto.Conn) []string {
+	lines, err := client.ReadDotLines()
+	require.NoError(t, err, "Reading Text Should Not Error")
+	return lines
+}
+
+func TestShow(t *testing.T) {
+	client := dial(t)
+
+	command(t, client, 250, "CLIENT go test")
+	assert.Equal(t, "2 databases present", command(t, client, 110, "SHOW DB"), "Expected Database Count")
+	assert.Equal(t, []string{`pl-en "Polish-English Dictionary"`, `en-pl "English-Polish Dictionary"`}, readText(t, client), "Expected Databases")
+	status(t, client, 250)
+
+	command(t, client, 111, "SHOW STRAT")
+	assert.Equal(t, 2, len(readText(t, client)), "Expected Two Strategies")
+	status(t, client, 250)
+
+	command(t, client, 501, "SHOW NOTHING")
+	command(t, client, 500, "LOOKUP kot")
+	command(t, client, 221, "QUIT")
+}
+
+func TestDefine(t *testing.T) {
+	client := dial(t)
+
+	assert.Equal(t, "1 definitions retrieved", command(t, client, 150, "DEFINE * Kot"), "Expected One Definition")
+	assert.Equal(t, `"kot" pl-en "Polish-English Dictionary"`, status(t, client, 151), "Expected Definition Header")
+	assert.Equal(t, []string{"kot", "   [kɔt] noun", "   1. cat", "      Kot śpi."}, readText(t, client), "Expected Article")
+	status(t, client, 250)
+
+	command(t, client, 150, `DEFINE en-pl "Cat"`)
+	assert.Equal(t, `"cat" en-pl "English-Polish Dictionary"`, status(t, client, 151), "Expected English Headword Ignoring Case")
+	assert.Equal(t, []string{"cat", "   1. kot (noun)", "      Kot śpi."}, readText(t, client), "Expected Reversed Article")
+	status(t, client, 250)
+
+	command(t, client, 552, "DEFINE * kotka")
+	command(t, client, 550, "DEFINE de-pl kot")
+	command(t, client, 501, "DEFINE kot")
+}
+
+func TestMatch(t *testing.T) {
+	client := dial(t)
+
+	command(t, client, 152, "MATCH pl-en prefix ko")
+	assert.Equal(t, []string{`pl-en "kot"`, `pl-en "kotek"`}, readText(t, client), "Expected Prefix Matches")
+	status(t, client, 250)
+
+	command(t, client, 152, "MATCH * exact DOG")
+	assert.Equal(t, []string{`en-pl "dog"`}, readText(t, client), "Expected Exact Match In en-pl")
+	status(t, client, 250)
+
+	command(t, client, 152, "MATCH ! . k")
+	assert.Equal(t, []string{`pl-en "kot"`, `pl-en "kotek"`}, readText(t, client), "Expected First Database Only")
+	status(t, client, 250)
+
+	command(t, client, 551, "MATCH * soundex kot")
+	command(t, client, 552, "MATCH * prefix xyz")
+}
+
+func TestParseCommand(t *testing.T) {
+	args, err := parseCommand(`DEFINE  * "ice cream" 'it''s' a\ b ""`)
+	require.NoError(t, err, "parseCommand Should Not Error")
+	assert.Equal(t, []string{"DEFINE", "*", "ice cream", "its", "a b", ""}, args, "Expected Quoted Words")
+
+	_, err = parseCommand(`DEFINE * "kot`)
+	assert.Error(t, err, "Expected Unterminated Quote Error")
+}
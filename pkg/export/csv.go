@@ -0,0 +1,40 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+)
+
+// csvEncoder writes one row per translation: the Polish word, the English translation
+// and its example sentences. Words without translations are left out.
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	return &csvEncoder{w: csv.NewWriter(w)}
+}
+
+func (e *csvEncoder) begin() error {
+	return e.w.Write([]string{"polish_word", "english_translation", "example_sentences"})
+}
+
+func (e *csvEncoder) word(word *models.Word) error {
+	for _, t := range word.Translations {
+		record := []string{word.PolishWord, t.EnglishTranslation}
+		for _, s := range t.ExampleSentences {
+			record = append(record, s.SentenceText)
+		}
+		if err := e.w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *csvEncoder) end() error {
+	e.w.Flush()
+	return e.w.Error()
+}
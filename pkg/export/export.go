@@ -0,0 +1,99 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+)
+
+// Format of an exported dictionary.
+type Format int
+
+const (
+	// JSONLines writes one JSON object per word.
+	JSONLines Format = iota
+	// CSV writes one row per translation, in the format accepted by the importer.
+	CSV
+	// TEI writes a TEI Lex-0 XML document.
+	TEI
+)
+
+// ParseFormat returns the format with the given name: "jsonl", "csv" or "tei".
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "jsonl", "json":
+		return JSONLines, nil
+	case "csv":
+		return CSV, nil
+	case "tei", "xml":
+		return TEI, nil
+	}
+	return 0, fmt.Errorf("unknown export format: %q", name)
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case TEI:
+		return "application/tei+xml; charset=utf-8"
+	default:
+		return "application/jsonl; charset=utf-8"
+	}
+}
+
+// Extension returns the file extension of the format, including the dot.
+func (f Format) Extension() string {
+	switch f {
+	case CSV:
+		return ".csv"
+	case TEI:
+		return ".tei.xml"
+	default:
+		return ".jsonl"
+	}
+}
+
+// encoder writes words in a particular format.
+type encoder interface {
+	begin() error
+	word(word *models.Word) error
+	end() error
+}
+
+func newEncoder(w io.Writer, format Format) encoder {
+	switch format {
+	case CSV:
+		return newCSVEncoder(w)
+	case TEI:
+		return newTEIEncoder(w)
+	default:
+		return newJSONLinesEncoder(w)
+	}
+}
+
+// Export streams the words matching the filter to w.
+// Words are read from the repository in batches, so memory use does not grow with the dictionary.
+func Export(w io.Writer, repo repository.Repository, filter repository.WordFilter, format Format) error {
+	buf := bufio.NewWriter(w)
+	enc := newEncoder(buf, format)
+
+	if err := enc.begin(); err != nil {
+		return err
+	}
+	err := repo.StreamWords(filter, func(word *models.Word) error {
+		return enc.word(word)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export words: %w", err)
+	}
+	if err := enc.end(); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
@@ -0,0 +1,113 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/sar-michal/dictionary-app/pkg/export"
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wordsRepo streams a fixed list of words. Other repository methods are not used by the exporter.
+type wordsRepo struct {
+	repository.Repository
+	words []models.Word
+}
+
+func (r *wordsRepo) StreamWords(filter repository.WordFilter, fn func(word *models.Word) error) error {
+	for i := range r.words {
+		if err := fn(&r.words[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newWordsRepo() *wordsRepo {
+	return &wordsRepo{words: []models.Word{
+		{
+			WordID:        1,
+			PolishWord:    "kot",
+			PartOfSpeech:  models.PartOfSpeechNoun,
+			Gender:        models.GenderMasculineAnimate,
+			Pronunciation: "kɔt",
+			Translations: []models.Translation{
+				{EnglishTranslation: "cat", ExampleSentences: []models.ExampleSentence{
+					{SentenceText: "Kot śpi, a pies <szczeka>."},
+				}},
+				{EnglishTranslation: "tomcat"},
+			},
+		},
+		{WordID: 2, PolishWord: "dom"},
+	}}
+}
+
+func TestExportJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	err := export.Export(&buf, newWordsRepo(), repository.WordFilter{}, export.JSONLines)
+	require.NoError(t, err, "Export Should Not Error")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Equal(t, 2, len(lines), "Expected One Line Per Word")
+
+	var word map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &word), "Expected Valid JSON")
+	assert.Equal(t, "kot", word["polishWord"], "Expected Polish Word")
+	assert.Equal(t, "noun", word["partOfSpeech"], "Expected Part Of Speech")
+	assert.Len(t, word["translations"], 2, "Expected Both Translations")
+	assert.Contains(t, lines[0], "<szczeka>", "Expected HTML Not To Be Escaped")
+}
+
+func TestExportCSVRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	err := export.Export(&buf, newWordsRepo(), repository.WordFilter{}, export.CSV)
+	require.NoError(t, err, "Export Should Not Error")
+
+	assert.Equal(t, "polish_word,english_translation,example_sentences\n"+
+		"kot,cat,\"Kot śpi, a pies <szczeka>.\"\n"+
+		"kot,tomcat\n", buf.String(), "Expected One Row Per Translation")
+
+}
+
+func TestExportTEI(t *testing.T) {
+	var buf bytes.Buffer
+	err := export.Export(&buf, newWordsRepo(), repository.WordFilter{}, export.TEI)
+	require.NoError(t, err, "Export Should Not Error")
+
+	var doc struct {
+		Entries []struct {
+			ID     string   `xml:"http://www.w3.org/XML/1998/namespace id,attr"`
+			Orth   string   `xml:"form>orth"`
+			Pron   string   `xml:"form>pron"`
+			Gram   []string `xml:"gramGrp>gram"`
+			Senses []struct {
+				Quotes []string `xml:"cit>quote"`
+			} `xml:"sense"`
+		} `xml:"text>body>entry"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc), "Expected Well-Formed XML")
+	require.Equal(t, 2, len(doc.Entries), "Expected One Entry Per Word")
+
+	kot := doc.Entries[0]
+	assert.Equal(t, "w1", kot.ID, "Expected Entry ID")
+	assert.Equal(t, "kot", kot.Orth, "Expected Headword")
+	assert.Equal(t, "kɔt", kot.Pron, "Expected Pronunciation")
+	assert.Equal(t, []string{"noun", "masculine_animate"}, kot.Gram, "Expected Grammar")
+	require.Equal(t, 2, len(kot.Senses), "Expected One Sense Per Translation")
+	assert.Equal(t, []string{"cat", "Kot śpi, a pies <szczeka>."}, kot.Senses[0].Quotes, "Expected Translation And Example")
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := export.ParseFormat("TEI")
+	require.NoError(t, err, "ParseFormat Should Not Error")
+	assert.Equal(t, export.TEI, format, "Expected TEI Format")
+
+	_, err = export.ParseFormat("pdf")
+	assert.Error(t, err, "Expected Error For Unknown Format")
+}
@@ -0,0 +1,58 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+)
+
+type jsonWord struct {
+	WordID        uint              `json:"wordID"`
+	PolishWord    string            `json:"polishWord"`
+	PartOfSpeech  string            `json:"partOfSpeech,omitempty"`
+	Gender        string            `json:"gender,omitempty"`
+	Aspect        string            `json:"aspect,omitempty"`
+	Pronunciation string            `json:"pronunciation,omitempty"`
+	Translations  []jsonTranslation `json:"translations"`
+}
+
+type jsonTranslation struct {
+	EnglishTranslation string   `json:"englishTranslation"`
+	ExampleSentences   []string `json:"exampleSentences"`
+}
+
+type jsonLinesEncoder struct {
+	enc *json.Encoder
+}
+
+func newJSONLinesEncoder(w io.Writer) *jsonLinesEncoder {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &jsonLinesEncoder{enc: enc}
+}
+
+func (e *jsonLinesEncoder) begin() error { return nil }
+
+func (e *jsonLinesEncoder) word(word *models.Word) error {
+	out := jsonWord{
+		WordID:        word.WordID,
+		PolishWord:    word.PolishWord,
+		PartOfSpeech:  string(word.PartOfSpeech),
+		Gender:        string(word.Gender),
+		Aspect:        string(word.Aspect),
+		Pronunciation: word.Transcription(),
+		Translations:  make([]jsonTranslation, len(word.Translations)),
+	}
+	for i, t := range word.Translations {
+		sentences := make([]string, len(t.ExampleSentences))
+		for j, s := range t.ExampleSentences {
+			sentences[j] = s.SentenceText
+		}
+		out.Translations[i] = jsonTranslation{EnglishTranslation: t.EnglishTranslation, ExampleSentences: sentences}
+	}
+	// Encode terminates every value with a newline.
+	return e.enc.Encode(out)
+}
+
+func (e *jsonLinesEncoder) end() error { return nil }
@@ -0,0 +1,154 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+)
+
+const teiHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<TEI xmlns="http://www.tei-c.org/ns/1.0">
+  <teiHeader>
+    <fileDesc>
+      <titleStmt>
+        <title>Polish-English Dictionary</title>
+      </titleStmt>
+      <publicationStmt>
+        <p>Exported from the Dictionary App.</p>
+      </publicationStmt>
+      <sourceDesc>
+        <p>Born digital.</p>
+      </sourceDesc>
+    </fileDesc>
+  </teiHeader>
+  <text>
+    <body>
+`
+
+const teiFooter = `
+    </body>
+  </text>
+</TEI>
+`
+
+// Universal Dependencies part of speech tags, recommended by TEI Lex-0 as normalized values.
+var universalPartOfSpeech = map[models.PartOfSpeech]string{
+	models.PartOfSpeechNoun:         "NOUN",
+	models.PartOfSpeechVerb:         "VERB",
+	models.PartOfSpeechAdjective:    "ADJ",
+	models.PartOfSpeechAdverb:       "ADV",
+	models.PartOfSpeechPronoun:      "PRON",
+	models.PartOfSpeechNumeral:      "NUM",
+	models.PartOfSpeechPreposition:  "ADP",
+	models.PartOfSpeechConjunction:  "CCONJ",
+	models.PartOfSpeechParticle:     "PART",
+	models.PartOfSpeechInterjection: "INTJ",
+}
+
+type teiEntry struct {
+	XMLName xml.Name    `xml:"entry"`
+	ID      string      `xml:"xml:id,attr"`
+	Lang    string      `xml:"xml:lang,attr"`
+	Form    teiForm     `xml:"form"`
+	Gram    *teiGramGrp `xml:"gramGrp,omitempty"`
+	Senses  []teiSense  `xml:"sense"`
+}
+
+type teiForm struct {
+	Type string   `xml:"type,attr"`
+	Orth string   `xml:"orth"`
+	Pron *teiPron `xml:"pron,omitempty"`
+}
+
+type teiPron struct {
+	Notation string `xml:"notation,attr"`
+	Text     string `xml:",chardata"`
+}
+
+type teiGramGrp struct {
+	Grams []teiGram `xml:"gram"`
+}
+
+type teiGram struct {
+	Type string `xml:"type,attr"`
+	Norm string `xml:"norm,attr,omitempty"`
+	Text string `xml:",chardata"`
+}
+
+type teiSense struct {
+	ID   string   `xml:"xml:id,attr"`
+	N    int      `xml:"n,attr"`
+	Cits []teiCit `xml:"cit"`
+}
+
+type teiCit struct {
+	Type  string `xml:"type,attr"`
+	Lang  string `xml:"xml:lang,attr,omitempty"`
+	Quote string `xml:"quote"`
+}
+
+// teiEncoder writes a TEI Lex-0 document with an entry per word and a sense per translation.
+type teiEncoder struct {
+	w   io.Writer
+	enc *xml.Encoder
+}
+
+func newTEIEncoder(w io.Writer) *teiEncoder {
+	enc := xml.NewEncoder(w)
+	enc.Indent("      ", "  ")
+	return &teiEncoder{w: w, enc: enc}
+}
+
+func (e *teiEncoder) begin() error {
+	_, err := io.WriteString(e.w, teiHeader)
+	return err
+}
+
+func (e *teiEncoder) word(word *models.Word) error {
+	entryID := fmt.Sprintf("w%d", word.WordID)
+	entry := teiEntry{
+		ID:   entryID,
+		Lang: "pl",
+		Form: teiForm{Type: "lemma", Orth: word.PolishWord},
+	}
+	if pron := word.Transcription(); pron != "" {
+		entry.Form.Pron = &teiPron{Notation: "ipa", Text: pron}
+	}
+
+	var grams []teiGram
+	if word.PartOfSpeech != "" {
+		grams = append(grams, teiGram{Type: "pos", Norm: universalPartOfSpeech[word.PartOfSpeech], Text: string(word.PartOfSpeech)})
+	}
+	if word.Gender != "" {
+		grams = append(grams, teiGram{Type: "gender", Text: string(word.Gender)})
+	}
+	if word.Aspect != "" {
+		grams = append(grams, teiGram{Type: "aspect", Text: string(word.Aspect)})
+	}
+	if len(grams) > 0 {
+		entry.Gram = &teiGramGrp{Grams: grams}
+	}
+
+	for i, t := range word.Translations {
+		sense := teiSense{
+			ID:   fmt.Sprintf("%s.s%d", entryID, i+1),
+			N:    i + 1,
+			Cits: []teiCit{{Type: "translationEquivalent", Lang: "en", Quote: t.EnglishTranslation}},
+		}
+		for _, s := range t.ExampleSentences {
+			sense.Cits = append(sense.Cits, teiCit{Type: "example", Lang: "pl", Quote: s.SentenceText})
+		}
+		entry.Senses = append(entry.Senses, sense)
+	}
+	return e.enc.Encode(entry)
+}
+
+func (e *teiEncoder) end() error {
+	if err := e.enc.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(e.w, teiFooter)
+	return err
+}
@@ -0,0 +1,294 @@
+package g2p
+
+import (
+	"strings"
+	"unicode"
+)
+
+// phoneKind groups phones by how they take part in voicing assimilation.
+type phoneKind int
+
+const (
+	vowel phoneKind = iota
+	sonorant
+	obstruent
+)
+
+type phone struct {
+	ipa  string
+	kind phoneKind
+	// palatal marks softened consonants such as the "p" in "pies".
+	palatal bool
+	// weak marks "w" and "rz", which are devoiced after a voiceless obstruent
+	// but do not voice the obstruent before them, e.g. "kwiat", "trzy".
+	weak bool
+}
+
+func (p phone) String() string {
+	if p.palatal {
+		return p.ipa + "ʲ"
+	}
+	return p.ipa
+}
+
+// nasalMark is the combining tilde marking nasal vowels.
+const nasalMark = "\u0303"
+
+// voiceless maps voiced obstruents to their voiceless counterparts.
+var voiceless = map[string]string{
+	"b": "p", "d": "t", "g": "k", "v": "f", "z": "s", "ʐ": "ʂ", "ʑ": "ɕ", "ɣ": "x",
+	"d͡z": "t͡s", "d͡ʐ": "t͡ʂ", "d͡ʑ": "t͡ɕ",
+}
+
+// voiced maps voiceless obstruents to their voiced counterparts.
+var voiced = func() map[string]string {
+	m := make(map[string]string, len(voiceless))
+	for v, vl := range voiceless {
+		m[vl] = v
+	}
+	return m
+}()
+
+func isVoiced(ipa string) bool {
+	_, ok := voiceless[ipa]
+	return ok
+}
+
+// softConsonant is a letter sequence ending in "i" that denotes a soft consonant.
+// The "i" is silent before a vowel ("siano") and pronounced otherwise ("si").
+type softConsonant struct {
+	letters string
+	ipa     string
+	kind    phoneKind
+}
+
+// softened lists the soft consonants, longer sequences first.
+var softened = []softConsonant{
+	{"dzi", "d͡ʑ", obstruent},
+	{"ci", "t͡ɕ", obstruent},
+	{"si", "ɕ", obstruent},
+	{"zi", "ʑ", obstruent},
+	{"ni", "ɲ", sonorant},
+}
+
+// digraphs are two-letter spellings of a single phone.
+var digraphs = map[string]phone{
+	"ch": {ipa: "x", kind: obstruent},
+	"cz": {ipa: "t͡ʂ", kind: obstruent},
+	"sz": {ipa: "ʂ", kind: obstruent},
+	"rz": {ipa: "ʐ", kind: obstruent, weak: true},
+	"dż": {ipa: "d͡ʐ", kind: obstruent},
+	"dź": {ipa: "d͡ʑ", kind: obstruent},
+	"dz": {ipa: "d͡z", kind: obstruent},
+}
+
+var letters = map[rune]phone{
+	'a': {ipa: "a", kind: vowel},
+	'ą': {ipa: "ɔ" + nasalMark, kind: vowel},
+	'e': {ipa: "ɛ", kind: vowel},
+	'ę': {ipa: "ɛ" + nasalMark, kind: vowel},
+	'i': {ipa: "i", kind: vowel},
+	'o': {ipa: "ɔ", kind: vowel},
+	'ó': {ipa: "u", kind: vowel},
+	'u': {ipa: "u", kind: vowel},
+	'y': {ipa: "ɨ", kind: vowel},
+	'b': {ipa: "b", kind: obstruent},
+	'c': {ipa: "t͡s", kind: obstruent},
+	'ć': {ipa: "t͡ɕ", kind: obstruent},
+	'd': {ipa: "d", kind: obstruent},
+	'f': {ipa: "f", kind: obstruent},
+	'g': {ipa: "g", kind: obstruent},
+	'h': {ipa: "x", kind: obstruent},
+	'j': {ipa: "j", kind: sonorant},
+	'k': {ipa: "k", kind: obstruent},
+	'l': {ipa: "l", kind: sonorant},
+	'ł': {ipa: "w", kind: sonorant},
+	'm': {ipa: "m", kind: sonorant},
+	'n': {ipa: "n", kind: sonorant},
+	'ń': {ipa: "ɲ", kind: sonorant},
+	'p': {ipa: "p", kind: obstruent},
+	'r': {ipa: "r", kind: sonorant},
+	's': {ipa: "s", kind: obstruent},
+	'ś': {ipa: "ɕ", kind: obstruent},
+	't': {ipa: "t", kind: obstruent},
+	'w': {ipa: "v", kind: obstruent, weak: true},
+	'z': {ipa: "z", kind: obstruent},
+	'ź': {ipa: "ʑ", kind: obstruent},
+	'ż': {ipa: "ʐ", kind: obstruent},
+	// Letters found only in loanwords.
+	'q': {ipa: "k", kind: obstruent},
+	'v': {ipa: "v", kind: obstruent},
+	'x': {ipa: "ks", kind: obstruent},
+}
+
+// palatalizable are consonants softened by a following "i" and vowel, e.g. "biały", "kiedy".
+var palatalizable = map[string]bool{
+	"b": true, "p": true, "m": true, "f": true, "v": true, "k": true, "g": true, "x": true,
+}
+
+func isVowelLetter(r rune) bool {
+	return strings.ContainsRune("aąeęioóuy", r)
+}
+
+// Transcribe returns a broad IPA transcription of Polish text.
+// Words are transcribed separately, so final devoicing applies to every word.
+// Characters other than letters separate words and are dropped. Stress is not marked.
+func Transcribe(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	transcribed := make([]string, 0, len(words))
+	for _, word := range words {
+		if ipa := transcribeWord([]rune(word)); ipa != "" {
+			transcribed = append(transcribed, ipa)
+		}
+	}
+	return strings.Join(transcribed, " ")
+}
+
+func transcribeWord(word []rune) string {
+	phones := segment(word)
+	assimilateVoicing(phones)
+	phones = resolveNasals(phones)
+
+	var b strings.Builder
+	for _, p := range phones {
+		b.WriteString(p.String())
+	}
+	return b.String()
+}
+
+// segment splits a word into phones, resolving digraphs and soft consonants.
+func segment(word []rune) []phone {
+	var phones []phone
+	followedByVowel := func(i int) bool {
+		return i < len(word) && isVowelLetter(word[i])
+	}
+	for i := 0; i < len(word); {
+		rest := string(word[i:])
+
+		if s, ok := softenedPrefix(rest); ok {
+			n := len([]rune(s.letters))
+			phones = append(phones, phone{ipa: s.ipa, kind: s.kind})
+			if !followedByVowel(i + n) {
+				phones = append(phones, letters['i'])
+			}
+			i += n
+			continue
+		}
+
+		var p phone
+		n := 1
+		if i+1 < len(word) {
+			if d, ok := digraphs[string(word[i:i+2])]; ok {
+				p, n = d, 2
+			}
+		}
+		if n == 1 {
+			known, ok := letters[word[i]]
+			if !ok {
+				// Letters from other alphabets are skipped.
+				i++
+				continue
+			}
+			p = known
+		}
+		i += n
+
+		// "i" between a consonant and a vowel only softens the consonant.
+		if palatalizable[p.ipa] && i < len(word) && word[i] == 'i' && followedByVowel(i+1) {
+			p.palatal = true
+			i++
+		}
+		phones = append(phones, p)
+	}
+	return phones
+}
+
+func softenedPrefix(s string) (softConsonant, bool) {
+	for _, soft := range softened {
+		if strings.HasPrefix(s, soft.letters) {
+			return soft, true
+		}
+	}
+	return softConsonant{}, false
+}
+
+// assimilateVoicing applies final devoicing and voicing assimilation in obstruent clusters.
+// Clusters take the voicing of their last obstruent ("wódka" - "vutka", "prośba" - "prɔʑba"),
+// except that "w" and "rz" do not pass their voicing on and are devoiced after voiceless obstruents.
+func assimilateVoicing(phones []phone) {
+	// Regressive assimilation, starting with a voiceless word end.
+	target, assimilate := false, true
+	for i := len(phones) - 1; i >= 0; i-- {
+		p := &phones[i]
+		if p.kind != obstruent {
+			assimilate = false
+			continue
+		}
+		if assimilate {
+			setVoicing(p, target)
+		}
+		if p.weak {
+			assimilate = false
+			continue
+		}
+		target, assimilate = isVoiced(p.ipa), true
+	}
+
+	// Progressive devoicing of "w" and "rz".
+	for i := 1; i < len(phones); i++ {
+		prev, p := phones[i-1], &phones[i]
+		if p.weak && prev.kind == obstruent && !isVoiced(prev.ipa) {
+			setVoicing(p, false)
+		}
+	}
+}
+
+func setVoicing(p *phone, voice bool) {
+	if voice {
+		if v, ok := voiced[p.ipa]; ok {
+			p.ipa = v
+		}
+		return
+	}
+	if vl, ok := voiceless[p.ipa]; ok {
+		p.ipa = vl
+	}
+}
+
+// nasalClosures maps consonants to the nasal consonant a nasal vowel turns into before them.
+var nasalClosures = map[string]string{
+	"p": "m", "b": "m",
+	"t": "n", "d": "n", "t͡s": "n", "d͡z": "n", "t͡ʂ": "n", "d͡ʐ": "n",
+	"t͡ɕ": "ɲ", "d͡ʑ": "ɲ",
+	"k": "ŋ", "g": "ŋ",
+}
+
+// resolveNasals splits nasal vowels before stops and affricates ("ząb" - "zɔmp"),
+// denasalizes them before "l", "ł" and a word-final "ę", and velarizes "n" before "k" and "g".
+func resolveNasals(phones []phone) []phone {
+	result := make([]phone, 0, len(phones))
+	for i, p := range phones {
+		var next *phone
+		if i+1 < len(phones) {
+			next = &phones[i+1]
+		}
+		oral := strings.TrimSuffix(p.ipa, nasalMark)
+		nasal := oral != p.ipa
+
+		switch {
+		case nasal && next != nil && nasalClosures[next.ipa] != "":
+			result = append(result, phone{ipa: oral, kind: vowel}, phone{ipa: nasalClosures[next.ipa], kind: sonorant})
+		case nasal && next != nil && (next.ipa == "l" || next.ipa == "w"):
+			result = append(result, phone{ipa: oral, kind: vowel})
+		case nasal && next == nil && oral == "ɛ":
+			result = append(result, phone{ipa: oral, kind: vowel})
+		case p.ipa == "n" && next != nil && (next.ipa == "k" || next.ipa == "g"):
+			result = append(result, phone{ipa: "ŋ", kind: sonorant})
+		default:
+			result = append(result, p)
+		}
+	}
+	return result
+}
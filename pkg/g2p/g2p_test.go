@@ -0,0 +1,51 @@
+package g2p_test
+
+import (
+	"testing"
+
+	"github.com/sar-michal/dictionary-app/pkg/g2p"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranscribe(t *testing.T) {
+	cases := map[string]string{
+		// Digraphs
+		"szkoła": "ʂkɔwa",
+		"czas":   "t͡ʂas",
+		"rzeka":  "ʐɛka",
+		"dżem":   "d͡ʐɛm",
+		"chata":  "xata",
+		"dzwon":  "d͡zvɔn",
+		// Soft consonants
+		"siano": "ɕanɔ",
+		"zima":  "ʑima",
+		"dzień": "d͡ʑɛɲ",
+		"cicho": "t͡ɕixɔ",
+		"pies":  "pʲɛs",
+		// Final devoicing
+		"chleb": "xlɛp",
+		"mózg":  "musk",
+		"żółw":  "ʐuwf",
+		// Voicing assimilation
+		"wódka":  "vutka",
+		"prośba": "prɔʑba",
+		"wstać":  "fstat͡ɕ",
+		"kwiat":  "kfʲat",
+		"trzy":   "tʂɨ",
+		"twój":   "tfuj",
+		// Nasal vowels
+		"ząb":  "zɔmp",
+		"ręka": "rɛŋka",
+		"idę":  "idɛ",
+		"są":   "sɔ̃",
+		"bank": "baŋk",
+	}
+	for text, ipa := range cases {
+		assert.Equal(t, ipa, g2p.Transcribe(text), "Unexpected transcription of '%s'", text)
+	}
+}
+
+func TestTranscribePhrase(t *testing.T) {
+	assert.Equal(t, "ɲɛ vʲɛm", g2p.Transcribe("Nie wiem!"), "Expected words to be transcribed separately")
+	assert.Equal(t, "", g2p.Transcribe("123"), "Expected empty transcription without letters")
+}
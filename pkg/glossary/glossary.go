@@ -0,0 +1,148 @@
+package glossary
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+)
+
+// Direction of a bilingual dictionary.
+type Direction int
+
+const (
+	PolishEnglish Direction = iota
+	EnglishPolish
+)
+
+// Directions lists both directions, Polish to English first.
+var Directions = []Direction{PolishEnglish, EnglishPolish}
+
+// Code returns the language pair, e.g. "pl-en".
+func (d Direction) Code() string {
+	if d == EnglishPolish {
+		return "en-pl"
+	}
+	return "pl-en"
+}
+
+// Title returns a human-readable name of the dictionary.
+func (d Direction) Title() string {
+	if d == EnglishPolish {
+		return "English-Polish Dictionary"
+	}
+	return "Polish-English Dictionary"
+}
+
+// Sense is a single meaning of a headword in the target language.
+type Sense struct {
+	Text string
+	// Grammar describes the Polish word of an English-Polish sense, e.g. "noun, feminine".
+	Grammar  string
+	Examples []string
+}
+
+// Entry is a headword with its senses. Polish homonyms have an entry each.
+type Entry struct {
+	Headword      string
+	Pronunciation string
+	Grammar       string
+	Senses        []Sense
+}
+
+// Glossary holds the entries of a dictionary grouped by headword.
+type Glossary struct {
+	Direction Direction
+	Entries   map[string][]Entry
+}
+
+// New returns an empty glossary.
+func New(direction Direction) *Glossary {
+	return &Glossary{Direction: direction, Entries: make(map[string][]Entry)}
+}
+
+// Build reads the words matching the filter and arranges them in the given direction.
+func Build(repo repository.Repository, filter repository.WordFilter, direction Direction) (*Glossary, error) {
+	g := New(direction)
+	err := repo.StreamWords(filter, func(word *models.Word) error {
+		g.Add(word)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read words: %w", err)
+	}
+	return g, nil
+}
+
+// Add adds a word with its translations and example sentences. In the English-Polish
+// direction every English translation becomes a headword and the Polish words
+// it translates become its senses.
+func (g *Glossary) Add(word *models.Word) {
+	if g.Direction == EnglishPolish {
+		g.addReversed(word)
+	} else {
+		g.add(word)
+	}
+}
+
+func (g *Glossary) add(word *models.Word) {
+	entry := Entry{
+		Headword:      word.PolishWord,
+		Pronunciation: word.Transcription(),
+		Grammar:       DescribeGrammar(word.Grammar()),
+	}
+	for _, t := range word.Translations {
+		entry.Senses = append(entry.Senses, Sense{Text: t.EnglishTranslation, Examples: sentences(t)})
+	}
+	g.Entries[word.PolishWord] = append(g.Entries[word.PolishWord], entry)
+}
+
+func (g *Glossary) addReversed(word *models.Word) {
+	grammar := DescribeGrammar(word.Grammar())
+	for _, t := range word.Translations {
+		entries := g.Entries[t.EnglishTranslation]
+		if len(entries) == 0 {
+			entries = []Entry{{Headword: t.EnglishTranslation}}
+		}
+		entries[0].Senses = append(entries[0].Senses, Sense{
+			Text:     word.PolishWord,
+			Grammar:  grammar,
+			Examples: sentences(t),
+		})
+		g.Entries[t.EnglishTranslation] = entries
+	}
+}
+
+// Headwords returns the headwords in no particular order.
+func (g *Glossary) Headwords() []string {
+	headwords := make([]string, 0, len(g.Entries))
+	for h := range g.Entries {
+		headwords = append(headwords, h)
+	}
+	return headwords
+}
+
+func sentences(t models.Translation) []string {
+	result := make([]string, len(t.ExampleSentences))
+	for i, s := range t.ExampleSentences {
+		result[i] = s.SentenceText
+	}
+	return result
+}
+
+// DescribeGrammar returns a short description such as "noun, masculine animate" or "verb, perfective".
+func DescribeGrammar(grammar models.Grammar) string {
+	var parts []string
+	for _, v := range []string{string(grammar.PartOfSpeech), string(grammar.Gender), string(grammar.Aspect)} {
+		if v != "" {
+			parts = append(parts, strings.ReplaceAll(v, "_", " "))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FileName returns the base name of exported dictionary files, e.g. "dictionary-pl-en".
+func (d Direction) FileName() string {
+	return "dictionary-" + d.Code()
+}
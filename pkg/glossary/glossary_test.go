@@ -0,0 +1,49 @@
+package glossary_test
+
+import (
+	"testing"
+
+	"github.com/sar-michal/dictionary-app/pkg/glossary"
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// build adds a fixed list of words to a new glossary, the way Build adds the words it streams.
+func build(direction glossary.Direction) *glossary.Glossary {
+	words := []models.Word{
+		{PolishWord: "zamek", PartOfSpeech: models.PartOfSpeechNoun, Gender: models.GenderMasculineInanimate,
+			Translations: []models.Translation{{EnglishTranslation: "castle"}, {EnglishTranslation: "lock"}}},
+		{PolishWord: "zamek", Translations: []models.Translation{{EnglishTranslation: "zip"}}},
+		{PolishWord: "kłódka", PartOfSpeech: models.PartOfSpeechNoun, Gender: models.GenderFeminine,
+			Translations: []models.Translation{{EnglishTranslation: "lock", ExampleSentences: []models.ExampleSentence{
+				{SentenceText: "Kłódka jest zamknięta."},
+			}}}},
+	}
+	g := glossary.New(direction)
+	for i := range words {
+		g.Add(&words[i])
+	}
+	return g
+}
+
+func TestAddPolishEnglish(t *testing.T) {
+	g := build(glossary.PolishEnglish)
+
+	assert.ElementsMatch(t, []string{"zamek", "kłódka"}, g.Headwords(), "Expected Polish Headwords")
+	require.Equal(t, 2, len(g.Entries["zamek"]), "Expected An Entry Per Homonym")
+	assert.Equal(t, "noun, masculine inanimate", g.Entries["zamek"][0].Grammar, "Expected Grammar Description")
+	assert.Equal(t, 2, len(g.Entries["zamek"][0].Senses), "Expected A Sense Per Translation")
+}
+
+func TestAddEnglishPolish(t *testing.T) {
+	g := build(glossary.EnglishPolish)
+
+	assert.ElementsMatch(t, []string{"castle", "lock", "zip"}, g.Headwords(), "Expected English Headwords")
+	require.Equal(t, 1, len(g.Entries["lock"]), "Expected One Entry Per English Headword")
+	senses := g.Entries["lock"][0].Senses
+	require.Equal(t, 2, len(senses), "Expected Both Polish Words")
+	assert.Equal(t, "zamek", senses[0].Text, "Expected 'zamek'")
+	assert.Equal(t, "noun, feminine", senses[1].Grammar, "Expected Grammar Of 'kłódka'")
+	assert.Equal(t, []string{"Kłódka jest zamknięta."}, senses[1].Examples, "Expected Example Sentence")
+}
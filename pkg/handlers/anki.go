@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+
+	"github.com/sar-michal/dictionary-app/pkg/anki"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+)
+
+// AnkiRoute is the pattern the Anki deck handler is registered under.
+const AnkiRoute = "GET /export/anki"
+
+// Anki serves an Anki deck as a file download.
+// The deck, reverse and audio query parameters set the deck name and card options;
+// the other parameters select the words as described in parseWordFilter.
+func Anki(repo repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		filter, err := parseWordFilter(query)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		opts := anki.Options{DeckName: query.Get("deck")}
+		reverse, err := parseOptionalBool(query, "reverse")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		audio, err := parseOptionalBool(query, "audio")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		opts.Reverse = reverse != nil && *reverse
+		opts.Audio = audio != nil && *audio
+
+		// The deck is built before sending it, so errors can still be reported with a proper status.
+		var buf bytes.Buffer
+		if err := anki.Export(&buf, repo, filter, opts); err != nil {
+			log.Printf("Failed to export Anki deck: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", anki.ContentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="dictionary.apkg"`)
+		if _, err := buf.WriteTo(w); err != nil {
+			log.Printf("Failed to send Anki deck: %v", err)
+		}
+	})
+}
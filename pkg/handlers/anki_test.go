@@ -0,0 +1,46 @@
+package handlers_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sar-michal/dictionary-app/pkg/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnki(t *testing.T) {
+	repo := &exportRepo{}
+	mux := http.NewServeMux()
+	mux.Handle(handlers.AnkiRoute, handlers.Anki(repo))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/export/anki?wordIDs=1,3&reverse=true", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code, "Expected OK Status")
+	assert.Equal(t, "application/apkg", rec.Header().Get("Content-Type"), "Expected Anki Content Type")
+	assert.Equal(t, []uint{1, 3}, repo.filter.WordIDs, "Expected Word IDs Filter")
+
+	body := rec.Body.Bytes()
+	reader, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	require.NoError(t, err, "Expected A Zip Archive")
+	var names []string
+	for _, f := range reader.File {
+		names = append(names, f.Name)
+	}
+	assert.ElementsMatch(t, []string{"collection.anki2", "media"}, names, "Expected Collection And Media Index")
+}
+
+func TestAnkiInvalidParameters(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle(handlers.AnkiRoute, handlers.Anki(&exportRepo{}))
+
+	for _, target := range []string{"/export/anki?wordIDs=1,x", "/export/anki?reverse=sometimes"} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected Bad Request Status For %s", target)
+	}
+}
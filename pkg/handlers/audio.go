@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/sar-michal/dictionary-app/pkg/auth"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+	"gorm.io/gorm"
+)
+
+// AudioRoute is the pattern the audio handler is registered under.
+const AudioRoute = "GET /audio/{recordingID}"
+
+// AudioURL returns the path an audio recording is served at.
+func AudioURL(recordingID uint) string {
+	return fmt.Sprintf("/audio/%d", recordingID)
+}
+
+// Audio serves audio recordings from the repository.
+// Range requests are supported, so players can seek without downloading the whole file.
+// Recordings of private words are only served to users who can see the word, see auth.Middleware.
+func Audio(repo repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseUint(r.PathValue("recordingID"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid recording ID", http.StatusBadRequest)
+			return
+		}
+
+		scope := repository.Scope{}
+		// Recordings are cached by shared caches only when anonymous users can see them as well.
+		cacheControl := "public, max-age=31536000, immutable"
+		if user := auth.UserFromContext(r.Context()); user != nil {
+			scope.Owners = user.Owners()
+			cacheControl = "private, max-age=31536000, immutable"
+		}
+
+		recording, err := repo.WithScope(scope).GetAudioRecordingByID(uint(id))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to get audio recording %d: %v", id, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", recording.ContentType)
+		// Recordings are never modified, only replaced by new ones.
+		w.Header().Set("Cache-Control", cacheControl)
+		w.Header().Set("Vary", "Authorization")
+		http.ServeContent(w, r, "", recording.CreatedAt, bytes.NewReader(recording.Data))
+	})
+}
@@ -0,0 +1,101 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sar-michal/dictionary-app/pkg/auth"
+	"github.com/sar-michal/dictionary-app/pkg/handlers"
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// audioRepo serves a single recording. Other repository methods are not used by the handler.
+type audioRepo struct {
+	repository.Repository
+	recording models.AudioRecording
+	// owner of the word the recording belongs to.
+	owner models.Owner
+	scope repository.Scope
+}
+
+func (r *audioRepo) WithScope(scope repository.Scope) repository.Repository {
+	scoped := *r
+	scoped.scope = scope
+	return &scoped
+}
+
+func (r *audioRepo) GetAudioRecordingByID(recordingID uint) (*models.AudioRecording, error) {
+	if recordingID != r.recording.RecordingID || !(r.owner.Shared() || slices.Contains(r.scope.Owners, r.owner)) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &r.recording, nil
+}
+
+func newAudioServer() *http.ServeMux {
+	return newAudioServerOwnedBy(models.SharedOwner)
+}
+
+func newAudioServerOwnedBy(owner models.Owner) *http.ServeMux {
+	repo := &audioRepo{owner: owner, recording: models.AudioRecording{
+		RecordingID: 1,
+		ContentType: "audio/ogg",
+		Data:        []byte("0123456789"),
+		CreatedAt:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}}
+	mux := http.NewServeMux()
+	mux.Handle(handlers.AudioRoute, handlers.Audio(repo))
+	return mux
+}
+
+func TestAudio(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newAudioServer().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, handlers.AudioURL(1), nil))
+
+	require.Equal(t, http.StatusOK, rec.Code, "Expected OK Status")
+	assert.Equal(t, "audio/ogg", rec.Header().Get("Content-Type"), "Expected Stored Content Type")
+	assert.Equal(t, "0123456789", rec.Body.String(), "Expected Whole Recording")
+	assert.Equal(t, "public, max-age=31536000, immutable", rec.Header().Get("Cache-Control"), "Expected Public Caching")
+}
+
+func TestAudioPrivate(t *testing.T) {
+	user := &models.User{UserID: 7}
+	server := newAudioServerOwnedBy(models.UserOwner(user.UserID))
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, handlers.AudioURL(1), nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code, "Expected Private Recording To Be Hidden From Anonymous Users")
+
+	req := httptest.NewRequest(http.MethodGet, handlers.AudioURL(1), nil)
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, req.WithContext(auth.WithUser(req.Context(), user)))
+	require.Equal(t, http.StatusOK, rec.Code, "Expected OK Status For The Owner")
+	assert.Equal(t, "private, max-age=31536000, immutable", rec.Header().Get("Cache-Control"), "Expected Private Caching")
+}
+
+func TestAudioRange(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, handlers.AudioURL(1), nil)
+	req.Header.Set("Range", "bytes=2-5")
+	rec := httptest.NewRecorder()
+	newAudioServer().ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusPartialContent, rec.Code, "Expected Partial Content Status")
+	assert.Equal(t, "bytes 2-5/10", rec.Header().Get("Content-Range"), "Expected Content Range")
+	assert.Equal(t, "2345", rec.Body.String(), "Expected Requested Bytes")
+}
+
+func TestAudioNotFound(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newAudioServer().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, handlers.AudioURL(2), nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code, "Expected Not Found Status")
+
+	rec = httptest.NewRecorder()
+	newAudioServer().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/audio/abc", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected Bad Request Status")
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/sar-michal/dictionary-app/pkg/export"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+)
+
+// ExportRoute is the pattern the export handler is registered under.
+const ExportRoute = "GET /export"
+
+// Export streams the dictionary as a file download.
+// The format query parameter selects jsonl (the default), csv or tei;
+// the other parameters filter the words as described in parseWordFilter.
+func Export(repo repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		format := export.JSONLines
+		if name := query.Get("format"); name != "" {
+			var err error
+			if format, err = export.ParseFormat(name); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		filter, err := parseWordFilter(query)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="dictionary%s"`, format.Extension()))
+		if err := export.Export(w, repo, filter, format); err != nil {
+			// The response has already started, so the client only sees a truncated file.
+			log.Printf("Failed to export dictionary: %v", err)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sar-michal/dictionary-app/pkg/handlers"
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportRepo records the filter it was called with and streams a single word.
+type exportRepo struct {
+	repository.Repository
+	filter repository.WordFilter
+}
+
+func (r *exportRepo) StreamWords(filter repository.WordFilter, fn func(word *models.Word) error) error {
+	r.filter = filter
+	return fn(&models.Word{WordID: 1, PolishWord: "kot", Translations: []models.Translation{{EnglishTranslation: "cat"}}})
+}
+
+func TestExport(t *testing.T) {
+	repo := &exportRepo{}
+	mux := http.NewServeMux()
+	mux.Handle(handlers.ExportRoute, handlers.Export(repo))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/export?format=csv&partOfSpeech=noun&hasTranslations=true", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code, "Expected OK Status")
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"), "Expected CSV Content Type")
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), `filename="dictionary.csv"`, "Expected Download File Name")
+	assert.Equal(t, "polish_word,english_translation,example_sentences\nkot,cat\n", rec.Body.String(), "Expected CSV Body")
+
+	require.NotNil(t, repo.filter.PartOfSpeech, "Expected Part Of Speech Filter")
+	assert.Equal(t, models.PartOfSpeechNoun, *repo.filter.PartOfSpeech, "Expected Noun Filter")
+	require.NotNil(t, repo.filter.HasTranslations, "Expected Has Translations Filter")
+	assert.True(t, *repo.filter.HasTranslations, "Expected Words With Translations")
+}
+
+func TestExportInvalidParameters(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle(handlers.ExportRoute, handlers.Export(&exportRepo{}))
+
+	for _, target := range []string{"/export?format=pdf", "/export?hasTranslations=maybe"} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected Bad Request Status For %s", target)
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+)
+
+// parseWordFilter reads a word filter from query parameters: wordIDs (comma-separated),
+// startsWith, partOfSpeech, gender, aspect, hasTranslations and hasExampleSentences.
+// Grammatical values are lowercase, e.g. "partOfSpeech=noun".
+func parseWordFilter(query url.Values) (repository.WordFilter, error) {
+	filter := repository.WordFilter{StartsWith: query.Get("startsWith")}
+
+	if v := query.Get("wordIDs"); v != "" {
+		ids, err := repository.ParseWordIDs(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.WordIDs = ids
+	}
+
+	if v := query.Get("partOfSpeech"); v != "" {
+		partOfSpeech := models.PartOfSpeech(v)
+		filter.PartOfSpeech = &partOfSpeech
+	}
+	if v := query.Get("gender"); v != "" {
+		gender := models.Gender(v)
+		filter.Gender = &gender
+	}
+	if v := query.Get("aspect"); v != "" {
+		aspect := models.Aspect(v)
+		filter.Aspect = &aspect
+	}
+
+	var err error
+	if filter.HasTranslations, err = parseOptionalBool(query, "hasTranslations"); err != nil {
+		return filter, err
+	}
+	if filter.HasExampleSentences, err = parseOptionalBool(query, "hasExampleSentences"); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+func parseOptionalBool(query url.Values, name string) (*bool, error) {
+	v := query.Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %q", name, v)
+	}
+	return &b, nil
+}
@@ -0,0 +1,261 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+	"gorm.io/gorm"
+)
+
+// Format of an imported file.
+type Format int
+
+const (
+	CSV Format = iota
+	TSV
+)
+
+// ParseFormat returns the format with the given name, e.g. "csv" or "tsv".
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "csv":
+		return CSV, nil
+	case "tsv":
+		return TSV, nil
+	}
+	return 0, fmt.Errorf("unknown import format: %q", name)
+}
+
+// RowStatus is the outcome of importing a single row.
+type RowStatus int
+
+const (
+	// RowCreated means the row added a word, translation or example sentence.
+	RowCreated RowStatus = iota
+	// RowSkipped means everything in the row was already in the dictionary.
+	RowSkipped
+	// RowRejected means the row failed validation and was not imported.
+	RowRejected
+)
+
+// RowResult describes how a row was imported. Line is the line the row starts at, counting from 1.
+type RowResult struct {
+	Line               int
+	Status             RowStatus
+	PolishWord         string
+	EnglishTranslation string
+	Errors             []string
+}
+
+// Report summarizes an import.
+type Report struct {
+	Created  int
+	Skipped  int
+	Rejected int
+	Rows     []RowResult
+}
+
+func (r *Report) add(row RowResult) {
+	switch row.Status {
+	case RowCreated:
+		r.Created++
+	case RowSkipped:
+		r.Skipped++
+	case RowRejected:
+		r.Rejected++
+	}
+	r.Rows = append(r.Rows, row)
+}
+
+// Importer imports dictionary entries from CSV or TSV files.
+//
+// Each row holds a Polish word, its English translation and any number of example sentences,
+// one per column. A header row starting with "polish" is skipped.
+type Importer struct {
+	Repo repository.Repository
+	// Validate sanitizes a single value, returning an error if the value is not acceptable.
+	Validate func(input string) (string, error)
+}
+
+// row is a parsed and validated record.
+type row struct {
+	result    RowResult
+	sentences []string
+}
+
+// Import reads all rows from r and imports them in a single transaction.
+// Invalid rows are rejected and reported; a database error aborts the whole import.
+func (imp *Importer) Import(r io.Reader, format Format) (*Report, error) {
+	rows, err := imp.parse(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	err = imp.Repo.Transaction(func(txRepo repository.Repository) error {
+		for _, row := range rows {
+			if row.result.Status != RowRejected {
+				status, err := importRow(txRepo, row)
+				if err != nil {
+					return fmt.Errorf("failed to import line %d: %w", row.result.Line, err)
+				}
+				row.result.Status = status
+			}
+			report.add(row.result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// parse reads and validates all rows. Rows that cannot be parsed or fail validation are marked as rejected.
+func (imp *Importer) parse(r io.Reader, format Format) ([]row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	if format == TSV {
+		reader.Comma = '\t'
+		// TSV files exported from spreadsheets do not escape quotes.
+		reader.LazyQuotes = true
+	}
+
+	var rows []row
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, row{result: RowResult{
+				Line:   parseErr.StartLine,
+				Status: RowRejected,
+				Errors: []string{parseErr.Err.Error()},
+			}})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+
+		if first {
+			// Spreadsheets often start files with a byte order mark.
+			record[0] = strings.TrimPrefix(record[0], "\uFEFF")
+			if isHeader(record) {
+				continue
+			}
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, imp.validate(line, record))
+	}
+	return rows, nil
+}
+
+func isHeader(record []string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(record[0])), "polish")
+}
+
+// validate checks a record and returns the row to import, or a rejected row with all validation errors.
+func (imp *Importer) validate(line int, record []string) row {
+	result := row{result: RowResult{Line: line, Status: RowCreated}}
+	if len(record) < 2 {
+		result.result.Status = RowRejected
+		result.result.Errors = []string{"expected a polish word and an english translation"}
+		return result
+	}
+
+	var errs []string
+	polishWord, err := imp.Validate(record[0])
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("polish word: %v", err))
+	}
+	englishTranslation, err := imp.Validate(record[1])
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("english translation: %v", err))
+	}
+	for i, cell := range record[2:] {
+		if strings.TrimSpace(cell) == "" {
+			// Rows have different numbers of sentences, so trailing cells may be empty.
+			continue
+		}
+		sentence, err := imp.Validate(cell)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("example sentence %d: %v", i+1, err))
+			continue
+		}
+		result.sentences = append(result.sentences, sentence)
+	}
+
+	result.result.PolishWord = polishWord
+	result.result.EnglishTranslation = englishTranslation
+	if len(errs) > 0 {
+		result.result.Status = RowRejected
+		result.result.Errors = errs
+	}
+	return result
+}
+
+// importRow creates whatever is missing from the row and reports whether anything was created.
+func importRow(txRepo repository.Repository, row row) (RowStatus, error) {
+	polishWord, englishTranslation := row.result.PolishWord, row.result.EnglishTranslation
+
+	exists, err := rowExists(txRepo, polishWord, englishTranslation, row.sentences)
+	if err != nil {
+		return 0, err
+	}
+	if exists {
+		return RowSkipped, nil
+	}
+
+	word, err := txRepo.GetOrCreateWord(polishWord, models.Grammar{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get or create word: %w", err)
+	}
+	translation, err := txRepo.GetOrCreateTranslation(word.WordID, englishTranslation)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get or create translation: %w", err)
+	}
+	for _, sentence := range row.sentences {
+		_, err := txRepo.GetOrCreateExampleSentence(translation.TranslationID, sentence)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get or create example sentence: %w", err)
+		}
+	}
+	return RowCreated, nil
+}
+
+// rowExists reports whether the word, its translation and all example sentences are already stored.
+func rowExists(txRepo repository.Repository, polishWord, englishTranslation string, sentences []string) (bool, error) {
+	var partOfSpeech models.PartOfSpeech
+	word, err := txRepo.GetWordByPolish(polishWord, &partOfSpeech)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get word: %w", err)
+	}
+
+	for _, translation := range word.Translations {
+		if translation.EnglishTranslation != englishTranslation {
+			continue
+		}
+		for _, sentence := range sentences {
+			found := slices.ContainsFunc(translation.ExampleSentences, func(s models.ExampleSentence) bool {
+				return s.SentenceText == sentence
+			})
+			if !found {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,174 @@
+package importer_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sar-michal/dictionary-app/pkg/importer"
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// memoryRepo keeps words in memory. Only the methods used by the importer are implemented.
+type memoryRepo struct {
+	repository.Repository
+	words  []models.Word
+	nextID uint
+}
+
+func (r *memoryRepo) id() uint {
+	r.nextID++
+	return r.nextID
+}
+
+func (r *memoryRepo) Transaction(fn func(repo repository.Repository) error) error {
+	return fn(r)
+}
+
+func (r *memoryRepo) GetWordByPolish(polishWord string, partOfSpeech *models.PartOfSpeech) (*models.Word, error) {
+	for i := range r.words {
+		if r.words[i].PolishWord == polishWord {
+			return &r.words[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryRepo) GetOrCreateWord(polishWord string, grammar models.Grammar) (*models.Word, error) {
+	if word, err := r.GetWordByPolish(polishWord, nil); err == nil {
+		return word, nil
+	}
+	r.words = append(r.words, models.Word{WordID: r.id(), PolishWord: polishWord})
+	return &r.words[len(r.words)-1], nil
+}
+
+func (r *memoryRepo) translation(translationID uint) *models.Translation {
+	for i := range r.words {
+		for j := range r.words[i].Translations {
+			if r.words[i].Translations[j].TranslationID == translationID {
+				return &r.words[i].Translations[j]
+			}
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepo) GetOrCreateTranslation(wordID uint, englishTranslation string) (*models.Translation, error) {
+	for i := range r.words {
+		if r.words[i].WordID != wordID {
+			continue
+		}
+		word := &r.words[i]
+		for j := range word.Translations {
+			if word.Translations[j].EnglishTranslation == englishTranslation {
+				return &word.Translations[j], nil
+			}
+		}
+		word.Translations = append(word.Translations, models.Translation{
+			TranslationID:      r.id(),
+			WordID:             wordID,
+			EnglishTranslation: englishTranslation,
+		})
+		return &word.Translations[len(word.Translations)-1], nil
+	}
+	return nil, fmt.Errorf("word %d not found", wordID)
+}
+
+func (r *memoryRepo) GetOrCreateExampleSentence(translationID uint, sentenceText string) (*models.ExampleSentence, error) {
+	translation := r.translation(translationID)
+	if translation == nil {
+		return nil, fmt.Errorf("translation %d not found", translationID)
+	}
+	for i := range translation.ExampleSentences {
+		if translation.ExampleSentences[i].SentenceText == sentenceText {
+			return &translation.ExampleSentences[i], nil
+		}
+	}
+	translation.ExampleSentences = append(translation.ExampleSentences, models.ExampleSentence{
+		SentenceID:    r.id(),
+		TranslationID: translationID,
+		SentenceText:  sentenceText,
+	})
+	return &translation.ExampleSentences[len(translation.ExampleSentences)-1], nil
+}
+
+func validate(input string) (string, error) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return "", fmt.Errorf("input cannot be empty")
+	}
+	return trimmed, nil
+}
+
+func TestImportCSV(t *testing.T) {
+	repo := &memoryRepo{}
+	imp := &importer.Importer{Repo: repo, Validate: validate}
+	input := "polish_word,english_translation,sentences\n" +
+		"kot,cat,\"Kot śpi.\"\n" +
+		"kot,cat\n" +
+		",dog\n" +
+		"pisać,write,\"Piszę list,\nktóry jest długi.\",Piszę.\n" +
+		"pies\n"
+
+	report, err := imp.Import(strings.NewReader(input), importer.CSV)
+	require.NoError(t, err, "Import Should Not Error")
+	assert.Equal(t, 2, report.Created, "Expected Two Created Rows")
+	assert.Equal(t, 1, report.Skipped, "Expected One Skipped Row")
+	assert.Equal(t, 2, report.Rejected, "Expected Two Rejected Rows")
+
+	require.Equal(t, 5, len(report.Rows), "Expected A Result For Every Row")
+	lines := []int{2, 3, 4, 5, 7}
+	statuses := []importer.RowStatus{
+		importer.RowCreated, importer.RowSkipped, importer.RowRejected, importer.RowCreated, importer.RowRejected,
+	}
+	for i, row := range report.Rows {
+		assert.Equal(t, lines[i], row.Line, "Unexpected Line Of Row %d", i)
+		assert.Equal(t, statuses[i], row.Status, "Unexpected Status Of Line %d", row.Line)
+	}
+	assert.Equal(t, []string{"polish word: input cannot be empty"}, report.Rows[2].Errors, "Expected Validation Error")
+
+	word, err := repo.GetWordByPolish("pisać", nil)
+	require.NoError(t, err, "Expected 'pisać' To Be Imported")
+	require.Equal(t, 1, len(word.Translations), "Expected One Translation")
+	assert.Equal(t, 2, len(word.Translations[0].ExampleSentences), "Expected Both Example Sentences")
+}
+
+func TestImportTSV(t *testing.T) {
+	repo := &memoryRepo{}
+	imp := &importer.Importer{Repo: repo, Validate: validate}
+	input := "dom\thouse\tMój \"dom\" jest duży.\n"
+
+	report, err := imp.Import(strings.NewReader(input), importer.TSV)
+	require.NoError(t, err, "Import Should Not Error")
+	require.Equal(t, 1, report.Created, "Expected One Created Row")
+
+	word, err := repo.GetWordByPolish("dom", nil)
+	require.NoError(t, err, "Expected 'dom' To Be Imported")
+	assert.Equal(t, "Mój \"dom\" jest duży.", word.Translations[0].ExampleSentences[0].SentenceText, "Expected Quotes To Be Kept")
+}
+
+func TestImportMalformedCSV(t *testing.T) {
+	imp := &importer.Importer{Repo: &memoryRepo{}, Validate: validate}
+	input := "kot,cat\n" +
+		"pies,\"do\"g\n" +
+		"dom,house\n"
+
+	report, err := imp.Import(strings.NewReader(input), importer.CSV)
+	require.NoError(t, err, "Import Should Not Error")
+	assert.Equal(t, 2, report.Created, "Expected Valid Rows To Be Imported")
+	require.Equal(t, 1, report.Rejected, "Expected Malformed Row To Be Rejected")
+	assert.Equal(t, 2, report.Rows[1].Line, "Expected Line Of Malformed Row")
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := importer.ParseFormat("TSV")
+	require.NoError(t, err, "ParseFormat Should Not Error")
+	assert.Equal(t, importer.TSV, format, "Expected TSV Format")
+
+	_, err = importer.ParseFormat("xlsx")
+	assert.Error(t, err, "Expected Error For Unknown Format")
+}
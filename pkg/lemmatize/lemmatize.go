@@ -0,0 +1,155 @@
+package lemmatize
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+)
+
+// Candidate is a possible base form of an inflected word together with the guessed grammatical form.
+type Candidate struct {
+	Lemma string
+	Tags  models.InflectionTags
+}
+
+// rule replaces an inflectional ending with the ending of the base form.
+type rule struct {
+	suffix      string
+	replacement string
+	tags        models.InflectionTags
+}
+
+// minStemLength is the minimal number of letters left after stripping a suffix.
+const minStemLength = 2
+
+var (
+	sg = models.NumberSingular
+	pl = models.NumberPlural
+)
+
+func nounTags(c models.GrammaticalCase, n models.GrammaticalNumber) models.InflectionTags {
+	return models.InflectionTags{Case: c, Number: n}
+}
+
+func verbTags(p models.Person, n models.GrammaticalNumber, t models.Tense) models.InflectionTags {
+	return models.InflectionTags{Person: p, Number: n, Tense: t}
+}
+
+func pastTags(p models.Person, n models.GrammaticalNumber, g models.Gender) models.InflectionTags {
+	return models.InflectionTags{Person: p, Number: n, Tense: models.TensePast, Gender: g}
+}
+
+// rules covers the regular endings of Polish nouns, adjectives and verbs.
+// Alternations in the stem (e.g. "pies" - "psa") are not handled and need stored inflections.
+var rules = []rule{
+	// Nouns
+	{"ami", "", nounTags(models.CaseInstrumental, pl)},
+	{"ami", "a", nounTags(models.CaseInstrumental, pl)},
+	{"ami", "o", nounTags(models.CaseInstrumental, pl)},
+	{"ach", "", nounTags(models.CaseLocative, pl)},
+	{"ach", "a", nounTags(models.CaseLocative, pl)},
+	{"ach", "o", nounTags(models.CaseLocative, pl)},
+	{"om", "", nounTags(models.CaseDative, pl)},
+	{"om", "a", nounTags(models.CaseDative, pl)},
+	{"om", "o", nounTags(models.CaseDative, pl)},
+	{"ów", "", nounTags(models.CaseGenitive, pl)},
+	{"owi", "", nounTags(models.CaseDative, sg)},
+	{"em", "", nounTags(models.CaseInstrumental, sg)},
+	{"em", "o", nounTags(models.CaseInstrumental, sg)},
+	{"a", "", nounTags(models.CaseGenitive, sg)},
+	{"a", "o", nounTags(models.CaseNominative, pl)},
+	{"u", "", nounTags(models.CaseGenitive, sg)},
+	{"y", "", nounTags(models.CaseNominative, pl)},
+	{"y", "a", nounTags(models.CaseGenitive, sg)},
+	{"i", "a", nounTags(models.CaseGenitive, sg)},
+	{"ę", "a", nounTags(models.CaseAccusative, sg)},
+	{"ą", "a", nounTags(models.CaseInstrumental, sg)},
+
+	// Adjectives
+	{"ego", "y", nounTags(models.CaseGenitive, sg)},
+	{"ego", "i", nounTags(models.CaseGenitive, sg)},
+	{"emu", "y", nounTags(models.CaseDative, sg)},
+	{"emu", "i", nounTags(models.CaseDative, sg)},
+	{"ymi", "y", nounTags(models.CaseInstrumental, pl)},
+	{"imi", "i", nounTags(models.CaseInstrumental, pl)},
+	{"ych", "y", nounTags(models.CaseGenitive, pl)},
+	{"ich", "i", nounTags(models.CaseGenitive, pl)},
+	{"ym", "y", nounTags(models.CaseInstrumental, sg)},
+	{"im", "i", nounTags(models.CaseInstrumental, sg)},
+	{"ej", "y", models.InflectionTags{Case: models.CaseGenitive, Number: sg, Gender: models.GenderFeminine}},
+	{"ej", "i", models.InflectionTags{Case: models.CaseGenitive, Number: sg, Gender: models.GenderFeminine}},
+	{"a", "y", models.InflectionTags{Case: models.CaseNominative, Number: sg, Gender: models.GenderFeminine}},
+	{"e", "y", models.InflectionTags{Case: models.CaseNominative, Number: sg, Gender: models.GenderNeuter}},
+	{"ą", "y", models.InflectionTags{Case: models.CaseAccusative, Number: sg, Gender: models.GenderFeminine}},
+
+	// Verbs, present tense
+	{"am", "ać", verbTags(models.PersonFirst, sg, models.TensePresent)},
+	{"asz", "ać", verbTags(models.PersonSecond, sg, models.TensePresent)},
+	{"a", "ać", verbTags(models.PersonThird, sg, models.TensePresent)},
+	{"amy", "ać", verbTags(models.PersonFirst, pl, models.TensePresent)},
+	{"acie", "ać", verbTags(models.PersonSecond, pl, models.TensePresent)},
+	{"ają", "ać", verbTags(models.PersonThird, pl, models.TensePresent)},
+	{"em", "eć", verbTags(models.PersonFirst, sg, models.TensePresent)},
+	{"esz", "eć", verbTags(models.PersonSecond, sg, models.TensePresent)},
+	{"e", "eć", verbTags(models.PersonThird, sg, models.TensePresent)},
+	{"emy", "eć", verbTags(models.PersonFirst, pl, models.TensePresent)},
+	{"ecie", "eć", verbTags(models.PersonSecond, pl, models.TensePresent)},
+	{"eją", "eć", verbTags(models.PersonThird, pl, models.TensePresent)},
+	{"ię", "ić", verbTags(models.PersonFirst, sg, models.TensePresent)},
+	{"isz", "ić", verbTags(models.PersonSecond, sg, models.TensePresent)},
+	{"i", "ić", verbTags(models.PersonThird, sg, models.TensePresent)},
+	{"imy", "ić", verbTags(models.PersonFirst, pl, models.TensePresent)},
+	{"icie", "ić", verbTags(models.PersonSecond, pl, models.TensePresent)},
+	{"ią", "ić", verbTags(models.PersonThird, pl, models.TensePresent)},
+	{"ę", "yć", verbTags(models.PersonFirst, sg, models.TensePresent)},
+	{"ysz", "yć", verbTags(models.PersonSecond, sg, models.TensePresent)},
+	{"y", "yć", verbTags(models.PersonThird, sg, models.TensePresent)},
+	{"ymy", "yć", verbTags(models.PersonFirst, pl, models.TensePresent)},
+	{"ycie", "yć", verbTags(models.PersonSecond, pl, models.TensePresent)},
+	{"ą", "yć", verbTags(models.PersonThird, pl, models.TensePresent)},
+
+	// Verbs, past tense
+	{"łem", "ć", pastTags(models.PersonFirst, sg, models.GenderMasculinePersonal)},
+	{"łam", "ć", pastTags(models.PersonFirst, sg, models.GenderFeminine)},
+	{"łeś", "ć", pastTags(models.PersonSecond, sg, models.GenderMasculinePersonal)},
+	{"łaś", "ć", pastTags(models.PersonSecond, sg, models.GenderFeminine)},
+	{"ł", "ć", pastTags(models.PersonThird, sg, models.GenderMasculinePersonal)},
+	{"ła", "ć", pastTags(models.PersonThird, sg, models.GenderFeminine)},
+	{"ło", "ć", pastTags(models.PersonThird, sg, models.GenderNeuter)},
+	{"liśmy", "ć", pastTags(models.PersonFirst, pl, models.GenderMasculinePersonal)},
+	{"łyśmy", "ć", pastTags(models.PersonFirst, pl, models.GenderFeminine)},
+	{"liście", "ć", pastTags(models.PersonSecond, pl, models.GenderMasculinePersonal)},
+	{"łyście", "ć", pastTags(models.PersonSecond, pl, models.GenderFeminine)},
+	{"li", "ć", pastTags(models.PersonThird, pl, models.GenderMasculinePersonal)},
+	{"ły", "ć", pastTags(models.PersonThird, pl, models.GenderFeminine)},
+}
+
+func init() {
+	// Longer endings are more specific, so they are tried first.
+	sort.SliceStable(rules, func(i, j int) bool {
+		return utf8.RuneCountInString(rules[i].suffix) > utf8.RuneCountInString(rules[j].suffix)
+	})
+}
+
+// Candidates returns possible base forms of an inflected Polish word, most specific first.
+// Each lemma is returned once, with the grammatical form of the first matching rule.
+func Candidates(form string) []Candidate {
+	form = strings.ToLower(form)
+	seen := make(map[string]bool)
+	var candidates []Candidate
+	for _, r := range rules {
+		stem, ok := strings.CutSuffix(form, r.suffix)
+		if !ok || utf8.RuneCountInString(stem) < minStemLength {
+			continue
+		}
+		lemma := stem + r.replacement
+		if lemma == form || seen[lemma] {
+			continue
+		}
+		seen[lemma] = true
+		candidates = append(candidates, Candidate{Lemma: lemma, Tags: r.tags})
+	}
+	return candidates
+}
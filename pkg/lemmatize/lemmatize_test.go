@@ -0,0 +1,52 @@
+package lemmatize_test
+
+import (
+	"testing"
+
+	"github.com/sar-michal/dictionary-app/pkg/lemmatize"
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func lemmas(candidates []lemmatize.Candidate) []string {
+	var result []string
+	for _, c := range candidates {
+		result = append(result, c.Lemma)
+	}
+	return result
+}
+
+func TestCandidates(t *testing.T) {
+	cases := map[string]string{
+		"kota":      "kot",
+		"kotami":    "kot",
+		"kobietą":   "kobieta",
+		"oknach":    "okno",
+		"dobrego":   "dobry",
+		"czytam":    "czytać",
+		"czytałem":  "czytać",
+		"robię":     "robić",
+		"Kotów":     "kot",
+		"umieliśmy": "umieć",
+	}
+	for form, lemma := range cases {
+		assert.Contains(t, lemmas(lemmatize.Candidates(form)), lemma, "Expected '%s' among candidates for '%s'", lemma, form)
+	}
+}
+
+func TestCandidatesTags(t *testing.T) {
+	for _, c := range lemmatize.Candidates("czytałam") {
+		if c.Lemma == "czytać" {
+			assert.Equal(t, models.TensePast, c.Tags.Tense, "Expected past tense")
+			assert.Equal(t, models.PersonFirst, c.Tags.Person, "Expected first person")
+			assert.Equal(t, models.GenderFeminine, c.Tags.Gender, "Expected feminine gender")
+			return
+		}
+	}
+	t.Fatal("Expected 'czytać' among candidates for 'czytałam'")
+}
+
+func TestCandidatesShortStem(t *testing.T) {
+	assert.Empty(t, lemmatize.Candidates("ja"), "Stems shorter than two letters should not be produced")
+	assert.Empty(t, lemmatize.Candidates("kot"), "Base forms without known endings should have no candidates")
+}
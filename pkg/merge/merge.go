@@ -0,0 +1,173 @@
+package merge
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+)
+
+// ErrSameWord is returned when merging a word into itself or moving a translation to the word it belongs to.
+var ErrSameWord = errors.New("source and target are the same word")
+
+// ErrHiddenEntries is returned when merging a word or translation with translations or example sentences
+// of other users, which would be moved to the trash with it.
+var ErrHiddenEntries = errors.New("source has private entries of other users")
+
+// Summary counts the translations and example sentences moved to the target word, and the ones merged
+// into an equal translation or example sentence the target already had.
+type Summary struct {
+	MovedTranslations      int
+	MergedTranslations     int
+	MovedExampleSentences  int
+	MergedExampleSentences int
+}
+
+// MergeWords moves the translations, inflected forms, relations and audio recordings of the source word
+// to the target word in a transaction, and moves the source word to the trash.
+// Translations equal to one of the target are merged into it, see MoveTranslation.
+// Fails with ErrHiddenEntries if the source word has translations or example sentences not seen through repo.
+func MergeWords(repo repository.Repository, sourceID uint, targetID uint) (*Summary, error) {
+	if sourceID == targetID {
+		return nil, ErrSameWord
+	}
+	summary := &Summary{}
+	err := repo.Transaction(func(txRepo repository.Repository) error {
+		source, err := txRepo.GetWordByID(sourceID)
+		if err != nil {
+			return fmt.Errorf("failed to find source word: %w", err)
+		}
+		target, err := txRepo.GetWordByID(targetID)
+		if err != nil {
+			return fmt.Errorf("failed to find target word: %w", err)
+		}
+		if err := checkHidden(txRepo, models.EntryWord, sourceID); err != nil {
+			return err
+		}
+		for i := range source.Translations {
+			if err := moveTranslation(txRepo, &source.Translations[i], target, summary); err != nil {
+				return err
+			}
+		}
+		if err := txRepo.MoveWordDependents(sourceID, targetID); err != nil {
+			return fmt.Errorf("failed to move inflections, relations and audio recordings: %w", err)
+		}
+		if err := txRepo.DeleteWord(sourceID); err != nil {
+			return fmt.Errorf("failed to delete source word: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// MoveTranslation moves a translation with its example sentences to another word in a transaction.
+// If the word already has an equal translation, the example sentences and review cards are moved to it instead,
+// skipping the ones it already has, and the emptied translation is moved to the trash.
+// Merging fails with ErrHiddenEntries if the translation has example sentences not seen through repo.
+// Translations and example sentences are equal when they have the same text and owner.
+func MoveTranslation(repo repository.Repository, translationID uint, toWordID uint) (*Summary, error) {
+	summary := &Summary{}
+	err := repo.Transaction(func(txRepo repository.Repository) error {
+		translation, err := txRepo.GetTranslationByID(translationID)
+		if err != nil {
+			return fmt.Errorf("failed to find translation: %w", err)
+		}
+		if translation.WordID == toWordID {
+			return ErrSameWord
+		}
+		target, err := txRepo.GetWordByID(toWordID)
+		if err != nil {
+			return fmt.Errorf("failed to find target word: %w", err)
+		}
+		return moveTranslation(txRepo, translation, target, summary)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// moveTranslation moves or merges a translation into the target word and counts it in the summary.
+// Fails with repository.ErrDuplicateEntry if the target word has an equal translation not seen through repo.
+func moveTranslation(repo repository.Repository, translation *models.Translation, target *models.Word, summary *Summary) error {
+	existing := findTranslation(target.Translations, translation)
+	if existing == nil {
+		// Hidden example sentences move with the translation
+		hidden, err := repo.CountHiddenEntries(models.EntryTranslation, translation.TranslationID)
+		if err != nil {
+			return fmt.Errorf("failed to count private entries: %w", err)
+		}
+		if _, err := repo.UpdateTranslationWord(translation.TranslationID, target.WordID); err != nil {
+			return fmt.Errorf("failed to move translation %q: %w", translation.EnglishTranslation, err)
+		}
+		summary.MovedTranslations++
+		summary.MovedExampleSentences += len(translation.ExampleSentences) + int(hidden)
+		return nil
+	}
+
+	if err := checkHidden(repo, models.EntryTranslation, translation.TranslationID); err != nil {
+		return err
+	}
+	for _, sentence := range translation.ExampleSentences {
+		if findExampleSentence(existing.ExampleSentences, &sentence) != nil {
+			summary.MergedExampleSentences++
+			continue
+		}
+		_, err := repo.UpdateExampleSentenceTranslation(sentence.SentenceID, existing.TranslationID)
+		if err != nil {
+			return fmt.Errorf("failed to move example sentence %q: %w", sentence.SentenceText, err)
+		}
+		summary.MovedExampleSentences++
+	}
+	if err := repo.MoveReviewCards(translation.TranslationID, existing.TranslationID); err != nil {
+		return fmt.Errorf("failed to move review cards of translation %q: %w", translation.EnglishTranslation, err)
+	}
+	// Duplicate sentences are deleted together with the translation.
+	if err := repo.DeleteTranslation(translation.TranslationID); err != nil {
+		return fmt.Errorf("failed to delete merged translation %q: %w", translation.EnglishTranslation, err)
+	}
+	summary.MergedTranslations++
+	return nil
+}
+
+// MergesInto reports whether moving the translation to the word merges it into an equal translation,
+// which moves the translation to the trash.
+func MergesInto(translation *models.Translation, word *models.Word) bool {
+	return translation.WordID != word.WordID && findTranslation(word.Translations, translation) != nil
+}
+
+// checkHidden fails with ErrHiddenEntries if the word or translation has entries not seen through repo.
+func checkHidden(repo repository.Repository, entryType models.EntryType, entryID uint) error {
+	hidden, err := repo.CountHiddenEntries(entryType, entryID)
+	if err != nil {
+		return fmt.Errorf("failed to count private entries: %w", err)
+	}
+	if hidden > 0 {
+		return ErrHiddenEntries
+	}
+	return nil
+}
+
+// findTranslation returns the translation equal to the given one, or nil if there is none.
+func findTranslation(translations []models.Translation, translation *models.Translation) *models.Translation {
+	for i, t := range translations {
+		if t.EnglishTranslation == translation.EnglishTranslation && t.Owner == translation.Owner {
+			return &translations[i]
+		}
+	}
+	return nil
+}
+
+// findExampleSentence returns the example sentence equal to the given one, or nil if there is none.
+func findExampleSentence(sentences []models.ExampleSentence, sentence *models.ExampleSentence) *models.ExampleSentence {
+	for i, s := range sentences {
+		if s.SentenceText == sentence.SentenceText && s.Owner == sentence.Owner {
+			return &sentences[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package merge_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sar-michal/dictionary-app/pkg/merge"
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mergeRepo keeps words in memory and records the changes made through it. Other repository methods are not used.
+type mergeRepo struct {
+	repository.Repository
+	words []models.Word
+	// hidden counts the entries of a word or translation not seen through the repository, e.g. "word 1".
+	hidden map[string]int64
+	calls  []string
+}
+
+func newMergeRepo() *mergeRepo {
+	return &mergeRepo{hidden: map[string]int64{}, words: []models.Word{
+		{WordID: 1, PolishWord: "kot", Translations: []models.Translation{
+			{TranslationID: 10, WordID: 1, EnglishTranslation: "cat", ExampleSentences: []models.ExampleSentence{
+				{SentenceID: 100, TranslationID: 10, SentenceText: "Kot śpi."},
+				{SentenceID: 101, TranslationID: 10, SentenceText: "Kot je."},
+			}},
+			{TranslationID: 11, WordID: 1, EnglishTranslation: "tomcat", ExampleSentences: []models.ExampleSentence{
+				{SentenceID: 110, TranslationID: 11, SentenceText: "Kot miauczy."},
+			}},
+		}},
+		{WordID: 2, PolishWord: "kocur", Translations: []models.Translation{
+			{TranslationID: 20, WordID: 2, EnglishTranslation: "cat", ExampleSentences: []models.ExampleSentence{
+				{SentenceID: 200, TranslationID: 20, SentenceText: "Kot śpi."},
+			}},
+		}},
+	}}
+}
+
+func (r *mergeRepo) GetWordByID(wordID uint) (*models.Word, error) {
+	for i := range r.words {
+		if r.words[i].WordID == wordID {
+			return &r.words[i], nil
+		}
+	}
+	return nil, fmt.Errorf("word %d not found", wordID)
+}
+
+func (r *mergeRepo) GetTranslationByID(translationID uint) (*models.Translation, error) {
+	for i := range r.words {
+		for j := range r.words[i].Translations {
+			if r.words[i].Translations[j].TranslationID == translationID {
+				return &r.words[i].Translations[j], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("translation %d not found", translationID)
+}
+
+func (r *mergeRepo) UpdateTranslationWord(translationID uint, wordID uint) (*models.Translation, error) {
+	r.calls = append(r.calls, fmt.Sprintf("UpdateTranslationWord %d %d", translationID, wordID))
+	return &models.Translation{TranslationID: translationID, WordID: wordID}, nil
+}
+
+func (r *mergeRepo) UpdateExampleSentenceTranslation(sentenceID uint, translationID uint) (*models.ExampleSentence, error) {
+	r.calls = append(r.calls, fmt.Sprintf("UpdateExampleSentenceTranslation %d %d", sentenceID, translationID))
+	return &models.ExampleSentence{SentenceID: sentenceID, TranslationID: translationID}, nil
+}
+
+func (r *mergeRepo) DeleteTranslation(translationID uint) error {
+	r.calls = append(r.calls, fmt.Sprintf("DeleteTranslation %d", translationID))
+	return nil
+}
+
+func (r *mergeRepo) CountHiddenEntries(entryType models.EntryType, entryID uint) (int64, error) {
+	return r.hidden[fmt.Sprintf("%s %d", entryType, entryID)], nil
+}
+
+func (r *mergeRepo) MoveWordDependents(fromWordID uint, toWordID uint) error {
+	r.calls = append(r.calls, fmt.Sprintf("MoveWordDependents %d %d", fromWordID, toWordID))
+	return nil
+}
+
+func (r *mergeRepo) MoveReviewCards(fromTranslationID uint, toTranslationID uint) error {
+	r.calls = append(r.calls, fmt.Sprintf("MoveReviewCards %d %d", fromTranslationID, toTranslationID))
+	return nil
+}
+
+func (r *mergeRepo) DeleteWord(wordID uint) error {
+	r.calls = append(r.calls, fmt.Sprintf("DeleteWord %d", wordID))
+	return nil
+}
+
+func (r *mergeRepo) Transaction(fn func(repo repository.Repository) error) error {
+	return fn(r)
+}
+
+func TestMergeWords(t *testing.T) {
+	repo := newMergeRepo()
+	summary, err := merge.MergeWords(repo, 1, 2)
+	require.NoError(t, err, "MergeWords Should Not Error")
+	assert.Equal(t, merge.Summary{
+		MovedTranslations:      1,
+		MergedTranslations:     1,
+		MovedExampleSentences:  2,
+		MergedExampleSentences: 1,
+	}, *summary, "Expected Moved And Merged Counts")
+	assert.Equal(t, []string{
+		"UpdateExampleSentenceTranslation 101 20",
+		"MoveReviewCards 10 20",
+		"DeleteTranslation 10",
+		"UpdateTranslationWord 11 2",
+		"MoveWordDependents 1 2",
+		"DeleteWord 1",
+	}, repo.calls, "Expected Equal Translation To Be Merged, The Other Moved And The Source Deleted")
+
+	_, err = merge.MergeWords(repo, 2, 2)
+	assert.ErrorIs(t, err, merge.ErrSameWord, "Expected Same Word Error")
+}
+
+func TestMergeWordsWithHiddenEntries(t *testing.T) {
+	repo := newMergeRepo()
+	repo.hidden["word 1"] = 1
+	_, err := merge.MergeWords(repo, 1, 2)
+	assert.ErrorIs(t, err, merge.ErrHiddenEntries, "Expected Hidden Entries Error")
+	assert.Empty(t, repo.calls, "Expected Nothing To Be Changed")
+
+	repo = newMergeRepo()
+	repo.hidden["translation 10"] = 1
+	_, err = merge.MoveTranslation(repo, 10, 2)
+	assert.ErrorIs(t, err, merge.ErrHiddenEntries, "Expected Hidden Entries Error When Merging A Translation")
+}
+
+func TestMergeWordsKeepsOwners(t *testing.T) {
+	repo := newMergeRepo()
+	repo.words[1].Translations[0].Owner = models.UserOwner(5)
+	summary, err := merge.MergeWords(repo, 1, 2)
+	require.NoError(t, err, "MergeWords Should Not Error")
+	assert.Equal(t, 2, summary.MovedTranslations, "Expected Translations Of Other Owners Not To Be Merged")
+	assert.Equal(t, 0, summary.MergedTranslations, "Expected No Merged Translations")
+}
+
+func TestMoveTranslation(t *testing.T) {
+	repo := newMergeRepo()
+	repo.hidden["translation 11"] = 2
+	summary, err := merge.MoveTranslation(repo, 11, 2)
+	require.NoError(t, err, "MoveTranslation Should Not Error")
+	assert.Equal(t, merge.Summary{MovedTranslations: 1, MovedExampleSentences: 3}, *summary, "Expected Hidden Sentences To Be Counted")
+	assert.Equal(t, []string{"UpdateTranslationWord 11 2"}, repo.calls, "Expected Translation To Be Moved")
+
+	_, err = merge.MoveTranslation(repo, 11, 1)
+	assert.ErrorIs(t, err, merge.ErrSameWord, "Expected Same Word Error")
+	_, err = merge.MoveTranslation(repo, 11, 99)
+	assert.Error(t, err, "Expected Error For Missing Word")
+}
@@ -1,31 +1,602 @@
 package models
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sar-michal/dictionary-app/pkg/g2p"
+	"github.com/sar-michal/dictionary-app/pkg/normalize"
+	"github.com/sar-michal/dictionary-app/pkg/srs"
 	"gorm.io/gorm"
 )
 
+// PartOfSpeech of a word. Empty when unspecified.
+type PartOfSpeech string
+
+const (
+	PartOfSpeechNoun         PartOfSpeech = "noun"
+	PartOfSpeechVerb         PartOfSpeech = "verb"
+	PartOfSpeechAdjective    PartOfSpeech = "adjective"
+	PartOfSpeechAdverb       PartOfSpeech = "adverb"
+	PartOfSpeechPronoun      PartOfSpeech = "pronoun"
+	PartOfSpeechNumeral      PartOfSpeech = "numeral"
+	PartOfSpeechPreposition  PartOfSpeech = "preposition"
+	PartOfSpeechConjunction  PartOfSpeech = "conjunction"
+	PartOfSpeechParticle     PartOfSpeech = "particle"
+	PartOfSpeechInterjection PartOfSpeech = "interjection"
+)
+
+// Gender is the grammatical gender of a word. Empty when unspecified.
+type Gender string
+
+const (
+	GenderMasculinePersonal  Gender = "masculine_personal"
+	GenderMasculineAnimate   Gender = "masculine_animate"
+	GenderMasculineInanimate Gender = "masculine_inanimate"
+	GenderFeminine           Gender = "feminine"
+	GenderNeuter             Gender = "neuter"
+)
+
+// Aspect of a verb. Empty when unspecified.
+type Aspect string
+
+const (
+	AspectPerfective   Aspect = "perfective"
+	AspectImperfective Aspect = "imperfective"
+)
+
+// Grammar groups the grammatical metadata of a word.
+type Grammar struct {
+	PartOfSpeech PartOfSpeech
+	Gender       Gender
+	Aspect       Aspect
+}
+
+// Owner of a private dictionary entry: "user:<id>" for a single user or "group:<name>" for a group of users.
+// Entries of the shared dictionary have no owner.
+type Owner string
+
+// SharedOwner owns the entries of the shared dictionary, visible to everyone.
+const SharedOwner Owner = ""
+
+// UserOwner returns the owner of the private entries of a user.
+func UserOwner(userID uint) Owner {
+	return Owner("user:" + strconv.FormatUint(uint64(userID), 10))
+}
+
+// GroupOwner returns the owner of the entries shared within a group.
+func GroupOwner(group string) Owner {
+	return Owner("group:" + group)
+}
+
+// Shared reports whether the entry belongs to the shared dictionary.
+func (o Owner) Shared() bool {
+	return o == SharedOwner
+}
+
+// Group reports whether the entry belongs to a group.
+func (o Owner) Group() bool {
+	return strings.HasPrefix(string(o), "group:")
+}
+
 type Word struct {
-	WordID       uint          `gorm:"primaryKey"`
-	PolishWord   string        `gorm:"uniqueIndex;not null"`
-	Translations []Translation `gorm:"foreignKey:WordID"`
+	WordID     uint   `gorm:"primaryKey"`
+	PolishWord string `gorm:"not null;uniqueIndex:idx_word_part_of_speech,where:deleted_at IS NULL"`
+	// Homonyms such as "zamek" are told apart by their part of speech.
+	PartOfSpeech PartOfSpeech `gorm:"not null;default:'';uniqueIndex:idx_word_part_of_speech"`
+	Gender       Gender       `gorm:"not null;default:''"`
+	Aspect       Aspect       `gorm:"not null;default:''"`
+	// Owner is empty for words of the shared dictionary. Private words may repeat shared ones.
+	Owner Owner `gorm:"not null;default:'';uniqueIndex:idx_word_part_of_speech"`
+	// SearchKey is the folded PolishWord used for diacritic-insensitive lookups.
+	SearchKey string `gorm:"index;not null;default:''"`
+	// Pronunciation is the IPA transcription generated from the PolishWord, e.g. "ʐuwf" for "żółw".
+	Pronunciation string `gorm:"not null;default:''"`
+	// PronunciationOverride is an IPA transcription entered by an editor. It takes precedence when set.
+	PronunciationOverride string           `gorm:"not null;default:''"`
+	Translations          []Translation    `gorm:"foreignKey:WordID"`
+	Inflections           []Inflection     `gorm:"foreignKey:WordID"`
+	AudioRecordings       []AudioRecording `gorm:"foreignKey:WordID"`
+	CreatedAt             time.Time        `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	// DeletedAt is set for words in the trash. Their translations and example sentences share it.
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// Grammar returns the grammatical metadata of the word.
+func (w *Word) Grammar() Grammar {
+	return Grammar{PartOfSpeech: w.PartOfSpeech, Gender: w.Gender, Aspect: w.Aspect}
+}
+
+// Transcription returns the manual pronunciation if there is one, and the generated one otherwise.
+func (w *Word) Transcription() string {
+	if w.PronunciationOverride != "" {
+		return w.PronunciationOverride
+	}
+	return w.Pronunciation
 }
 
+// BeforeSave keeps the SearchKey and generated Pronunciation in sync with the PolishWord.
+func (w *Word) BeforeSave(tx *gorm.DB) error {
+	w.SearchKey = normalize.Fold(w.PolishWord)
+	w.Pronunciation = g2p.Transcribe(w.PolishWord)
+	return nil
+}
+
+// Translation of a word. Private translations may be added to shared words.
 type Translation struct {
 	TranslationID      uint              `gorm:"primaryKey"`
-	WordID             uint              `gorm:"not null;uniqueIndex:idx_word_translation"`
+	WordID             uint              `gorm:"not null;uniqueIndex:idx_word_translation,where:deleted_at IS NULL"`
 	EnglishTranslation string            `gorm:"not null;uniqueIndex:idx_word_translation"`
+	Owner              Owner             `gorm:"not null;default:'';uniqueIndex:idx_word_translation"`
 	ExampleSentences   []ExampleSentence `gorm:"foreignKey:TranslationID"`
+	CreatedAt          time.Time         `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	DeletedAt          gorm.DeletedAt    `gorm:"index"`
 }
 type ExampleSentence struct {
-	SentenceID    uint   `gorm:"primaryKey"`
-	TranslationID uint   `gorm:"not null;uniqueIndex:idx_translation_sentence"`
-	SentenceText  string `gorm:"not null;uniqueIndex:idx_translation_sentence"`
+	SentenceID    uint           `gorm:"primaryKey"`
+	TranslationID uint           `gorm:"not null;uniqueIndex:idx_translation_sentence,where:deleted_at IS NULL"`
+	SentenceText  string         `gorm:"not null;uniqueIndex:idx_translation_sentence"`
+	Owner         Owner          `gorm:"not null;default:'';uniqueIndex:idx_translation_sentence"`
+	CreatedAt     time.Time      `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+}
+
+// AudioRecording is a recorded pronunciation of a word.
+// The audio is stored in the database and served over HTTP.
+type AudioRecording struct {
+	RecordingID uint   `gorm:"primaryKey"`
+	WordID      uint   `gorm:"not null;index"`
+	ContentType string `gorm:"not null"`
+	Size        int64  `gorm:"not null"`
+	// Data is omitted when listing recordings.
+	Data      []byte    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// GrammaticalCase of an inflected form.
+type GrammaticalCase string
+
+const (
+	CaseNominative   GrammaticalCase = "nominative"
+	CaseGenitive     GrammaticalCase = "genitive"
+	CaseDative       GrammaticalCase = "dative"
+	CaseAccusative   GrammaticalCase = "accusative"
+	CaseInstrumental GrammaticalCase = "instrumental"
+	CaseLocative     GrammaticalCase = "locative"
+	CaseVocative     GrammaticalCase = "vocative"
+)
+
+// GrammaticalNumber of an inflected form.
+type GrammaticalNumber string
+
+const (
+	NumberSingular GrammaticalNumber = "singular"
+	NumberPlural   GrammaticalNumber = "plural"
+)
+
+// Person of a conjugated verb form.
+type Person string
+
+const (
+	PersonFirst  Person = "first"
+	PersonSecond Person = "second"
+	PersonThird  Person = "third"
+)
+
+// Tense of a conjugated verb form.
+type Tense string
+
+const (
+	TensePresent Tense = "present"
+	TensePast    Tense = "past"
+	TenseFuture  Tense = "future"
+)
+
+// InflectionTags describe the grammatical categories of an inflected form.
+// Categories that do not apply are left empty.
+type InflectionTags struct {
+	Case   GrammaticalCase
+	Number GrammaticalNumber
+	Person Person
+	Tense  Tense
+	Gender Gender
+}
+
+// Inflection is an inflected form of a word, e.g. "kota" (genitive singular of "kot").
+type Inflection struct {
+	InflectionID uint              `gorm:"primaryKey"`
+	WordID       uint              `gorm:"not null;uniqueIndex:idx_word_inflection"`
+	Form         string            `gorm:"not null;index;uniqueIndex:idx_word_inflection"`
+	Case         GrammaticalCase   `gorm:"column:grammatical_case;not null;default:'';uniqueIndex:idx_word_inflection"`
+	Number       GrammaticalNumber `gorm:"not null;default:'';uniqueIndex:idx_word_inflection"`
+	Person       Person            `gorm:"not null;default:'';uniqueIndex:idx_word_inflection"`
+	Tense        Tense             `gorm:"not null;default:'';uniqueIndex:idx_word_inflection"`
+	Gender       Gender            `gorm:"not null;default:'';uniqueIndex:idx_word_inflection"`
+	CreatedAt    time.Time         `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// Tags returns the grammatical categories of the inflected form.
+func (i *Inflection) Tags() InflectionTags {
+	return InflectionTags{Case: i.Case, Number: i.Number, Person: i.Person, Tense: i.Tense, Gender: i.Gender}
+}
+
+// SetTags replaces the grammatical categories of the inflected form.
+func (i *Inflection) SetTags(tags InflectionTags) {
+	i.Case = tags.Case
+	i.Number = tags.Number
+	i.Person = tags.Person
+	i.Tense = tags.Tense
+	i.Gender = tags.Gender
+}
+
+// RelationType is the kind of relation between two words.
+type RelationType string
+
+const (
+	RelationSynonym      RelationType = "synonym"
+	RelationAntonym      RelationType = "antonym"
+	RelationDiminutive   RelationType = "diminutive"
+	RelationAugmentative RelationType = "augmentative"
+	RelationDerivedFrom  RelationType = "derived_from"
+	RelationSeeAlso      RelationType = "see_also"
+)
+
+// Symmetric reports whether the relation holds in both directions,
+// e.g. "duży" is a synonym of "wielki" and "wielki" is a synonym of "duży".
+func (t RelationType) Symmetric() bool {
+	switch t {
+	case RelationSynonym, RelationAntonym, RelationSeeAlso:
+		return true
+	}
+	return false
+}
+
+// WordRelation links two words. It reads "Word is a <Type> of RelatedWord",
+// e.g. "kotek" is a diminutive of "kot".
+// Symmetric relations are stored once, with the lower word ID as WordID.
+type WordRelation struct {
+	RelationID    uint         `gorm:"primaryKey"`
+	WordID        uint         `gorm:"not null;index;uniqueIndex:idx_word_relation"`
+	RelatedWordID uint         `gorm:"not null;index;uniqueIndex:idx_word_relation"`
+	Type          RelationType `gorm:"not null;uniqueIndex:idx_word_relation"`
+	Word          Word         `gorm:"foreignKey:WordID"`
+	RelatedWord   Word         `gorm:"foreignKey:RelatedWordID"`
+	CreatedAt     time.Time    `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// Normalize orders the words of a symmetric relation so that it is stored only once.
+func (r *WordRelation) Normalize() {
+	if r.Type.Symmetric() && r.WordID > r.RelatedWordID {
+		r.WordID, r.RelatedWordID = r.RelatedWordID, r.WordID
+	}
+}
+
+// ReviewCard tracks how well a learner knows a translation.
+type ReviewCard struct {
+	CardID        uint        `gorm:"primaryKey"`
+	Learner       string      `gorm:"not null;uniqueIndex:idx_learner_translation;index:idx_learner_due"`
+	TranslationID uint        `gorm:"not null;index;uniqueIndex:idx_learner_translation"`
+	Translation   Translation `gorm:"foreignKey:TranslationID"`
+	Repetitions   int         `gorm:"not null;default:0"`
+	IntervalDays  int         `gorm:"not null;default:0"`
+	EaseFactor    float64     `gorm:"not null"`
+	Due           time.Time   `gorm:"not null;index:idx_learner_due"`
+	LastReviewed  *time.Time
+	CreatedAt     time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// State returns the scheduling state of the card.
+func (c *ReviewCard) State() srs.State {
+	return srs.State{Repetitions: c.Repetitions, IntervalDays: c.IntervalDays, EaseFactor: c.EaseFactor, Due: c.Due}
+}
+
+// SetState replaces the scheduling state of the card.
+func (c *ReviewCard) SetState(state srs.State) {
+	c.Repetitions = state.Repetitions
+	c.IntervalDays = state.IntervalDays
+	c.EaseFactor = state.EaseFactor
+	c.Due = state.Due
+}
+
+// ReviewLog records a review of a card and the state it led to.
+type ReviewLog struct {
+	LogID        uint      `gorm:"primaryKey"`
+	CardID       uint      `gorm:"not null;index"`
+	Grade        int       `gorm:"not null"`
+	IntervalDays int       `gorm:"not null"`
+	EaseFactor   float64   `gorm:"not null"`
+	ReviewedAt   time.Time `gorm:"not null"`
+}
+
+// Role of a user. Each role includes the permissions of the roles before it.
+type Role string
+
+const (
+	// RoleViewer can query the dictionary and study.
+	RoleViewer Role = "viewer"
+	// RoleEditor can also create and update entries.
+	RoleEditor Role = "editor"
+	// RoleAdmin can also delete entries and manage users.
+	RoleAdmin Role = "admin"
+)
+
+var roleRanks = map[Role]int{RoleViewer: 1, RoleEditor: 2, RoleAdmin: 3}
+
+// Valid reports whether the role is known.
+func (r Role) Valid() bool {
+	return roleRanks[r] > 0
+}
+
+// Includes reports whether the role grants the permissions of the required role,
+// e.g. admins can do everything editors can.
+func (r Role) Includes(required Role) bool {
+	return r.Valid() && roleRanks[r] >= roleRanks[required]
+}
+
+// User is an account that can sign in to the API.
+type User struct {
+	UserID       uint   `gorm:"primaryKey"`
+	Username     string `gorm:"not null;uniqueIndex"`
+	PasswordHash string `gorm:"not null"`
+	Role         Role   `gorm:"not null;default:'viewer'"`
+	// Group, such as a class of students, shares private entries between its members. Empty when none.
+	Group     string    `gorm:"column:user_group;not null;default:''"`
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// Owners returns the owners of the private entries visible to the user.
+func (u *User) Owners() []Owner {
+	owners := []Owner{UserOwner(u.UserID)}
+	if u.Group != "" {
+		owners = append(owners, GroupOwner(u.Group))
+	}
+	return owners
+}
+
+// EntryType is the kind of a dictionary entry.
+type EntryType string
+
+const (
+	EntryWord            EntryType = "word"
+	EntryTranslation     EntryType = "translation"
+	EntryExampleSentence EntryType = "example_sentence"
+)
+
+// ProposalStatus tells whether a proposal was reviewed by an editor.
+type ProposalStatus string
+
+const (
+	ProposalPending  ProposalStatus = "pending"
+	ProposalApproved ProposalStatus = "approved"
+	ProposalRejected ProposalStatus = "rejected"
+)
+
+// ProposalAction is the kind of change a proposal makes to the shared dictionary.
+type ProposalAction string
+
+const (
+	ActionCreate ProposalAction = "create"
+	ActionUpdate ProposalAction = "update"
+	ActionDelete ProposalAction = "delete"
+	// ActionPromote moves a private entry to the shared dictionary.
+	ActionPromote ProposalAction = "promote"
+	// ActionRevert reverts an entry to its state after a revision.
+	ActionRevert ProposalAction = "revert"
+)
+
+// FieldChange is the difference between the current and the proposed value of a field.
+// Before is empty for added values and After for removed ones.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// ChangeProposal is a change to the shared dictionary suggested by a user who cannot make it directly.
+// The change is applied when an editor approves the proposal.
+type ChangeProposal struct {
+	ProposalID uint           `gorm:"primaryKey"`
+	Action     ProposalAction `gorm:"not null"`
+	EntryType  EntryType      `gorm:"not null"`
+	// EntryID is the changed entry, or the word or translation a new entry is added to. Zero for new words.
+	EntryID uint `gorm:"not null;default:0"`
+	// Change is the JSON-encoded change replayed on approval.
+	Change string `gorm:"type:text;not null"`
+	// Diff compares the change with the dictionary at the time it was proposed.
+	Diff       []FieldChange  `gorm:"type:text;not null;serializer:json"`
+	ProposedBy uint           `gorm:"not null;index"`
+	Proposer   User           `gorm:"foreignKey:ProposedBy"`
+	Status     ProposalStatus `gorm:"not null;default:'pending';index"`
+	// Comment left by the editor who reviewed the proposal.
+	Comment    string `gorm:"not null;default:''"`
+	ReviewedBy *uint
+	Reviewer   *User `gorm:"foreignKey:ReviewedBy"`
+	ReviewedAt *time.Time
+	CreatedAt  time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// RevisionAction is the kind of change recorded in a revision.
+type RevisionAction string
+
+const (
+	RevisionCreate RevisionAction = "create"
+	RevisionUpdate RevisionAction = "update"
+	RevisionDelete RevisionAction = "delete"
+	// RevisionRestore restores a deleted entry from the trash.
+	RevisionRestore RevisionAction = "restore"
+)
+
+// EntrySnapshot is the state of a word, translation or example sentence recorded in a revision.
+// Only the field of the entry type is set. Snapshots of deleted words and translations include
+// the translations and example sentences deleted with them.
+type EntrySnapshot struct {
+	Word            *Word            `json:",omitempty"`
+	Translation     *Translation     `json:",omitempty"`
+	ExampleSentence *ExampleSentence `json:",omitempty"`
+}
+
+// Owner returns the owner of the entry in the snapshot.
+func (s *EntrySnapshot) Owner() Owner {
+	switch {
+	case s.Word != nil:
+		return s.Word.Owner
+	case s.Translation != nil:
+		return s.Translation.Owner
+	case s.ExampleSentence != nil:
+		return s.ExampleSentence.Owner
+	}
+	return SharedOwner
+}
+
+// Revision records a create, update or delete of a word, translation or example sentence.
+type Revision struct {
+	RevisionID uint           `gorm:"primaryKey"`
+	EntryType  EntryType      `gorm:"not null;index:idx_revision_entry"`
+	EntryID    uint           `gorm:"not null;index:idx_revision_entry"`
+	Action     RevisionAction `gorm:"not null"`
+	// Before is the entry before the change. Nil for created entries.
+	Before *EntrySnapshot `gorm:"type:text;serializer:json"`
+	// After is the entry after the change. Nil for deleted entries.
+	After *EntrySnapshot `gorm:"type:text;serializer:json"`
+	// AuthorID is the user who made the change. Nil for changes made by the system, such as imports.
+	AuthorID  *uint     `gorm:"index"`
+	Author    *User     `gorm:"foreignKey:AuthorID"`
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// State returns the state of the entry recorded by the revision: the entry after a create
+// or update, and before a delete.
+func (r *Revision) State() *EntrySnapshot {
+	if r.After != nil {
+		return r.After
+	}
+	return r.Before
 }
 
 func Migrate(db *gorm.DB) error {
-	err := db.AutoMigrate(&Word{}, &Translation{}, &ExampleSentence{})
+	// pg_trgm provides the similarity() function and trigram indexes used by word search.
+	err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error
+	if err != nil {
+		return err
+	}
+	// Polish collation used to sort words alphabetically ("ą" right after "a", "ł" after "l" etc.).
+	err = db.Exec("CREATE COLLATION IF NOT EXISTS polish (provider = icu, locale = 'pl-PL')").Error
+	if err != nil {
+		return err
+	}
+	// Pronunciations used to be entered manually only. Keep them as overrides.
+	movePronunciations := db.Migrator().HasTable(&Word{}) &&
+		db.Migrator().HasColumn(&Word{}, "pronunciation") &&
+		!db.Migrator().HasColumn(&Word{}, "pronunciation_override")
+	// Unique indexes used to span the shared dictionary only and to include deleted entries.
+	// Drop them so they are recreated with the owner for entries that are not deleted.
+	if err := dropUnscopedIndexes(db); err != nil {
+		return err
+	}
+	err = db.AutoMigrate(&Word{}, &Translation{}, &ExampleSentence{}, &Inflection{}, &WordRelation{}, &AudioRecording{},
+		&ReviewCard{}, &ReviewLog{}, &User{}, &ChangeProposal{}, &Revision{})
+	if err != nil {
+		return err
+	}
+	// Words used to be unique by polish_word alone.
+	if db.Migrator().HasIndex(&Word{}, "idx_words_polish_word") {
+		if err := db.Migrator().DropIndex(&Word{}, "idx_words_polish_word"); err != nil {
+			return err
+		}
+	}
+	if movePronunciations {
+		err = db.Exec("UPDATE words SET pronunciation_override = pronunciation, pronunciation = ''").Error
+		if err != nil {
+			return err
+		}
+	}
+	err = db.Exec("CREATE INDEX IF NOT EXISTS idx_words_polish_word_trgm ON words USING gin (polish_word gin_trgm_ops)").Error
 	if err != nil {
 		return err
 	}
+	if err := backfillWords(db); err != nil {
+		return err
+	}
+	if err := migratePromotionProposals(db); err != nil {
+		return err
+	}
 	return nil
 }
+
+// dropUnscopedIndexes drops the unique indexes of entries that do not have an owner or deleted_at column yet.
+func dropUnscopedIndexes(db *gorm.DB) error {
+	indexes := []struct {
+		model any
+		name  string
+	}{
+		{&Word{}, "idx_word_part_of_speech"},
+		{&Translation{}, "idx_word_translation"},
+		{&ExampleSentence{}, "idx_translation_sentence"},
+	}
+	for _, index := range indexes {
+		if !db.Migrator().HasTable(index.model) ||
+			db.Migrator().HasColumn(index.model, "owner") && db.Migrator().HasColumn(index.model, "deleted_at") {
+			continue
+		}
+		if !db.Migrator().HasIndex(index.model, index.name) {
+			continue
+		}
+		if err := db.Migrator().DropIndex(index.model, index.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migratePromotionProposals moves the proposals of the promotion_proposals table, which preceded
+// ChangeProposal, to change_proposals as ActionPromote proposals and drops the table.
+// The text of the proposed entry becomes the diff, since the word or translation it belonged to is not known.
+func migratePromotionProposals(db *gorm.DB) error {
+	if !db.Migrator().HasTable("promotion_proposals") {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			INSERT INTO change_proposals
+				(action, entry_type, entry_id, change, diff, proposed_by, status, reviewed_by, reviewed_at, created_at)
+			SELECT
+				?, entry_type, entry_id,
+				jsonb_build_object('action', ?::text, 'entryType', entry_type, 'entryID', entry_id, 'fields', '{}'::jsonb)::text,
+				jsonb_build_array(jsonb_build_object('field', CASE entry_type
+					WHEN ? THEN 'polishWord' WHEN ? THEN 'englishTranslation' ELSE 'sentenceText' END, 'after', text))::text,
+				proposed_by, status, reviewed_by, reviewed_at, created_at
+			FROM promotion_proposals
+			ORDER BY proposal_id`,
+			ActionPromote, ActionPromote, EntryWord, EntryTranslation,
+		).Error
+		if err != nil {
+			return err
+		}
+		return tx.Migrator().DropTable("promotion_proposals")
+	})
+}
+
+// backfillWords fills in the SearchKey and generated Pronunciation of words created before they were introduced.
+func backfillWords(db *gorm.DB) error {
+	var words []Word
+	return db.
+		Where("search_key = ? OR pronunciation = ?", "", "").
+		FindInBatches(&words, 1000, func(tx *gorm.DB, batch int) error {
+			for _, w := range words {
+				err := tx.
+					Model(&Word{}).
+					Where("word_id = ?", w.WordID).
+					UpdateColumns(map[string]any{
+						"search_key":    normalize.Fold(w.PolishWord),
+						"pronunciation": g2p.Transcribe(w.PolishWord),
+					}).
+					Error
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}).
+		Error
+}
@@ -0,0 +1,26 @@
+package normalize
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Letters that do not decompose into a base letter and a combining mark.
+var strokeReplacer = strings.NewReplacer("ł", "l", "Ł", "L")
+
+// Fold returns a search key for the input. The key is lowercased,
+// stripped of diacritics and NFC-normalized, so "Żółw" and "zolw" share the same key.
+func Fold(input string) string {
+	stripped := strokeReplacer.Replace(input)
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, stripped)
+	if err != nil {
+		// Transformers above never fail on valid strings; fall back to the plain input.
+		folded = stripped
+	}
+	return strings.ToLower(folded)
+}
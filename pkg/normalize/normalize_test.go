@@ -0,0 +1,23 @@
+package normalize_test
+
+import (
+	"testing"
+
+	"github.com/sar-michal/dictionary-app/pkg/normalize"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFold(t *testing.T) {
+	cases := map[string]string{
+		"żółw":                  "zolw",
+		"Żółw":                  "zolw",
+		"zolw":                  "zolw",
+		"łódź":                  "lodz",
+		"ĄĆĘŁŃÓŚŹŻ":             "acelnoszz",
+		"z\u0307o\u0301\u0142w": "zolw", // decomposed "żółw"
+		"kot":                   "kot",
+	}
+	for input, expected := range cases {
+		assert.Equal(t, expected, normalize.Fold(input), "Unexpected fold of '%s'", input)
+	}
+}
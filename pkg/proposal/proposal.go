@@ -0,0 +1,472 @@
+package proposal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+)
+
+// ErrNoChange is returned when proposing an update that does not change anything.
+var ErrNoChange = errors.New("the change does not differ from the dictionary")
+
+// Fields are the values set by a change. Nil fields are left unchanged.
+type Fields struct {
+	PolishWord *string `json:"polishWord,omitempty"`
+	// IgnoreDiacritics reuses a new word's existing spellings that differ only in case or diacritics.
+	IgnoreDiacritics bool `json:"ignoreDiacritics,omitempty"`
+	// Grammar replaces all grammatical metadata of a word when set.
+	Grammar *models.Grammar `json:"grammar,omitempty"`
+	// Pronunciation is the manual IPA transcription of a word. An empty string clears it.
+	Pronunciation      *string  `json:"pronunciation,omitempty"`
+	EnglishTranslation *string  `json:"englishTranslation,omitempty"`
+	ExampleSentences   []string `json:"exampleSentences,omitempty"`
+	SentenceText       *string  `json:"sentenceText,omitempty"`
+}
+
+// Change is a create, update, delete, promotion or revert of a word, translation or example sentence.
+// It mirrors a mutation, so that it can be stored with a proposal and replayed once approved.
+type Change struct {
+	Action    models.ProposalAction `json:"action"`
+	EntryType models.EntryType      `json:"entryType"`
+	// EntryID is the changed entry. New translations and example sentences are added to the word
+	// or translation with this ID; a new translation with no EntryID creates the word from Fields.
+	EntryID uint `json:"entryID,omitempty"`
+	// RevisionID is the revision a revert returns the entry to.
+	RevisionID uint   `json:"revisionID,omitempty"`
+	Fields     Fields `json:"fields"`
+}
+
+// RequiredRole returns the role needed to make the change to the shared dictionary directly.
+func RequiredRole(change Change) models.Role {
+	if change.Action == models.ActionDelete {
+		return models.RoleAdmin
+	}
+	return models.RoleEditor
+}
+
+// Propose records the change as a pending proposal of the user.
+// Its diff is computed against the dictionary seen through repo.
+func Propose(repo repository.Repository, change Change, proposedBy uint) (*models.ChangeProposal, error) {
+	diff, err := Diff(repo, change)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(change)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode change: %w", err)
+	}
+	return repo.CreateChangeProposal(models.ChangeProposal{
+		Action:     change.Action,
+		EntryType:  change.EntryType,
+		EntryID:    change.EntryID,
+		Change:     string(data),
+		Diff:       diff,
+		ProposedBy: proposedBy,
+	})
+}
+
+// Decode returns the change recorded with a proposal.
+func Decode(proposal *models.ChangeProposal) (Change, error) {
+	var change Change
+	if err := json.Unmarshal([]byte(proposal.Change), &change); err != nil {
+		return change, fmt.Errorf("failed to decode change of proposal %d: %w", proposal.ProposalID, err)
+	}
+	return change, nil
+}
+
+// Review approves or rejects a pending proposal in a transaction. Approving replays the change
+// through the repository, and the proposal stays pending if the change fails.
+func Review(repo repository.Repository, proposalID uint, reviewerID uint, approve bool, comment string, reviewedAt time.Time) (*models.ChangeProposal, error) {
+	var reviewed *models.ChangeProposal
+	err := repo.Transaction(func(txRepo repository.Repository) error {
+		proposal, err := txRepo.GetChangeProposalByID(proposalID)
+		if err != nil {
+			return err
+		}
+		if proposal.Status != models.ProposalPending {
+			return repository.ErrProposalReviewed
+		}
+		status := models.ProposalRejected
+		if approve {
+			change, err := Decode(proposal)
+			if err != nil {
+				return err
+			}
+			if err := Apply(txRepo, change); err != nil {
+				return fmt.Errorf("failed to apply change: %w", err)
+			}
+			status = models.ProposalApproved
+		}
+		reviewed, err = txRepo.ReviewChangeProposal(proposalID, status, reviewerID, comment, reviewedAt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reviewed, nil
+}
+
+// Apply makes the change through the repository.
+func Apply(repo repository.Repository, change Change) error {
+	switch change.Action {
+	case models.ActionCreate:
+		return applyCreate(repo, change)
+	case models.ActionUpdate:
+		return applyUpdate(repo, change)
+	case models.ActionDelete:
+		switch change.EntryType {
+		case models.EntryWord:
+			return repo.DeleteWord(change.EntryID)
+		case models.EntryTranslation:
+			return repo.DeleteTranslation(change.EntryID)
+		case models.EntryExampleSentence:
+			return repo.DeleteExampleSentence(change.EntryID)
+		}
+	case models.ActionPromote:
+		return repo.PromoteEntry(change.EntryType, change.EntryID)
+	case models.ActionRevert:
+		_, err := repo.RevertToRevision(change.RevisionID)
+		return err
+	default:
+		return fmt.Errorf("unknown action: %q", change.Action)
+	}
+	return fmt.Errorf("unknown entry type: %q", change.EntryType)
+}
+
+func applyCreate(repo repository.Repository, change Change) error {
+	fields := change.Fields
+	switch change.EntryType {
+	case models.EntryWord:
+		if fields.PolishWord == nil {
+			return missingField(change, "polishWord")
+		}
+		if fields.IgnoreDiacritics {
+			_, err := repo.GetOrCreateWordFolded(*fields.PolishWord, grammar(fields))
+			return err
+		}
+		_, err := repo.GetOrCreateWord(*fields.PolishWord, grammar(fields))
+		return err
+	case models.EntryTranslation:
+		if fields.EnglishTranslation == nil {
+			return missingField(change, "englishTranslation")
+		}
+		wordID := change.EntryID
+		if wordID == 0 {
+			if fields.PolishWord == nil {
+				return missingField(change, "polishWord")
+			}
+			word, err := repo.GetOrCreateWord(*fields.PolishWord, grammar(fields))
+			if err != nil {
+				return err
+			}
+			wordID = word.WordID
+		}
+		translation, err := repo.GetOrCreateTranslation(wordID, *fields.EnglishTranslation)
+		if err != nil {
+			return err
+		}
+		for _, sentence := range fields.ExampleSentences {
+			if _, err := repo.GetOrCreateExampleSentence(translation.TranslationID, sentence); err != nil {
+				return err
+			}
+		}
+		return nil
+	case models.EntryExampleSentence:
+		if fields.SentenceText == nil {
+			return missingField(change, "sentenceText")
+		}
+		_, err := repo.GetOrCreateExampleSentence(change.EntryID, *fields.SentenceText)
+		return err
+	}
+	return fmt.Errorf("unknown entry type: %q", change.EntryType)
+}
+
+func applyUpdate(repo repository.Repository, change Change) error {
+	fields := change.Fields
+	switch change.EntryType {
+	case models.EntryWord:
+		if fields.PolishWord != nil {
+			if _, err := repo.UpdateWord(change.EntryID, *fields.PolishWord); err != nil {
+				return err
+			}
+		}
+		if fields.Grammar != nil {
+			if _, err := repo.UpdateWordGrammar(change.EntryID, *fields.Grammar); err != nil {
+				return err
+			}
+		}
+		if fields.Pronunciation != nil {
+			if _, err := repo.UpdateWordPronunciation(change.EntryID, *fields.Pronunciation); err != nil {
+				return err
+			}
+		}
+		return nil
+	case models.EntryTranslation:
+		if fields.EnglishTranslation == nil {
+			return missingField(change, "englishTranslation")
+		}
+		_, err := repo.UpdateTranslation(change.EntryID, *fields.EnglishTranslation)
+		return err
+	case models.EntryExampleSentence:
+		if fields.SentenceText == nil {
+			return missingField(change, "sentenceText")
+		}
+		_, err := repo.UpdateExampleSentence(change.EntryID, *fields.SentenceText)
+		return err
+	}
+	return fmt.Errorf("unknown entry type: %q", change.EntryType)
+}
+
+// Diff compares the change with the dictionary seen through repo. Created and promoted entries
+// are listed as added values, deleted ones as removed values together with what they cascade to.
+func Diff(repo repository.Repository, change Change) ([]models.FieldChange, error) {
+	switch change.Action {
+	case models.ActionCreate:
+		return diffCreate(repo, change)
+	case models.ActionUpdate:
+		return diffUpdate(repo, change)
+	case models.ActionDelete:
+		return diffDelete(repo, change)
+	case models.ActionPromote:
+		return diffPromote(repo, change)
+	case models.ActionRevert:
+		return diffRevert(repo, change)
+	}
+	return nil, fmt.Errorf("unknown action: %q", change.Action)
+}
+
+func diffCreate(repo repository.Repository, change Change) ([]models.FieldChange, error) {
+	fields := change.Fields
+	var diff []models.FieldChange
+	switch change.EntryType {
+	case models.EntryWord:
+		diff = added(diff, "polishWord", fields.PolishWord)
+		diff = addedGrammar(diff, fields.Grammar)
+	case models.EntryTranslation:
+		if change.EntryID == 0 {
+			diff = added(diff, "polishWord", fields.PolishWord)
+			diff = addedGrammar(diff, fields.Grammar)
+		} else if _, err := repo.GetWordByID(change.EntryID); err != nil {
+			return nil, fmt.Errorf("failed to find word: %w", err)
+		}
+		diff = added(diff, "englishTranslation", fields.EnglishTranslation)
+		for _, sentence := range fields.ExampleSentences {
+			diff = added(diff, "exampleSentence", &sentence)
+		}
+	case models.EntryExampleSentence:
+		if _, err := repo.GetTranslationByID(change.EntryID); err != nil {
+			return nil, fmt.Errorf("failed to find translation: %w", err)
+		}
+		diff = added(diff, "sentenceText", fields.SentenceText)
+	default:
+		return nil, fmt.Errorf("unknown entry type: %q", change.EntryType)
+	}
+	return diff, nil
+}
+
+func diffUpdate(repo repository.Repository, change Change) ([]models.FieldChange, error) {
+	fields := change.Fields
+	var diff []models.FieldChange
+	switch change.EntryType {
+	case models.EntryWord:
+		word, err := repo.GetWordByID(change.EntryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find word: %w", err)
+		}
+		diff = changed(diff, "polishWord", word.PolishWord, fields.PolishWord)
+		if fields.Grammar != nil {
+			diff = changed(diff, "partOfSpeech", string(word.PartOfSpeech), (*string)(&fields.Grammar.PartOfSpeech))
+			diff = changed(diff, "gender", string(word.Gender), (*string)(&fields.Grammar.Gender))
+			diff = changed(diff, "aspect", string(word.Aspect), (*string)(&fields.Grammar.Aspect))
+		}
+		diff = changed(diff, "pronunciation", word.PronunciationOverride, fields.Pronunciation)
+	case models.EntryTranslation:
+		translation, err := repo.GetTranslationByID(change.EntryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find translation: %w", err)
+		}
+		diff = changed(diff, "englishTranslation", translation.EnglishTranslation, fields.EnglishTranslation)
+	case models.EntryExampleSentence:
+		sentence, err := repo.GetExampleSentenceByID(change.EntryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find example sentence: %w", err)
+		}
+		diff = changed(diff, "sentenceText", sentence.SentenceText, fields.SentenceText)
+	default:
+		return nil, fmt.Errorf("unknown entry type: %q", change.EntryType)
+	}
+	if len(diff) == 0 {
+		return nil, ErrNoChange
+	}
+	return diff, nil
+}
+
+func diffDelete(repo repository.Repository, change Change) ([]models.FieldChange, error) {
+	var diff []models.FieldChange
+	switch change.EntryType {
+	case models.EntryWord:
+		word, err := repo.GetWordByID(change.EntryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find word: %w", err)
+		}
+		diff = removed(diff, "polishWord", word.PolishWord)
+		for _, translation := range word.Translations {
+			diff = removedTranslation(diff, &translation)
+		}
+	case models.EntryTranslation:
+		translation, err := repo.GetTranslationByID(change.EntryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find translation: %w", err)
+		}
+		diff = removedTranslation(diff, translation)
+	case models.EntryExampleSentence:
+		sentence, err := repo.GetExampleSentenceByID(change.EntryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find example sentence: %w", err)
+		}
+		diff = removed(diff, "sentenceText", sentence.SentenceText)
+	default:
+		return nil, fmt.Errorf("unknown entry type: %q", change.EntryType)
+	}
+	return diff, nil
+}
+
+// diffPromote lists the entry and the private word and translation it belongs to as added to the shared dictionary.
+func diffPromote(repo repository.Repository, change Change) ([]models.FieldChange, error) {
+	var diff []models.FieldChange
+	entryType, entryID := change.EntryType, change.EntryID
+	promoted := false
+	if entryType == models.EntryExampleSentence {
+		sentence, err := repo.GetExampleSentenceByID(entryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find example sentence: %w", err)
+		}
+		if !sentence.Owner.Shared() {
+			diff = append(diff, models.FieldChange{Field: "sentenceText", After: sentence.SentenceText})
+			promoted = true
+		}
+		entryType, entryID = models.EntryTranslation, sentence.TranslationID
+	}
+	if entryType == models.EntryTranslation {
+		translation, err := repo.GetTranslationByID(entryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find translation: %w", err)
+		}
+		if !translation.Owner.Shared() {
+			diff = append(diff, models.FieldChange{Field: "englishTranslation", After: translation.EnglishTranslation})
+			promoted = true
+		} else if !promoted {
+			return nil, repository.ErrAlreadyShared
+		}
+		entryType, entryID = models.EntryWord, translation.WordID
+	}
+	if entryType != models.EntryWord {
+		return nil, fmt.Errorf("unknown entry type: %q", change.EntryType)
+	}
+	word, err := repo.GetWordByID(entryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find word: %w", err)
+	}
+	if !word.Owner.Shared() {
+		diff = append(diff, models.FieldChange{Field: "polishWord", After: word.PolishWord})
+	} else if !promoted {
+		return nil, repository.ErrAlreadyShared
+	}
+	// Keep the order of the shared dictionary, from the word down.
+	for i, j := 0, len(diff)-1; i < j; i, j = i+1, j-1 {
+		diff[i], diff[j] = diff[j], diff[i]
+	}
+	return diff, nil
+}
+
+// diffRevert compares the entry with its state after the revision. The fields of entries
+// in the trash or purged from it are listed as added values.
+func diffRevert(repo repository.Repository, change Change) ([]models.FieldChange, error) {
+	revision, err := repo.GetRevisionByID(change.RevisionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find revision: %w", err)
+	}
+	state := revision.State()
+	var diff []models.FieldChange
+	// Deleted entries are not found and compared with empty values.
+	switch {
+	case state.Word != nil:
+		var current models.Word
+		if word, err := repo.GetWordByID(revision.EntryID); err == nil {
+			current = *word
+		}
+		diff = changed(diff, "polishWord", current.PolishWord, &state.Word.PolishWord)
+		diff = changed(diff, "partOfSpeech", string(current.PartOfSpeech), (*string)(&state.Word.PartOfSpeech))
+		diff = changed(diff, "gender", string(current.Gender), (*string)(&state.Word.Gender))
+		diff = changed(diff, "aspect", string(current.Aspect), (*string)(&state.Word.Aspect))
+		diff = changed(diff, "pronunciation", current.PronunciationOverride, &state.Word.PronunciationOverride)
+	case state.Translation != nil:
+		var current models.Translation
+		if translation, err := repo.GetTranslationByID(revision.EntryID); err == nil {
+			current = *translation
+		}
+		diff = changed(diff, "englishTranslation", current.EnglishTranslation, &state.Translation.EnglishTranslation)
+	case state.ExampleSentence != nil:
+		var current models.ExampleSentence
+		if sentence, err := repo.GetExampleSentenceByID(revision.EntryID); err == nil {
+			current = *sentence
+		}
+		diff = changed(diff, "sentenceText", current.SentenceText, &state.ExampleSentence.SentenceText)
+	default:
+		return nil, fmt.Errorf("revision %d does not hold an entry", revision.RevisionID)
+	}
+	if len(diff) == 0 {
+		return nil, ErrNoChange
+	}
+	return diff, nil
+}
+
+func grammar(fields Fields) models.Grammar {
+	if fields.Grammar == nil {
+		return models.Grammar{}
+	}
+	return *fields.Grammar
+}
+
+func added(diff []models.FieldChange, field string, value *string) []models.FieldChange {
+	if value == nil || *value == "" {
+		return diff
+	}
+	return append(diff, models.FieldChange{Field: field, After: *value})
+}
+
+func addedGrammar(diff []models.FieldChange, grammar *models.Grammar) []models.FieldChange {
+	if grammar == nil {
+		return diff
+	}
+	diff = added(diff, "partOfSpeech", (*string)(&grammar.PartOfSpeech))
+	diff = added(diff, "gender", (*string)(&grammar.Gender))
+	return added(diff, "aspect", (*string)(&grammar.Aspect))
+}
+
+func changed(diff []models.FieldChange, field string, before string, after *string) []models.FieldChange {
+	if after == nil || *after == before {
+		return diff
+	}
+	return append(diff, models.FieldChange{Field: field, Before: before, After: *after})
+}
+
+func removed(diff []models.FieldChange, field string, value string) []models.FieldChange {
+	return append(diff, models.FieldChange{Field: field, Before: value})
+}
+
+func removedTranslation(diff []models.FieldChange, translation *models.Translation) []models.FieldChange {
+	diff = removed(diff, "englishTranslation", translation.EnglishTranslation)
+	for _, sentence := range translation.ExampleSentences {
+		diff = removed(diff, "exampleSentence", sentence.SentenceText)
+	}
+	return diff
+}
+
+func missingField(change Change, field string) error {
+	return fmt.Errorf("%s of %s is missing %s", change.Action, change.EntryType, field)
+}
@@ -0,0 +1,262 @@
+package proposal_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/proposal"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// proposalRepo keeps translations and proposals in memory. Only the methods used by proposals are implemented.
+type proposalRepo struct {
+	repository.Repository
+	words     []models.Word
+	proposals []models.ChangeProposal
+}
+
+func newProposalRepo() *proposalRepo {
+	return &proposalRepo{words: []models.Word{
+		{WordID: 1, PolishWord: "kot", Translations: []models.Translation{
+			{TranslationID: 10, WordID: 1, EnglishTranslation: "cat", ExampleSentences: []models.ExampleSentence{
+				{SentenceID: 100, TranslationID: 10, SentenceText: "Kot śpi."},
+			}},
+		}},
+	}}
+}
+
+func (r *proposalRepo) Transaction(fn func(repo repository.Repository) error) error {
+	return fn(r)
+}
+
+func (r *proposalRepo) GetWordByID(wordID uint) (*models.Word, error) {
+	for i := range r.words {
+		if r.words[i].WordID == wordID {
+			return &r.words[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *proposalRepo) GetTranslationByID(translationID uint) (*models.Translation, error) {
+	for i := range r.words {
+		for j := range r.words[i].Translations {
+			if r.words[i].Translations[j].TranslationID == translationID {
+				return &r.words[i].Translations[j], nil
+			}
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *proposalRepo) GetOrCreateTranslation(wordID uint, englishTranslation string) (*models.Translation, error) {
+	word, err := r.GetWordByID(wordID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range word.Translations {
+		if word.Translations[i].EnglishTranslation == englishTranslation {
+			return &word.Translations[i], nil
+		}
+	}
+	translationID := wordID*10 + uint(len(word.Translations))
+	word.Translations = append(word.Translations, models.Translation{TranslationID: translationID, WordID: wordID, EnglishTranslation: englishTranslation})
+	return &word.Translations[len(word.Translations)-1], nil
+}
+
+func (r *proposalRepo) GetOrCreateExampleSentence(translationID uint, sentenceText string) (*models.ExampleSentence, error) {
+	translation, err := r.GetTranslationByID(translationID)
+	if err != nil {
+		return nil, err
+	}
+	sentenceID := translationID*10 + uint(len(translation.ExampleSentences))
+	translation.ExampleSentences = append(translation.ExampleSentences, models.ExampleSentence{
+		SentenceID:    sentenceID,
+		TranslationID: translationID,
+		SentenceText:  sentenceText,
+	})
+	return &translation.ExampleSentences[len(translation.ExampleSentences)-1], nil
+}
+
+func (r *proposalRepo) UpdateTranslation(translationID uint, newEnglishTranslation string) (*models.Translation, error) {
+	translation, err := r.GetTranslationByID(translationID)
+	if err != nil {
+		return nil, err
+	}
+	translation.EnglishTranslation = newEnglishTranslation
+	return translation, nil
+}
+
+func (r *proposalRepo) DeleteTranslation(translationID uint) error {
+	for i := range r.words {
+		for j, translation := range r.words[i].Translations {
+			if translation.TranslationID == translationID {
+				r.words[i].Translations = append(r.words[i].Translations[:j], r.words[i].Translations[j+1:]...)
+				return nil
+			}
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (r *proposalRepo) CreateChangeProposal(changeProposal models.ChangeProposal) (*models.ChangeProposal, error) {
+	changeProposal.ProposalID = uint(len(r.proposals) + 1)
+	changeProposal.Status = models.ProposalPending
+	r.proposals = append(r.proposals, changeProposal)
+	return &changeProposal, nil
+}
+
+func (r *proposalRepo) GetChangeProposalByID(proposalID uint) (*models.ChangeProposal, error) {
+	if proposalID == 0 || int(proposalID) > len(r.proposals) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	changeProposal := r.proposals[proposalID-1]
+	return &changeProposal, nil
+}
+
+func (r *proposalRepo) ReviewChangeProposal(proposalID uint, status models.ProposalStatus, reviewerID uint, comment string, reviewedAt time.Time) (*models.ChangeProposal, error) {
+	changeProposal, err := r.GetChangeProposalByID(proposalID)
+	if err != nil {
+		return nil, err
+	}
+	if changeProposal.Status != models.ProposalPending {
+		return nil, repository.ErrProposalReviewed
+	}
+	changeProposal.Status = status
+	changeProposal.ReviewedBy = &reviewerID
+	changeProposal.Comment = comment
+	changeProposal.ReviewedAt = &reviewedAt
+	r.proposals[proposalID-1] = *changeProposal
+	return changeProposal, nil
+}
+
+func updateTranslation(translationID uint, englishTranslation string) proposal.Change {
+	return proposal.Change{
+		Action:    models.ActionUpdate,
+		EntryType: models.EntryTranslation,
+		EntryID:   translationID,
+		Fields:    proposal.Fields{EnglishTranslation: &englishTranslation},
+	}
+}
+
+func TestRequiredRole(t *testing.T) {
+	assert.Equal(t, models.RoleEditor, proposal.RequiredRole(proposal.Change{Action: models.ActionUpdate}), "Expected editors to make updates")
+	assert.Equal(t, models.RoleEditor, proposal.RequiredRole(proposal.Change{Action: models.ActionPromote}), "Expected editors to make promotions")
+	assert.Equal(t, models.RoleAdmin, proposal.RequiredRole(proposal.Change{Action: models.ActionDelete}), "Expected admins to make deletes")
+}
+
+func TestProposeAndApprove(t *testing.T) {
+	repo := newProposalRepo()
+	kitten := "kitten"
+	created, err := proposal.Propose(repo, proposal.Change{
+		Action:    models.ActionCreate,
+		EntryType: models.EntryTranslation,
+		EntryID:   1,
+		Fields:    proposal.Fields{EnglishTranslation: &kitten, ExampleSentences: []string{"Kotek się bawi."}},
+	}, 7)
+	require.NoError(t, err, "Propose should not error")
+	assert.Equal(t, models.ProposalPending, created.Status, "Expected a pending proposal")
+	assert.Equal(t, uint(7), created.ProposedBy, "Expected the proposer")
+	assert.Equal(t, []models.FieldChange{
+		{Field: "englishTranslation", After: "kitten"},
+		{Field: "exampleSentence", After: "Kotek się bawi."},
+	}, created.Diff, "Expected the added translation and example sentence")
+	assert.Equal(t, 1, len(repo.words[0].Translations), "Expected proposing not to change the dictionary")
+
+	approved, err := proposal.Review(repo, created.ProposalID, 3, true, "Looks good", time.Now())
+	require.NoError(t, err, "Review should not error")
+	assert.Equal(t, models.ProposalApproved, approved.Status, "Expected the proposal to be approved")
+	require.NotNil(t, approved.ReviewedBy, "Expected the reviewer to be recorded")
+	assert.Equal(t, uint(3), *approved.ReviewedBy, "Expected the reviewer")
+	require.Equal(t, 2, len(repo.words[0].Translations), "Expected the approved translation to be added")
+	added := repo.words[0].Translations[1]
+	assert.Equal(t, "kitten", added.EnglishTranslation, "Expected the approved translation")
+	require.Equal(t, 1, len(added.ExampleSentences), "Expected the approved example sentence")
+	assert.Equal(t, "Kotek się bawi.", added.ExampleSentences[0].SentenceText, "Expected the approved example sentence")
+
+	_, err = proposal.Review(repo, created.ProposalID, 3, true, "", time.Now())
+	assert.ErrorIs(t, err, repository.ErrProposalReviewed, "Expected an applied proposal not to be reviewed again")
+	assert.Equal(t, 1, len(repo.words[0].Translations[1].ExampleSentences), "Expected the change not to be applied twice")
+
+	_, err = proposal.Propose(repo, proposal.Change{Action: models.ActionCreate, EntryType: models.EntryTranslation, EntryID: 99}, 7)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected proposals for missing words to fail")
+}
+
+func TestReject(t *testing.T) {
+	repo := newProposalRepo()
+	update, err := proposal.Propose(repo, updateTranslation(10, "kitty"), 7)
+	require.NoError(t, err, "Propose should not error")
+
+	rejected, err := proposal.Review(repo, update.ProposalID, 3, false, "Too informal", time.Now())
+	require.NoError(t, err, "Review should not error")
+	assert.Equal(t, models.ProposalRejected, rejected.Status, "Expected the proposal to be rejected")
+	assert.Equal(t, "Too informal", rejected.Comment, "Expected the comment of the reviewer")
+	assert.Equal(t, "cat", repo.words[0].Translations[0].EnglishTranslation, "Expected the rejected change not to be applied")
+
+	_, err = proposal.Review(repo, update.ProposalID, 3, true, "", time.Now())
+	assert.ErrorIs(t, err, repository.ErrProposalReviewed, "Expected a rejected proposal not to be approved")
+	assert.Equal(t, "cat", repo.words[0].Translations[0].EnglishTranslation, "Expected the rejected change not to be applied")
+}
+
+func TestReviewFailingChange(t *testing.T) {
+	repo := newProposalRepo()
+	update, err := proposal.Propose(repo, updateTranslation(10, "kitty"), 7)
+	require.NoError(t, err, "Propose should not error")
+	require.NoError(t, repo.DeleteTranslation(10), "DeleteTranslation should not error")
+
+	_, err = proposal.Review(repo, update.ProposalID, 3, true, "", time.Now())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected the change of a deleted translation to fail")
+	pending, err := repo.GetChangeProposalByID(update.ProposalID)
+	require.NoError(t, err, "GetChangeProposalByID should not error")
+	assert.Equal(t, models.ProposalPending, pending.Status, "Expected the proposal to stay pending")
+}
+
+func TestDiffStaleBase(t *testing.T) {
+	repo := newProposalRepo()
+	update, err := proposal.Propose(repo, updateTranslation(10, "kitty"), 7)
+	require.NoError(t, err, "Propose should not error")
+
+	// The translation changes after the proposal was made
+	_, err = repo.UpdateTranslation(10, "puss")
+	require.NoError(t, err, "UpdateTranslation should not error")
+	change, err := proposal.Decode(update)
+	require.NoError(t, err, "Decode should not error")
+	diff, err := proposal.Diff(repo, change)
+	require.NoError(t, err, "Diff should not error")
+	assert.Equal(t, []models.FieldChange{{Field: "englishTranslation", Before: "puss", After: "kitty"}}, diff,
+		"Expected the diff against the current dictionary")
+	assert.Equal(t, []models.FieldChange{{Field: "englishTranslation", Before: "cat", After: "kitty"}}, update.Diff,
+		"Expected the stored diff against the dictionary at the time of the proposal")
+
+	// The same change is made directly before the proposal is reviewed
+	_, err = repo.UpdateTranslation(10, "kitty")
+	require.NoError(t, err, "UpdateTranslation should not error")
+	_, err = proposal.Diff(repo, change)
+	assert.ErrorIs(t, err, proposal.ErrNoChange, "Expected an applied change not to differ from the dictionary")
+	_, err = proposal.Propose(repo, change, 7)
+	assert.ErrorIs(t, err, proposal.ErrNoChange, "Expected an applied change not to be proposed")
+}
+
+func TestApply(t *testing.T) {
+	repo := newProposalRepo()
+	diff, err := proposal.Diff(repo, proposal.Change{Action: models.ActionDelete, EntryType: models.EntryTranslation, EntryID: 10})
+	require.NoError(t, err, "Diff should not error")
+	assert.Equal(t, []models.FieldChange{
+		{Field: "englishTranslation", Before: "cat"},
+		{Field: "exampleSentence", Before: "Kot śpi."},
+	}, diff, "Expected the deleted translation with its example sentence")
+
+	err = proposal.Apply(repo, proposal.Change{Action: models.ActionDelete, EntryType: models.EntryTranslation, EntryID: 10})
+	require.NoError(t, err, "Apply should not error")
+	assert.Empty(t, repo.words[0].Translations, "Expected the translation to be deleted")
+
+	err = proposal.Apply(repo, proposal.Change{Action: models.ActionUpdate, EntryType: models.EntryTranslation, EntryID: 10})
+	assert.Error(t, err, "Expected an update without a translation to fail")
+	err = proposal.Apply(repo, proposal.Change{Action: "rename", EntryType: models.EntryTranslation, EntryID: 10})
+	assert.EqualError(t, err, fmt.Sprintf("unknown action: %q", "rename"), "Expected an unknown action to fail")
+}
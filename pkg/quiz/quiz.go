@@ -0,0 +1,398 @@
+package quiz
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/sar-michal/dictionary-app/pkg/lemmatize"
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/normalize"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+)
+
+// Direction of a question: the language of the prompt and of the answer.
+type Direction int
+
+const (
+	PolishToEnglish Direction = iota
+	EnglishToPolish
+)
+
+// Type of a question.
+type Type int
+
+const (
+	// MultipleChoice asks to pick the translation among distractors taken from other translations.
+	MultipleChoice Type = iota
+	// TypedAnswer asks to type the translation.
+	TypedAnswer
+	// Cloze asks to fill the Polish word blanked out of an example sentence.
+	// The answer is always Polish, so the direction does not apply.
+	Cloze
+)
+
+const (
+	// MaxSize is the maximal number of questions in a quiz.
+	MaxSize = 50
+	// NumChoices is the number of choices of a multiple choice question, including the answer.
+	NumChoices = 4
+	// Blank replaces the word to fill in a cloze sentence.
+	Blank = "_____"
+)
+
+// ErrInvalidQuestionID is returned when a question ID cannot be decoded.
+var ErrInvalidQuestionID = errors.New("invalid question ID")
+
+// Question of a quiz. The ID identifies the question when checking an answer.
+type Question struct {
+	ID        string
+	Type      Type
+	Direction Direction
+	Prompt    string
+	// Hint is the English translation of the word to fill in a cloze sentence.
+	Hint    string
+	Choices []string
+}
+
+// Result of checking an answer.
+type Result struct {
+	Correct bool
+	// Accepted lists the correct answers.
+	Accepted []string
+}
+
+// questionKey is encoded in question IDs. Answers are looked up again when checking,
+// so the ID does not reveal them.
+type questionKey struct {
+	Type          Type      `json:"t"`
+	Direction     Direction `json:"d"`
+	TranslationID uint      `json:"tr"`
+	SentenceID    uint      `json:"s,omitempty"`
+}
+
+func encodeQuestionID(key questionKey) string {
+	data, _ := json.Marshal(key)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeQuestionID(id string) (questionKey, error) {
+	var key questionKey
+	data, err := base64.RawURLEncoding.DecodeString(id)
+	if err != nil {
+		return key, ErrInvalidQuestionID
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return key, ErrInvalidQuestionID
+	}
+	return key, nil
+}
+
+// item is a translation that questions can be asked about.
+type item struct {
+	word        *models.Word
+	translation *models.Translation
+}
+
+// Generator builds quizzes from the dictionary. The same Rand seed and dictionary give the same quiz.
+type Generator struct {
+	Repo repository.Repository
+	Rand *rand.Rand
+}
+
+// Generate returns up to size questions about randomly chosen translations.
+// Fewer questions are returned when the dictionary does not have enough translations,
+// or, for cloze questions, example sentences.
+func (g *Generator) Generate(size int, direction Direction, questionType Type) ([]Question, error) {
+	if size < 1 || size > MaxSize {
+		return nil, fmt.Errorf("quiz size must be between 1 and %d", MaxSize)
+	}
+	items, err := g.loadItems(size, questionType == Cloze)
+	if err != nil {
+		return nil, err
+	}
+	g.Rand.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
+
+	questions := []Question{}
+	asked := make(map[string]bool)
+	for _, it := range items {
+		if len(questions) == size {
+			break
+		}
+		var question Question
+		var ok bool
+		switch questionType {
+		case MultipleChoice:
+			question, ok = g.multipleChoice(it, items, direction)
+		case TypedAnswer:
+			question, ok = typedAnswer(it, direction), true
+		case Cloze:
+			question, ok = g.cloze(it)
+		default:
+			return nil, fmt.Errorf("unknown question type: %d", questionType)
+		}
+		// Homonyms and shared translations would repeat a prompt.
+		if !ok || asked[question.Prompt] {
+			continue
+		}
+		asked[question.Prompt] = true
+		questions = append(questions, question)
+	}
+	if questionType == MultipleChoice && len(questions) == 0 && len(items) > 0 {
+		return nil, fmt.Errorf("not enough translations for multiple choice questions")
+	}
+	return questions, nil
+}
+
+// loadItems samples the translations that questions can be asked about, optionally only those
+// with example sentences, and loads their words. More translations are sampled than questions asked,
+// since some are skipped and multiple choice questions draw their distractors from the others.
+func (g *Generator) loadItems(size int, withSentences bool) ([]item, error) {
+	sample, err := g.Repo.SampleTranslations(g.Rand.Uint64(), size*NumChoices, withSentences)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sample translations: %w", err)
+	}
+	if len(sample) == 0 {
+		return nil, nil
+	}
+	filter := repository.WordFilter{}
+	for _, t := range sample {
+		filter.WordIDs = append(filter.WordIDs, t.WordID)
+	}
+	byID := make(map[uint]item, len(sample))
+	err = g.Repo.StreamWords(filter, func(streamed *models.Word) error {
+		// The streamed word is overwritten by the next batch.
+		word := *streamed
+		word.Translations = slices.Clone(streamed.Translations)
+		for i := range word.Translations {
+			byID[word.Translations[i].TranslationID] = item{word: &word, translation: &word.Translations[i]}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load translations: %w", err)
+	}
+
+	items := make([]item, 0, len(sample))
+	for _, t := range sample {
+		// Skip translations changed since sampling.
+		it, ok := byID[t.TranslationID]
+		if !ok || (withSentences && len(it.translation.ExampleSentences) == 0) {
+			continue
+		}
+		items = append(items, it)
+	}
+	return items, nil
+}
+
+// prompt returns the prompt and the answer of an item in the given direction.
+func prompt(it item, direction Direction) (string, string) {
+	if direction == EnglishToPolish {
+		return it.translation.EnglishTranslation, it.word.PolishWord
+	}
+	return it.word.PolishWord, it.translation.EnglishTranslation
+}
+
+func typedAnswer(it item, direction Direction) Question {
+	text, _ := prompt(it, direction)
+	return Question{
+		ID:        encodeQuestionID(questionKey{Type: TypedAnswer, Direction: direction, TranslationID: it.translation.TranslationID}),
+		Type:      TypedAnswer,
+		Direction: direction,
+		Prompt:    text,
+	}
+}
+
+// multipleChoice draws distractors from the answers of other items, preferring
+// words of the same part of speech so that the choices are plausible.
+func (g *Generator) multipleChoice(it item, items []item, direction Direction) (Question, bool) {
+	text, answer := prompt(it, direction)
+	excluded := map[string]bool{normalizeAnswer(answer): true}
+	if direction == PolishToEnglish {
+		// Other translations of the same word are correct too.
+		for _, t := range it.word.Translations {
+			excluded[normalizeAnswer(t.EnglishTranslation)] = true
+		}
+	} else {
+		// So are other words with the same translation.
+		for _, other := range items {
+			for _, t := range other.word.Translations {
+				if t.EnglishTranslation == text {
+					excluded[normalizeAnswer(other.word.PolishWord)] = true
+				}
+			}
+		}
+	}
+
+	var similar, others []string
+	for _, other := range items {
+		_, candidate := prompt(other, direction)
+		key := normalizeAnswer(candidate)
+		if excluded[key] {
+			continue
+		}
+		excluded[key] = true
+		if other.word.PartOfSpeech == it.word.PartOfSpeech {
+			similar = append(similar, candidate)
+		} else {
+			others = append(others, candidate)
+		}
+	}
+	g.Rand.Shuffle(len(similar), func(i, j int) { similar[i], similar[j] = similar[j], similar[i] })
+	g.Rand.Shuffle(len(others), func(i, j int) { others[i], others[j] = others[j], others[i] })
+	distractors := append(similar, others...)
+	if len(distractors) == 0 {
+		return Question{}, false
+	}
+
+	choices := append([]string{answer}, distractors[:min(len(distractors), NumChoices-1)]...)
+	g.Rand.Shuffle(len(choices), func(i, j int) { choices[i], choices[j] = choices[j], choices[i] })
+	return Question{
+		ID:        encodeQuestionID(questionKey{Type: MultipleChoice, Direction: direction, TranslationID: it.translation.TranslationID}),
+		Type:      MultipleChoice,
+		Direction: direction,
+		Prompt:    text,
+		Choices:   choices,
+	}, true
+}
+
+func (g *Generator) cloze(it item) (Question, bool) {
+	sentences := slices.Clone(it.translation.ExampleSentences)
+	g.Rand.Shuffle(len(sentences), func(i, j int) { sentences[i], sentences[j] = sentences[j], sentences[i] })
+	for _, sentence := range sentences {
+		start, end, ok := findWord(sentence.SentenceText, it.word.PolishWord)
+		if !ok {
+			continue
+		}
+		return Question{
+			ID: encodeQuestionID(questionKey{
+				Type:          Cloze,
+				TranslationID: it.translation.TranslationID,
+				SentenceID:    sentence.SentenceID,
+			}),
+			Type:   Cloze,
+			Prompt: sentence.SentenceText[:start] + Blank + sentence.SentenceText[end:],
+			Hint:   it.translation.EnglishTranslation,
+		}, true
+	}
+	return Question{}, false
+}
+
+// findWord locates the headword in a sentence and returns the byte range of its first occurrence.
+// The headword matches ignoring case and diacritics, and inflected forms match when
+// suffix stripping leads back to the headword, e.g. "kota" for "kot".
+func findWord(sentence, headword string) (int, int, bool) {
+	folded := normalize.Fold(headword)
+	tokens := tokenize(sentence)
+	// Multi-word headwords match a run of tokens.
+	length := len(strings.Fields(headword))
+	for i := 0; i+length <= len(tokens); i++ {
+		start, end := tokens[i][0], tokens[i+length-1][1]
+		if normalize.Fold(strings.Join(strings.Fields(sentence[start:end]), " ")) == folded {
+			return start, end, true
+		}
+	}
+	if length > 1 {
+		return 0, 0, false
+	}
+	lower := strings.ToLower(headword)
+	for _, token := range tokens {
+		for _, candidate := range lemmatize.Candidates(sentence[token[0]:token[1]]) {
+			if candidate.Lemma == lower {
+				return token[0], token[1], true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// tokenize returns the byte ranges of the words of a sentence.
+func tokenize(sentence string) [][2]int {
+	var tokens [][2]int
+	start := -1
+	for i, r := range sentence {
+		isLetter := unicode.IsLetter(r) || r == '-'
+		if isLetter && start < 0 {
+			start = i
+		} else if !isLetter && start >= 0 {
+			tokens = append(tokens, [2]int{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, [2]int{start, len(sentence)})
+	}
+	return tokens
+}
+
+// Check tells whether the answer to the question is correct. Answers are
+// compared ignoring case, diacritics, surrounding punctuation and extra spaces.
+func Check(repo repository.Repository, questionID, answer string) (*Result, error) {
+	key, err := decodeQuestionID(questionID)
+	if err != nil {
+		return nil, err
+	}
+	accepted, err := acceptedAnswers(repo, key)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Accepted: accepted}
+	for _, a := range accepted {
+		if normalizeAnswer(a) == normalizeAnswer(answer) {
+			result.Correct = true
+		}
+	}
+	return result, nil
+}
+
+func acceptedAnswers(repo repository.Repository, key questionKey) ([]string, error) {
+	translation, err := repo.GetTranslationByID(key.TranslationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translation: %w", err)
+	}
+	word, err := repo.GetWordByID(translation.WordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word: %w", err)
+	}
+
+	var accepted []string
+	switch {
+	case key.Type == Cloze:
+		sentence, err := repo.GetExampleSentenceByID(key.SentenceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get example sentence: %w", err)
+		}
+		start, end, ok := findWord(sentence.SentenceText, word.PolishWord)
+		if !ok {
+			return nil, fmt.Errorf("the word no longer appears in the example sentence")
+		}
+		accepted = append(accepted, sentence.SentenceText[start:end])
+	case key.Direction == EnglishToPolish:
+		words, err := repo.ListWordsByEnglish(translation.EnglishTranslation)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list words: %w", err)
+		}
+		for _, w := range words {
+			accepted = append(accepted, w.PolishWord)
+		}
+	default:
+		for _, t := range word.Translations {
+			accepted = append(accepted, t.EnglishTranslation)
+		}
+	}
+	slices.Sort(accepted)
+	return slices.Compact(accepted), nil
+}
+
+// normalizeAnswer folds an answer for comparison.
+func normalizeAnswer(answer string) string {
+	answer = strings.TrimFunc(answer, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	})
+	return normalize.Fold(strings.Join(strings.Fields(answer), " "))
+}
@@ -0,0 +1,224 @@
+package quiz_test
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/quiz"
+	"github.com/sar-michal/dictionary-app/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dictionaryRepo serves a fixed dictionary. Other repository methods are not used.
+type dictionaryRepo struct {
+	repository.Repository
+	words []models.Word
+}
+
+func newDictionaryRepo() *dictionaryRepo {
+	noun, verb := models.PartOfSpeechNoun, models.PartOfSpeechVerb
+	entries := []struct {
+		polish       string
+		pos          models.PartOfSpeech
+		translations map[string][]string
+	}{
+		{"kot", noun, map[string][]string{"cat": {"Widzę kota na dachu."}}},
+		{"pies", noun, map[string][]string{"dog": {"Pies szczeka."}}},
+		{"żółw", noun, map[string][]string{"turtle": {"Żółw jest powolny."}, "tortoise": nil}},
+		{"dom", noun, map[string][]string{"house": {"To jest mój dom."}, "home": nil}},
+		{"czytać", verb, map[string][]string{"read": {"Lubię czytać książki."}}},
+		{"kocur", noun, map[string][]string{"cat": nil, "tomcat": nil}},
+	}
+	repo := &dictionaryRepo{}
+	var translationID, sentenceID uint
+	for i, e := range entries {
+		word := models.Word{WordID: uint(i + 1), PolishWord: e.polish, PartOfSpeech: e.pos}
+		for _, english := range slices.Sorted(maps.Keys(e.translations)) {
+			translationID++
+			translation := models.Translation{TranslationID: translationID, WordID: word.WordID, EnglishTranslation: english}
+			for _, text := range e.translations[english] {
+				sentenceID++
+				translation.ExampleSentences = append(translation.ExampleSentences,
+					models.ExampleSentence{SentenceID: sentenceID, TranslationID: translationID, SentenceText: text})
+			}
+			word.Translations = append(word.Translations, translation)
+		}
+		repo.words = append(repo.words, word)
+	}
+	return repo
+}
+
+// SampleTranslations returns the first translations in ID order; Generate shuffles them.
+func (r *dictionaryRepo) SampleTranslations(seed uint64, limit int, withSentences bool) ([]models.Translation, error) {
+	var translations []models.Translation
+	for _, w := range r.words {
+		for _, t := range w.Translations {
+			if len(translations) < limit && (!withSentences || len(t.ExampleSentences) > 0) {
+				translations = append(translations, t)
+			}
+		}
+	}
+	return translations, nil
+}
+
+func (r *dictionaryRepo) StreamWords(filter repository.WordFilter, fn func(word *models.Word) error) error {
+	for i := range r.words {
+		if len(filter.WordIDs) > 0 && !slices.Contains(filter.WordIDs, r.words[i].WordID) {
+			continue
+		}
+		if err := fn(&r.words[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *dictionaryRepo) GetWordByID(wordID uint) (*models.Word, error) {
+	for i := range r.words {
+		if r.words[i].WordID == wordID {
+			return &r.words[i], nil
+		}
+	}
+	return nil, fmt.Errorf("word %d not found", wordID)
+}
+
+func (r *dictionaryRepo) GetTranslationByID(translationID uint) (*models.Translation, error) {
+	for _, w := range r.words {
+		for i := range w.Translations {
+			if w.Translations[i].TranslationID == translationID {
+				return &w.Translations[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("translation %d not found", translationID)
+}
+
+func (r *dictionaryRepo) GetExampleSentenceByID(sentenceID uint) (*models.ExampleSentence, error) {
+	for _, w := range r.words {
+		for _, t := range w.Translations {
+			for i := range t.ExampleSentences {
+				if t.ExampleSentences[i].SentenceID == sentenceID {
+					return &t.ExampleSentences[i], nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("sentence %d not found", sentenceID)
+}
+
+func (r *dictionaryRepo) ListWordsByEnglish(englishTranslation string) ([]models.Word, error) {
+	var words []models.Word
+	for _, w := range r.words {
+		for _, t := range w.Translations {
+			if t.EnglishTranslation == englishTranslation {
+				words = append(words, w)
+			}
+		}
+	}
+	return words, nil
+}
+
+func generate(t *testing.T, repo repository.Repository, seed uint64, size int, direction quiz.Direction, questionType quiz.Type) []quiz.Question {
+	generator := &quiz.Generator{Repo: repo, Rand: rand.New(rand.NewPCG(seed, seed))}
+	questions, err := generator.Generate(size, direction, questionType)
+	require.NoError(t, err, "Generate Should Not Error")
+	return questions
+}
+
+func TestGenerateIsReproducible(t *testing.T) {
+	repo := newDictionaryRepo()
+	first := generate(t, repo, 42, 5, quiz.PolishToEnglish, quiz.MultipleChoice)
+	second := generate(t, repo, 42, 5, quiz.PolishToEnglish, quiz.MultipleChoice)
+	assert.Equal(t, first, second, "Expected The Same Quiz For The Same Seed")
+	assert.Equal(t, 5, len(first), "Expected Five Questions")
+
+	other := generate(t, repo, 7, 5, quiz.PolishToEnglish, quiz.MultipleChoice)
+	assert.NotEqual(t, first, other, "Expected A Different Quiz For Another Seed")
+}
+
+func TestMultipleChoice(t *testing.T) {
+	repo := newDictionaryRepo()
+	for _, question := range generate(t, repo, 1, 10, quiz.PolishToEnglish, quiz.MultipleChoice) {
+		require.Equal(t, quiz.NumChoices, len(question.Choices), "Expected %d Choices", quiz.NumChoices)
+		correct := 0
+		for _, choice := range question.Choices {
+			result, err := quiz.Check(repo, question.ID, choice)
+			require.NoError(t, err, "Check Should Not Error")
+			if result.Correct {
+				correct++
+			}
+		}
+		assert.Equal(t, 1, correct, "Expected Exactly One Correct Choice For '%s': %v", question.Prompt, question.Choices)
+	}
+}
+
+func TestTypedAnswer(t *testing.T) {
+	repo := newDictionaryRepo()
+	questions := generate(t, repo, 3, 10, quiz.EnglishToPolish, quiz.TypedAnswer)
+	var prompts []string
+	for _, q := range questions {
+		prompts = append(prompts, q.Prompt)
+	}
+	assert.ElementsMatch(t, []string{"cat", "dog", "turtle", "tortoise", "house", "home", "read", "tomcat"}, prompts,
+		"Expected Each English Translation Once")
+
+	for _, q := range questions {
+		if q.Prompt == "turtle" {
+			result, err := quiz.Check(repo, q.ID, "  ZOLW ")
+			require.NoError(t, err, "Check Should Not Error")
+			assert.True(t, result.Correct, "Expected Answer Without Diacritics To Be Correct")
+			assert.Equal(t, []string{"żółw"}, result.Accepted, "Expected Accepted Answer")
+		}
+		if q.Prompt == "cat" {
+			for _, answer := range []string{"kot", "Kocur!"} {
+				result, err := quiz.Check(repo, q.ID, answer)
+				require.NoError(t, err, "Check Should Not Error")
+				assert.True(t, result.Correct, "Expected '%s' To Be Correct", answer)
+			}
+			result, err := quiz.Check(repo, q.ID, "pies")
+			require.NoError(t, err, "Check Should Not Error")
+			assert.False(t, result.Correct, "Expected 'pies' To Be Wrong")
+		}
+	}
+}
+
+func TestCloze(t *testing.T) {
+	repo := newDictionaryRepo()
+	questions := generate(t, repo, 5, 10, quiz.PolishToEnglish, quiz.Cloze)
+	require.Equal(t, 5, len(questions), "Expected A Question Per Translation With Sentences")
+
+	for _, q := range questions {
+		assert.Contains(t, q.Prompt, quiz.Blank, "Expected A Blank In '%s'", q.Prompt)
+		switch q.Hint {
+		case "cat":
+			assert.Equal(t, "Widzę "+quiz.Blank+" na dachu.", q.Prompt, "Expected Inflected Form To Be Blanked")
+			result, err := quiz.Check(repo, q.ID, "kota")
+			require.NoError(t, err, "Check Should Not Error")
+			assert.True(t, result.Correct, "Expected 'kota' To Be Correct")
+		case "turtle":
+			assert.True(t, strings.HasPrefix(q.Prompt, quiz.Blank), "Expected Capitalized Word To Be Blanked")
+			result, err := quiz.Check(repo, q.ID, "zolw")
+			require.NoError(t, err, "Check Should Not Error")
+			assert.True(t, result.Correct, "Expected 'zolw' To Be Correct")
+		}
+	}
+}
+
+func TestCheckInvalidID(t *testing.T) {
+	_, err := quiz.Check(newDictionaryRepo(), "not a question", "kot")
+	assert.ErrorIs(t, err, quiz.ErrInvalidQuestionID, "Expected Invalid Question ID Error")
+}
+
+func TestGenerateSize(t *testing.T) {
+	generator := &quiz.Generator{Repo: newDictionaryRepo(), Rand: rand.New(rand.NewPCG(1, 1))}
+	_, err := generator.Generate(0, quiz.PolishToEnglish, quiz.TypedAnswer)
+	assert.Error(t, err, "Expected Error For Empty Quiz")
+	_, err = generator.Generate(quiz.MaxSize+1, quiz.PolishToEnglish, quiz.TypedAnswer)
+	assert.Error(t, err, "Expected Error For Too Large Quiz")
+}
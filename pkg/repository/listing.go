@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"gorm.io/gorm"
+)
+
+// WordOrderField selects what words are sorted by.
+type WordOrderField int
+
+const (
+	WordOrderByID WordOrderField = iota
+	// WordOrderByPolishWord sorts alphabetically using Polish collation rules.
+	WordOrderByPolishWord
+	WordOrderByCreatedAt
+	WordOrderByTranslationCount
+)
+
+// WordOrder defines the order of listed words.
+type WordOrder struct {
+	Field      WordOrderField
+	Descending bool
+}
+
+// WordFilter narrows down listed words. Nil or empty fields disable a condition.
+type WordFilter struct {
+	// WordIDs limits the words to the given IDs when it is not empty.
+	WordIDs []uint
+	// StartsWith matches words beginning with the given text, ignoring case.
+	StartsWith          string
+	HasTranslations     *bool
+	HasExampleSentences *bool
+	PartOfSpeech        *models.PartOfSpeech
+	Gender              *models.Gender
+	Aspect              *models.Aspect
+}
+
+// ParseWordIDs parses a comma-separated list of word IDs for WordFilter.WordIDs, e.g. "1,2, 3".
+func ParseWordIDs(list string) ([]uint, error) {
+	var ids []uint
+	for _, s := range strings.Split(list, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid word ID: %q", s)
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
+// TranslationOrderField selects what translations are sorted by.
+type TranslationOrderField int
+
+const (
+	TranslationOrderByID TranslationOrderField = iota
+	TranslationOrderByEnglishTranslation
+	TranslationOrderByCreatedAt
+	TranslationOrderByExampleSentenceCount
+)
+
+// TranslationOrder defines the order of listed translations.
+type TranslationOrder struct {
+	Field      TranslationOrderField
+	Descending bool
+}
+
+// TranslationFilter narrows down listed translations. Nil or empty fields disable a condition.
+type TranslationFilter struct {
+	// StartsWith matches translations beginning with the given text, ignoring case.
+	StartsWith          string
+	HasExampleSentences *bool
+}
+
+// wordKeyset returns the keyset used to sort words in the given order.
+// Only visible translations are counted.
+func (r *GormRepository) wordKeyset(order WordOrder) (keyset, error) {
+	ks := keyset{
+		idColumn: "words.word_id",
+		desc:     order.Descending,
+	}
+	switch order.Field {
+	case WordOrderByID:
+		ks.sortExpr, ks.sortType = "words.word_id", "bigint"
+	case WordOrderByPolishWord:
+		ks.sortExpr, ks.sortType = "words.polish_word COLLATE polish", "text"
+	case WordOrderByCreatedAt:
+		ks.sortExpr, ks.sortType = "words.created_at", "timestamptz"
+	case WordOrderByTranslationCount:
+		ks.sortExpr = "(SELECT COUNT(*) FROM translations t WHERE t.word_id = words.word_id AND t.owner IN ? AND t.deleted_at IS NULL)"
+		ks.sortArgs, ks.sortType = []any{r.Scope.owners()}, "bigint"
+	default:
+		return ks, fmt.Errorf("unknown word order field: %d", order.Field)
+	}
+	return ks, nil
+}
+
+// filterWords limits a statement on the words table to the visible words matching the filter.
+// Only visible translations and example sentences are taken into account.
+func (r *GormRepository) filterWords(stmt *gorm.DB, filter WordFilter) *gorm.DB {
+	owners := r.Scope.owners()
+	stmt = stmt.Scopes(r.visible("words"))
+	if len(filter.WordIDs) > 0 {
+		stmt = stmt.Where("words.word_id IN ?", filter.WordIDs)
+	}
+	if filter.StartsWith != "" {
+		stmt = stmt.Where("words.polish_word ILIKE ?", likeEscaper.Replace(filter.StartsWith)+"%")
+	}
+	if filter.HasTranslations != nil {
+		stmt = stmt.Where(existsCondition(
+			"SELECT 1 FROM translations t WHERE t.word_id = words.word_id AND t.owner IN ? AND t.deleted_at IS NULL",
+			*filter.HasTranslations,
+		), owners)
+	}
+	if filter.HasExampleSentences != nil {
+		stmt = stmt.Where(existsCondition(
+			"SELECT 1 FROM translations t JOIN example_sentences s ON s.translation_id = t.translation_id "+
+				"WHERE t.word_id = words.word_id AND t.owner IN ? AND s.owner IN ? AND t.deleted_at IS NULL AND s.deleted_at IS NULL",
+			*filter.HasExampleSentences,
+		), owners, owners)
+	}
+	if filter.PartOfSpeech != nil {
+		stmt = stmt.Where("words.part_of_speech = ?", *filter.PartOfSpeech)
+	}
+	if filter.Gender != nil {
+		stmt = stmt.Where("words.gender = ?", *filter.Gender)
+	}
+	if filter.Aspect != nil {
+		stmt = stmt.Where("words.aspect = ?", *filter.Aspect)
+	}
+	return stmt
+}
+
+// translationKeyset returns the keyset used to sort translations in the given order.
+// Only visible example sentences are counted.
+func (r *GormRepository) translationKeyset(order TranslationOrder) (keyset, error) {
+	ks := keyset{
+		idColumn: "translations.translation_id",
+		desc:     order.Descending,
+	}
+	switch order.Field {
+	case TranslationOrderByID:
+		ks.sortExpr, ks.sortType = "translations.translation_id", "bigint"
+	case TranslationOrderByEnglishTranslation:
+		ks.sortExpr, ks.sortType = "translations.english_translation", "text"
+	case TranslationOrderByCreatedAt:
+		ks.sortExpr, ks.sortType = "translations.created_at", "timestamptz"
+	case TranslationOrderByExampleSentenceCount:
+		ks.sortExpr = "(SELECT COUNT(*) FROM example_sentences s " +
+			"WHERE s.translation_id = translations.translation_id AND s.owner IN ? AND s.deleted_at IS NULL)"
+		ks.sortArgs, ks.sortType = []any{r.Scope.owners()}, "bigint"
+	default:
+		return ks, fmt.Errorf("unknown translation order field: %d", order.Field)
+	}
+	return ks, nil
+}
+
+// filterTranslations applies the filter conditions to a statement on the translations table.
+// Only visible example sentences are taken into account.
+func (r *GormRepository) filterTranslations(stmt *gorm.DB, filter TranslationFilter) *gorm.DB {
+	if filter.StartsWith != "" {
+		stmt = stmt.Where("translations.english_translation ILIKE ?", likeEscaper.Replace(filter.StartsWith)+"%")
+	}
+	if filter.HasExampleSentences != nil {
+		stmt = stmt.Where(existsCondition(
+			"SELECT 1 FROM example_sentences s WHERE s.translation_id = translations.translation_id AND s.owner IN ? AND s.deleted_at IS NULL",
+			*filter.HasExampleSentences,
+		), r.Scope.owners())
+	}
+	return stmt
+}
+
+func existsCondition(subquery string, exists bool) string {
+	if exists {
+		return "EXISTS (" + subquery + ")"
+	}
+	return "NOT EXISTS (" + subquery + ")"
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultPageSize is used when neither First nor Last is set.
+const DefaultPageSize = 20
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageArgs holds Relay-style pagination arguments.
+// First/After page forwards, Last/Before page backwards. Cursors are opaque strings.
+type PageArgs struct {
+	First  int
+	After  string
+	Last   int
+	Before string
+}
+
+// Page is a slice of items along with their cursors and page information.
+type Page[T any] struct {
+	Items           []T
+	Cursors         []string
+	HasNextPage     bool
+	HasPreviousPage bool
+	TotalCount      int64
+}
+
+// cursor identifies a row by its sort key and unique ID.
+type cursor struct {
+	Key string `json:"k"`
+	ID  uint   `json:"i"`
+}
+
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	var c cursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// keyset describes the ordering used for cursor pagination.
+type keyset struct {
+	// sortExpr is the SQL expression rows are sorted by.
+	sortExpr string
+	// sortArgs are bound to the placeholders of sortExpr.
+	sortArgs []any
+	// sortType is the SQL type the cursor key is cast back to when comparing.
+	sortType string
+	// idColumn is the unique column used as a tie-breaker.
+	idColumn string
+	desc     bool
+}
+
+// condition returns a WHERE clause selecting rows after (or before) the cursor in the keyset order.
+func (ks keyset) condition(c cursor, after bool) (string, []any) {
+	op := ">"
+	if after == ks.desc {
+		op = "<"
+	}
+	query := fmt.Sprintf("(%s, %s) %s (CAST(? AS %s), ?)", ks.sortExpr, ks.idColumn, op, ks.sortType)
+	return query, append(slices.Clone(ks.sortArgs), c.Key, c.ID)
+}
+
+// order returns the ORDER BY clause of the keyset. It is meant for db.Clauses,
+// since db.Order does not bind the sortArgs.
+func (ks keyset) order(reverse bool) clause.OrderBy {
+	dir := "ASC"
+	if ks.desc != reverse {
+		dir = "DESC"
+	}
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:  fmt.Sprintf("%s %s, %s %s", ks.sortExpr, dir, ks.idColumn, dir),
+		Vars: ks.sortArgs,
+	}}
+}
+
+// fetchPage runs keyset pagination over the rows selected by base.
+// base must return a fresh statement with the model and filters applied.
+// load fetches the full rows by their IDs.
+func fetchPage[T any](base func() *gorm.DB, ks keyset, page PageArgs, load func(ids []uint) (map[uint]T, error)) (*Page[T], error) {
+	if page.First < 0 || page.Last < 0 {
+		return nil, fmt.Errorf("page size cannot be negative")
+	}
+	if page.First > 0 && page.Last > 0 {
+		return nil, fmt.Errorf("first and last cannot be used together")
+	}
+	backward := page.Last > 0
+	limit := page.First
+	if backward {
+		limit = page.Last
+	}
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+
+	var total int64
+	if err := base().Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	stmt := base().Select(fmt.Sprintf("%s AS id, CAST(%s AS text) AS sort_key", ks.idColumn, ks.sortExpr), ks.sortArgs...)
+	if page.After != "" {
+		c, err := decodeCursor(page.After)
+		if err != nil {
+			return nil, err
+		}
+		query, args := ks.condition(c, true)
+		stmt = stmt.Where(query, args...)
+	}
+	if page.Before != "" {
+		c, err := decodeCursor(page.Before)
+		if err != nil {
+			return nil, err
+		}
+		query, args := ks.condition(c, false)
+		stmt = stmt.Where(query, args...)
+	}
+
+	var keys []struct {
+		ID      uint
+		SortKey string
+	}
+	// Fetch one extra row to know whether there are more rows in the paging direction.
+	err := stmt.Clauses(ks.order(backward)).Limit(limit + 1).Scan(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	hasMore := len(keys) > limit
+	if hasMore {
+		keys = keys[:limit]
+	}
+	if backward {
+		slices.Reverse(keys)
+	}
+
+	result := &Page[T]{
+		Items:      make([]T, 0, len(keys)),
+		Cursors:    make([]string, 0, len(keys)),
+		TotalCount: total,
+	}
+	if backward {
+		result.HasPreviousPage = hasMore
+		result.HasNextPage = page.Before != ""
+	} else {
+		result.HasNextPage = hasMore
+		result.HasPreviousPage = page.After != ""
+	}
+	if len(keys) == 0 {
+		return result, nil
+	}
+
+	ids := make([]uint, len(keys))
+	for i, k := range keys {
+		ids[i] = k.ID
+	}
+	items, err := load(ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		item, ok := items[k.ID]
+		if !ok {
+			// Deleted between the two queries.
+			continue
+		}
+		result.Items = append(result.Items, item)
+		result.Cursors = append(result.Cursors, encodeCursor(cursor{Key: k.SortKey, ID: k.ID}))
+	}
+	return result, nil
+}
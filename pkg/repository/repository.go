@@ -1,322 +1,1906 @@
 package repository
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sar-michal/dictionary-app/pkg/lemmatize"
 	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/normalize"
+	"github.com/sar-michal/dictionary-app/pkg/srs"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+// Repository gives access to the dictionary. Words, translations and example sentences
+// are limited to the shared dictionary and the private entries of the Scope.
 type Repository interface {
+	// WithScope returns a repository that merges the shared dictionary with the private entries of the scope.
+	WithScope(scope Scope) Repository
+
 	// GetOrCreateWord gets or creates a word in the database if it does not exist.
-	GetOrCreateWord(polishWord string) (*models.Word, error)
-	ListWords() ([]models.Word, error)
-	GetWordByPolish(polishWord string) (*models.Word, error)
+	// Words are identified by the Polish word and part of speech; gender and aspect are only set on creation.
+	GetOrCreateWord(polishWord string, grammar models.Grammar) (*models.Word, error)
+	// GetOrCreateWordFolded returns all words with the same part of speech whose search key
+	// matches the folded polishWord. If there are none, it creates the word and returns it as the only element.
+	GetOrCreateWordFolded(polishWord string, grammar models.Grammar) ([]models.Word, error)
+	// ListWords returns all words in the given order that match the filter.
+	ListWords(order WordOrder, filter WordFilter) ([]models.Word, error)
+	// ListWordsPage returns a page of words without preloading their translations.
+	ListWordsPage(order WordOrder, filter WordFilter, page PageArgs) (*Page[models.Word], error)
+	// StreamWords calls fn for every word matching the filter in ID order, loading words in batches.
+	// Translations and example sentences are preloaded. Iteration stops at the first error returned by fn.
+	StreamWords(filter WordFilter, fn func(word *models.Word) error) error
+	// GetWordByPolish finds a word. If partOfSpeech is nil, the earliest created homonym is returned.
+	GetWordByPolish(polishWord string, partOfSpeech *models.PartOfSpeech) (*models.Word, error)
+	// ListWordsByFoldedPolish returns the words matching polishWord ignoring case and diacritics.
+	ListWordsByFoldedPolish(polishWord string) ([]models.Word, error)
+	// ListWordsByEnglish returns the words that have the given English translation.
+	ListWordsByEnglish(englishTranslation string) ([]models.Word, error)
+	// ListWordsByFoldedEnglish returns the words that have the given English translation, ignoring case.
+	ListWordsByFoldedEnglish(englishTranslation string) ([]models.Word, error)
+	// ListPolishHeadwords returns up to limit distinct Polish words beginning with prefix, ignoring case,
+	// in Polish alphabetical order.
+	ListPolishHeadwords(prefix string, limit int) ([]string, error)
+	// ListEnglishHeadwords returns up to limit distinct English translations beginning with prefix, ignoring case.
+	ListEnglishHeadwords(prefix string, limit int) ([]string, error)
+	// LookupForm resolves an inflected form to its headwords. It matches headwords first,
+	// then stored inflections, and falls back to rule-based suffix stripping.
+	LookupForm(form string) ([]FormMatch, error)
+	// SearchWords returns words matching the query, ranked by similarity.
+	SearchWords(query string, mode SearchMode, limit int) ([]WordSearchHit, error)
 	GetWordByID(wordID uint) (*models.Word, error)
 	UpdateWord(wordID uint, newPolishWord string) (*models.Word, error)
-	// DeleteWord deletes a word and all its translations and example sentences.
+	UpdateWordGrammar(wordID uint, grammar models.Grammar) (*models.Word, error)
+	// UpdateWordPronunciation sets the manual IPA transcription of a word, overriding the generated one.
+	// An empty string clears the override.
+	UpdateWordPronunciation(wordID uint, pronunciation string) (*models.Word, error)
+	// DeleteWord moves a word and all its translations and example sentences to the trash.
+	// Its inflections, relations and audio recordings are deleted when it is purged.
 	DeleteWord(wordID uint) error
+	// CountHiddenEntries counts the translations and example sentences of a visible word, or the example sentences
+	// of a visible translation, that are not visible in the scope.
+	CountHiddenEntries(entryType models.EntryType, entryID uint) (int64, error)
+	// MoveWordDependents moves the inflected forms, relations and audio recordings of a visible word to another
+	// visible word. Inflected forms and relations the other word already has are deleted instead,
+	// and so are relations between the two words.
+	MoveWordDependents(fromWordID uint, toWordID uint) error
 
 	// GetOrCreateTranslation gets or creates a translation in the database if it does not exist.
 	GetOrCreateTranslation(wordID uint, englishTranslation string) (*models.Translation, error)
-	ListTranslations(wordID uint) ([]models.Translation, error)
+	// ListTranslations returns the translations of a word in the given order that match the filter.
+	ListTranslations(wordID uint, order TranslationOrder, filter TranslationFilter) ([]models.Translation, error)
+	// ListTranslationsPage returns a page of translations of a word without preloading example sentences.
+	ListTranslationsPage(wordID uint, page PageArgs) (*Page[models.Translation], error)
+	// SampleTranslations returns up to limit translations of visible words, without their example sentences,
+	// in a pseudo-random order determined by the seed. withSentences leaves out translations without example sentences.
+	SampleTranslations(seed uint64, limit int, withSentences bool) ([]models.Translation, error)
 	GetTranslationByID(translationID uint) (*models.Translation, error)
 	UpdateTranslation(translationID uint, newEnglishTranslation string) (*models.Translation, error)
-	// Deletes the translation and its associated example sentences
+	// UpdateTranslationWord moves a translation with its example sentences to another visible word.
+	// Fails with ErrPrivateParent when moving a shared translation to a private word,
+	// and with ErrDuplicateEntry if the word already has an equal translation.
+	UpdateTranslationWord(translationID uint, wordID uint) (*models.Translation, error)
+	// Moves the translation and its associated example sentences to the trash
 	DeleteTranslation(translationID uint) error
 
 	// GetOrCreateExampleSentence gets or creates an example sentence in the database if it does not exist.
 	GetOrCreateExampleSentence(translationID uint, sentenceText string) (*models.ExampleSentence, error)
 	ListExampleSentences(translationID uint) ([]models.ExampleSentence, error)
+	ListExampleSentencesPage(translationID uint, page PageArgs) (*Page[models.ExampleSentence], error)
 	GetExampleSentenceByID(sentenceID uint) (*models.ExampleSentence, error)
 	UpdateExampleSentence(sentenceID uint, newSentenceText string) (*models.ExampleSentence, error)
+	// UpdateExampleSentenceTranslation moves an example sentence to another visible translation.
+	// Fails with ErrPrivateParent when moving a shared sentence to a private translation.
+	UpdateExampleSentenceTranslation(sentenceID uint, translationID uint) (*models.ExampleSentence, error)
+	// DeleteExampleSentence moves an example sentence to the trash.
 	DeleteExampleSentence(sentenceID uint) error
 
+	// GetOrCreateInflection gets or creates an inflected form of a visible word if it does not exist.
+	GetOrCreateInflection(wordID uint, form string, tags models.InflectionTags) (*models.Inflection, error)
+	// ListInflections returns the inflected forms of a visible word.
+	ListInflections(wordID uint) ([]models.Inflection, error)
+	// GetInflectionByID finds an inflected form of a visible word.
+	GetInflectionByID(inflectionID uint) (*models.Inflection, error)
+	UpdateInflection(inflectionID uint, newForm string, newTags models.InflectionTags) (*models.Inflection, error)
+	DeleteInflection(inflectionID uint) error
+
+	// GetOrCreateWordRelation gets or creates a relation between two visible words if it does not exist.
+	// Symmetric relations are found regardless of the order of the words.
+	GetOrCreateWordRelation(wordID uint, relatedWordID uint, relationType models.RelationType) (*models.WordRelation, error)
+	// ListWordRelations returns the relations a word takes part in with other visible words, on either side.
+	// If relationType is nil, relations of all types are returned.
+	ListWordRelations(wordID uint, relationType *models.RelationType) ([]models.WordRelation, error)
+	GetWordRelationByID(relationID uint) (*models.WordRelation, error)
+	// UpdateWordRelation changes the type of a relation between visible words.
+	// Fails with ErrDuplicateEntry if the words already take part in a relation of the new type.
+	UpdateWordRelation(relationID uint, newType models.RelationType) (*models.WordRelation, error)
+	// DeleteWordRelation deletes a relation between visible words.
+	DeleteWordRelation(relationID uint) error
+
+	// CreateAudioRecording adds a recording to a visible word.
+	CreateAudioRecording(wordID uint, contentType string, data []byte) (*models.AudioRecording, error)
+	// ListAudioRecordings returns the recordings of a visible word without their audio data.
+	ListAudioRecordings(wordID uint) ([]models.AudioRecording, error)
+	// GetAudioRecordingByID finds a recording of a visible word including its audio data.
+	GetAudioRecordingByID(recordingID uint) (*models.AudioRecording, error)
+	// DeleteAudioRecording deletes a recording of a visible word.
+	DeleteAudioRecording(recordingID uint) error
+
+	// GetOrCreateReviewCard adds a translation to the cards studied by the learner, starting from the given state.
+	// An existing card is returned unchanged.
+	GetOrCreateReviewCard(learner string, translationID uint, state srs.State) (*models.ReviewCard, error)
+	// GetReviewCardByID finds a card. Preloads the translation and its example sentences.
+	GetReviewCardByID(cardID uint) (*models.ReviewCard, error)
+	// RecordReview stores the state of a card after a review and logs the review.
+	RecordReview(cardID uint, grade srs.Grade, state srs.State, reviewedAt time.Time) (*models.ReviewCard, error)
+	// ListDueReviewCards returns up to limit cards of the learner that are due at the given time, most overdue first.
+	// Preloads translations and example sentences.
+	ListDueReviewCards(learner string, now time.Time, limit int) ([]models.ReviewCard, error)
+	// ListReviewLogs returns the reviews of a card, oldest first.
+	ListReviewLogs(cardID uint) ([]models.ReviewLog, error)
+	// MoveReviewCards moves the review cards of a visible translation to another visible translation.
+	// Cards of learners who already study the other translation are deleted with their reviews instead.
+	MoveReviewCards(fromTranslationID uint, toTranslationID uint) error
+
+	// CreateUser creates an account with the viewer role. It fails if the username is taken.
+	CreateUser(username string, passwordHash string) (*models.User, error)
+	GetUserByID(userID uint) (*models.User, error)
+	GetUserByUsername(username string) (*models.User, error)
+	// ListUsers returns all users ordered by username.
+	ListUsers() ([]models.User, error)
+	UpdateUserRole(userID uint, role models.Role) (*models.User, error)
+	// UpdateUserGroup sets the group a user shares private entries with. An empty group removes the user from it.
+	UpdateUserGroup(userID uint, group string) (*models.User, error)
+
+	// PromoteEntry moves a private entry, and the private word and translation it belongs to,
+	// to the shared dictionary regardless of the scope.
+	// Fails with ErrDuplicateEntry if one of them already exists in the shared dictionary.
+	PromoteEntry(entryType models.EntryType, entryID uint) error
+
+	CreateChangeProposal(proposal models.ChangeProposal) (*models.ChangeProposal, error)
+	// GetChangeProposalByID finds a proposal. Preloads the proposer and the reviewer.
+	GetChangeProposalByID(proposalID uint) (*models.ChangeProposal, error)
+	// ListChangeProposalsPage returns a page of proposals with the given status, oldest first.
+	// Preloads the proposers and reviewers.
+	ListChangeProposalsPage(status models.ProposalStatus, page PageArgs) (*Page[models.ChangeProposal], error)
+	// ReviewChangeProposal sets the status of a pending proposal to approved or rejected, with the comment of the reviewer.
+	// It does not apply the change. Fails with ErrProposalReviewed if the proposal is no longer pending.
+	ReviewChangeProposal(proposalID uint, status models.ProposalStatus, reviewerID uint, comment string, reviewedAt time.Time) (*models.ChangeProposal, error)
+
+	// ListRevisions returns the revisions of a word, translation or example sentence visible in the scope,
+	// newest first. Preloads the authors.
+	ListRevisions(entryType models.EntryType, entryID uint) ([]models.Revision, error)
+	// GetRevisionByID finds a revision of an entry visible in the scope. Preloads the author.
+	GetRevisionByID(revisionID uint) (*models.Revision, error)
+	// RevertToRevision restores an entry to the state recorded by a revision: the state after a create
+	// or update, or the state before a delete. Deleted entries are restored from the trash, purged ones
+	// are recreated together with the translations and example sentences deleted with them.
+	// The owner of existing entries is kept.
+	// The revert is recorded as a new revision, which is returned.
+	RevertToRevision(revisionID uint) (*models.Revision, error)
+
+	// ListTrash returns the deleted words, translations and example sentences visible in the scope
+	// that have not been purged yet.
+	ListTrash() (*Trash, error)
+	// RestoreWord restores a deleted word together with the translations and example sentences deleted with it.
+	// Fails with ErrDuplicateEntry if an equal word was created after the delete.
+	RestoreWord(wordID uint) (*models.Word, error)
+	// RestoreTranslation restores a deleted translation together with the example sentences deleted with it.
+	// Fails with ErrDeletedParent if its word is deleted
+	// and with ErrDuplicateEntry if an equal translation was created after the delete.
+	RestoreTranslation(translationID uint) (*models.Translation, error)
+	// RestoreExampleSentence restores a deleted example sentence.
+	// Fails with ErrDeletedParent if its translation is deleted
+	// and with ErrDuplicateEntry if an equal sentence was created after the delete.
+	RestoreExampleSentence(sentenceID uint) (*models.ExampleSentence, error)
+	// PurgeDeleted permanently deletes the entries deleted before the given time, regardless of the scope,
+	// together with the inflections, relations, audio recordings and review cards of the purged words
+	// and translations. Returns the number of purged words, translations and example sentences.
+	PurgeDeleted(deletedBefore time.Time) (int64, error)
+
 	// Transaction executes the provided function within a database transaction.
 	Transaction(fn func(repo Repository) error) error
 }
 
+// SearchMode selects how SearchWords matches the query against Polish words.
+type SearchMode int
+
+const (
+	// SearchPrefix matches words starting with the query.
+	SearchPrefix SearchMode = iota
+	// SearchSubstring matches words containing the query.
+	SearchSubstring
+	// SearchFuzzy matches words similar to the query using trigram similarity.
+	SearchFuzzy
+)
+
+// DefaultSearchLimit is used by SearchWords when no positive limit is given.
+const DefaultSearchLimit = 10
+
+// DefaultDueCardsLimit is the number of due review cards returned when no limit is given.
+const DefaultDueCardsLimit = 20
+
+// fuzzySimilarityThreshold is the minimal trigram similarity of a fuzzy match.
+// It is kept low on purpose, as short Polish words share few trigrams.
+const fuzzySimilarityThreshold = 0.1
+
+// WordSearchHit is a single SearchWords result.
+type WordSearchHit struct {
+	Word       models.Word
+	Similarity float64
+}
+
+// LookupMethod tells how LookupForm matched a form to a word.
+type LookupMethod int
+
+const (
+	// LookupHeadword means the form is the word itself.
+	LookupHeadword LookupMethod = iota
+	// LookupInflection means the form is a stored inflection of the word.
+	LookupInflection
+	// LookupSuffixRule means the word was guessed by stripping an inflectional ending.
+	LookupSuffixRule
+)
+
+// FormMatch is a single LookupForm result.
+type FormMatch struct {
+	Word   models.Word
+	Method LookupMethod
+	// Inflection is the stored inflection that matched. It is nil for other methods.
+	Inflection *models.Inflection
+	// Tags describe the grammatical form that was matched.
+	Tags models.InflectionTags
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 // =============================
 // GormRepository implementation
 // =============================
 
 type GormRepository struct {
 	DB *gorm.DB
+	// Scope selects the visible private entries and the owner of created ones.
+	Scope Scope
 }
 
-func (r *GormRepository) GetOrCreateWord(polishWord string) (*models.Word, error) {
-	word := models.Word{
-		PolishWord: polishWord,
+func (r *GormRepository) GetOrCreateWord(polishWord string, grammar models.Grammar) (*models.Word, error) {
+	var word models.Word
+	// Prefer the shared word over creating a private copy of it.
+	err := r.DB.
+		Scopes(r.reusable("words")).
+		Where("polish_word = ? AND part_of_speech = ?", polishWord, grammar.PartOfSpeech).
+		Order("owner").
+		First(&word).
+		Error
+	if err == nil {
+		return &word, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	word = models.Word{
+		PolishWord:   polishWord,
+		PartOfSpeech: grammar.PartOfSpeech,
+		Gender:       grammar.Gender,
+		Aspect:       grammar.Aspect,
+		Owner:        r.Scope.Owner,
 	}
 	// Attempt to insert. On conflict, do nothing.
-	err := r.DB.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "polish_word"}},
-		DoNothing: true,
-	}).Create(&word).Error
+	err = r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "polish_word"}, {Name: "part_of_speech"}, {Name: "owner"}},
+			TargetWhere: notDeleted,
+			DoNothing:   true,
+		}).Create(&word)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return r.recordRevision(tx, models.RevisionCreate, models.EntryWord, word.WordID, nil, snapshotWord(word))
+	})
 	if err != nil {
 		return nil, err
 	}
 	// Retrieves the word from database.
-	err = r.DB.Where("polish_word = ?", polishWord).First(&word).Error
+	err = r.DB.
+		Where("polish_word = ? AND part_of_speech = ? AND owner = ?", polishWord, grammar.PartOfSpeech, r.Scope.Owner).
+		First(&word).
+		Error
 	if err != nil {
 		return nil, err
 	}
 	return &word, nil
 }
 
-// ListWords returns a slice of all words. Preloads translations and example sentences.
-func (r *GormRepository) ListWords() ([]models.Word, error) {
-	var words []models.Word
-	err := r.DB.Preload("Translations.ExampleSentences").Find(&words).Error
+func (r *GormRepository) GetOrCreateWordFolded(polishWord string, grammar models.Grammar) ([]models.Word, error) {
+	var candidates []models.Word
+	err := r.DB.
+		Scopes(r.reusable("words")).
+		Where("search_key = ? AND part_of_speech = ?", normalize.Fold(polishWord), grammar.PartOfSpeech).
+		Order("word_id").
+		Find(&candidates).
+		Error
 	if err != nil {
 		return nil, err
 	}
-	return words, nil
-}
+	if len(candidates) > 0 {
+		return candidates, nil
+	}
 
-// GetWordByPolish finds a word. Preloads translations and example sentences.
-func (r *GormRepository) GetWordByPolish(polishWord string) (*models.Word, error) {
-	var word models.Word
+	word, err := r.GetOrCreateWord(polishWord, grammar)
+	if err != nil {
+		return nil, err
+	}
+	return []models.Word{*word}, nil
+}
 
-	err := r.DB.
-		Preload("Translations.ExampleSentences").
-		Where("polish_word = ?", polishWord).
-		First(&word).
+// ListWords returns a slice of all words matching the filter.
+// Preloads translations and example sentences.
+func (r *GormRepository) ListWords(order WordOrder, filter WordFilter) ([]models.Word, error) {
+	ks, err := r.wordKeyset(order)
+	if err != nil {
+		return nil, err
+	}
+	var words []models.Word
+	err = r.filterWords(r.DB.Model(&models.Word{}), filter).
+		Scopes(r.preloadTranslations).
+		Clauses(ks.order(false)).
+		Find(&words).
 		Error
 	if err != nil {
 		return nil, err
 	}
-	return &word, nil
+	return words, nil
 }
 
-// GetWordByID finds a word. Preloads translations and example sentences.
-func (r *GormRepository) GetWordByID(wordID uint) (*models.Word, error) {
-	var word models.Word
+// streamBatchSize is the number of words StreamWords loads at once.
+const streamBatchSize = 500
 
-	err := r.DB.
-		Preload("Translations.ExampleSentences").
-		First(&word, wordID).
+func (r *GormRepository) StreamWords(filter WordFilter, fn func(word *models.Word) error) error {
+	var words []models.Word
+	return r.filterWords(r.DB.Model(&models.Word{}), filter).
+		Preload("Translations", func(db *gorm.DB) *gorm.DB {
+			return db.Scopes(r.visible("translations")).Order("translation_id")
+		}).
+		Preload("Translations.ExampleSentences", func(db *gorm.DB) *gorm.DB {
+			return db.Scopes(r.visible("example_sentences")).Order("sentence_id")
+		}).
+		FindInBatches(&words, streamBatchSize, func(tx *gorm.DB, batch int) error {
+			for i := range words {
+				if err := fn(&words[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).
 		Error
+}
+
+// GetWordByPolish finds a word. Preloads translations and example sentences.
+func (r *GormRepository) GetWordByPolish(polishWord string, partOfSpeech *models.PartOfSpeech) (*models.Word, error) {
+	var word models.Word
+
+	stmt := r.DB.
+		Scopes(r.visible("words"), r.preloadTranslations).
+		Where("polish_word = ?", polishWord)
+	if partOfSpeech != nil {
+		stmt = stmt.Where("part_of_speech = ?", *partOfSpeech)
+	}
+	err := stmt.First(&word).Error
 	if err != nil {
 		return nil, err
 	}
 	return &word, nil
 }
 
-func (r *GormRepository) UpdateWord(wordID uint, newPolishWord string) (*models.Word, error) {
-	word, err := r.GetWordByID(wordID)
+// ListWordsPage returns a page of words in the given order.
+func (r *GormRepository) ListWordsPage(order WordOrder, filter WordFilter, page PageArgs) (*Page[models.Word], error) {
+	ks, err := r.wordKeyset(order)
 	if err != nil {
 		return nil, err
 	}
-
-	word.PolishWord = newPolishWord
-
-	if err := r.DB.Save(word).Error; err != nil {
-		return nil, err
+	base := func() *gorm.DB {
+		return r.filterWords(r.DB.Model(&models.Word{}), filter)
 	}
-	return word, nil
-}
-
-func (r *GormRepository) DeleteWord(wordID uint) error {
-	err := r.DB.Transaction(func(tx *gorm.DB) error {
-
-		if tx.Error != nil {
-			return tx.Error
-		}
-		// Find all translations of the word
-		var translations []models.Translation
-		err := tx.Where("word_id = ?", wordID).Find(&translations).Error
-		if err != nil {
-			tx.Rollback()
-			return err
-		}
-		// Delete all associated example sentences
-		for _, t := range translations {
-			err = tx.
-				Where("translation_id = ?", t.TranslationID).
-				Delete(&models.ExampleSentence{}).Error
-			if err != nil {
-				tx.Rollback()
-				return err
-			}
+	load := func(ids []uint) (map[uint]models.Word, error) {
+		var words []models.Word
+		if err := r.DB.Find(&words, ids).Error; err != nil {
+			return nil, err
 		}
-		// Delete all translations of the word
-		err = tx.Where("word_id = ?", wordID).Delete(&models.Translation{}).Error
-		if err != nil {
-			tx.Rollback()
-			return err
-		}
-		// Delete the word
-		err = tx.Delete(&models.Word{}, wordID).Error
-		if err != nil {
-			tx.Rollback()
-			return err
+		byID := make(map[uint]models.Word, len(words))
+		for _, w := range words {
+			byID[w.WordID] = w
 		}
-		return nil
-	})
-	if err != nil {
-		return err
+		return byID, nil
 	}
-	return nil
+	return fetchPage(base, ks, page, load)
 }
 
-// ListTranslations returns a slice of translations of a word.
-// Preloads example sentences.
-func (r *GormRepository) ListTranslations(wordID uint) ([]models.Translation, error) {
-	var translations []models.Translation
+// ListWordsByFoldedPolish finds all words with the same search key as polishWord.
+// Preloads translations and example sentences.
+func (r *GormRepository) ListWordsByFoldedPolish(polishWord string) ([]models.Word, error) {
+	var words []models.Word
+
 	err := r.DB.
-		Where("word_id = ?", wordID).
-		Preload("ExampleSentences").
-		Find(&translations).
+		Scopes(r.visible("words"), r.preloadTranslations).
+		Where("search_key = ?", normalize.Fold(polishWord)).
+		Order("word_id").
+		Find(&words).
 		Error
 	if err != nil {
 		return nil, err
 	}
-	return translations, nil
+	return words, nil
 }
 
-// GetTranslationByID returns a translation. Preloads example sentences.
-func (r *GormRepository) GetTranslationByID(translationID uint) (*models.Translation, error) {
-	var translation models.Translation
+// ListWordsByEnglish finds all words translated as the given English word.
+// Preloads translations and example sentences.
+func (r *GormRepository) ListWordsByEnglish(englishTranslation string) ([]models.Word, error) {
+	var words []models.Word
+
 	err := r.DB.
-		Preload("ExampleSentences").
-		First(&translation, translationID).
+		Scopes(r.visible("words"), r.preloadTranslations).
+		Where("word_id IN (?)", r.DB.
+			Model(&models.Translation{}).
+			Scopes(r.visible("translations")).
+			Select("word_id").
+			Where("english_translation = ?", englishTranslation)).
+		Find(&words).
 		Error
 	if err != nil {
 		return nil, err
 	}
-	return &translation, nil
+	return words, nil
 }
 
-func (r *GormRepository) GetOrCreateTranslation(wordID uint, englishTranslation string) (*models.Translation, error) {
-	translation := models.Translation{
-		WordID:             wordID,
-		EnglishTranslation: englishTranslation,
-	}
-	// Attempt to insert. On conflict, do nothing.
-	err := r.DB.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "word_id"}, {Name: "english_translation"}},
-		DoNothing: true,
-	}).Create(&translation).Error
+func (r *GormRepository) ListWordsByFoldedEnglish(englishTranslation string) ([]models.Word, error) {
+	var words []models.Word
+
+	err := r.DB.
+		Scopes(r.visible("words"), r.preloadTranslations).
+		Where("word_id IN (?)", r.DB.
+			Model(&models.Translation{}).
+			Scopes(r.visible("translations")).
+			Select("word_id").
+			Where("LOWER(english_translation) = LOWER(?)", englishTranslation)).
+		Find(&words).
+		Error
 	if err != nil {
 		return nil, err
 	}
-	// Retrieve the translation from database.
-	err = r.DB.
-		Where("word_id = ? AND english_translation = ?", wordID, englishTranslation).
-		First(&translation).
+	return words, nil
+}
+
+func (r *GormRepository) ListPolishHeadwords(prefix string, limit int) ([]string, error) {
+	var headwords []string
+
+	err := r.DB.
+		Model(&models.Word{}).
+		Scopes(r.visible("words")).
+		Where("polish_word ILIKE ?", likeEscaper.Replace(prefix)+"%").
+		Group("polish_word").
+		Order("polish_word COLLATE polish").
+		Limit(limit).
+		Pluck("polish_word", &headwords).
 		Error
 	if err != nil {
 		return nil, err
 	}
-	return &translation, nil
+	return headwords, nil
 }
 
-func (r *GormRepository) UpdateTranslation(translationID uint, newEnglishTranslation string) (*models.Translation, error) {
-	translation, err := r.GetTranslationByID(translationID)
+func (r *GormRepository) ListEnglishHeadwords(prefix string, limit int) ([]string, error) {
+	var headwords []string
+
+	err := r.DB.
+		Model(&models.Translation{}).
+		Scopes(r.visible("translations")).
+		Where("english_translation ILIKE ?", likeEscaper.Replace(prefix)+"%").
+		Group("english_translation").
+		Order("english_translation").
+		Limit(limit).
+		Pluck("english_translation", &headwords).
+		Error
 	if err != nil {
 		return nil, err
 	}
+	return headwords, nil
+}
 
-	translation.EnglishTranslation = newEnglishTranslation
+func (r *GormRepository) LookupForm(form string) ([]FormMatch, error) {
+	variants := []string{form}
+	if lower := strings.ToLower(form); lower != form {
+		variants = append(variants, lower)
+	}
+	matches := []FormMatch{}
 
-	if err := r.DB.Save(translation).Error; err != nil {
+	headwords, err := r.listWordsByPolishWords(variants)
+	if err != nil {
 		return nil, err
 	}
-	return translation, nil
-}
+	for _, w := range headwords {
+		matches = append(matches, FormMatch{Word: w, Method: LookupHeadword})
+	}
 
-func (r *GormRepository) DeleteTranslation(translationID uint) error {
-	err := r.DB.Transaction(func(tx *gorm.DB) error {
-		// Delete all associated example sentences
-		err := tx.
-			Where("translation_id = ?", translationID).
-			Delete(&models.ExampleSentence{}).
+	var inflections []models.Inflection
+	err = r.DB.
+		Where("form IN ?", variants).
+		Order("inflection_id").
+		Find(&inflections).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	if len(inflections) > 0 {
+		wordIDs := make([]uint, len(inflections))
+		for i, inflection := range inflections {
+			wordIDs[i] = inflection.WordID
+		}
+		var words []models.Word
+		err = r.DB.
+			Scopes(r.visible("words"), r.preloadTranslations).
+			Find(&words, wordIDs).
 			Error
 		if err != nil {
-			return err
+			return nil, err
 		}
-		// Delete the translation
-		if err := tx.Delete(&models.Translation{}, translationID).Error; err != nil {
-			return err
+		byID := make(map[uint]models.Word, len(words))
+		for _, w := range words {
+			byID[w.WordID] = w
 		}
-		return nil
-	})
+		for _, inflection := range inflections {
+			word, ok := byID[inflection.WordID]
+			if !ok {
+				continue
+			}
+			matches = append(matches, FormMatch{
+				Word:       word,
+				Method:     LookupInflection,
+				Inflection: &inflection,
+				Tags:       inflection.Tags(),
+			})
+		}
+	}
+	if len(matches) > 0 {
+		return matches, nil
+	}
+
+	// Nothing is stored for this form, guess the headword from its ending.
+	candidates := lemmatize.Candidates(form)
+	if len(candidates) == 0 {
+		return matches, nil
+	}
+	lemmas := make([]string, len(candidates))
+	for i, c := range candidates {
+		lemmas[i] = c.Lemma
+	}
+	words, err := r.listWordsByPolishWords(lemmas)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	// Keep the order of candidates, which are sorted from the most specific rule.
+	for _, c := range candidates {
+		for _, w := range words {
+			if w.PolishWord == c.Lemma {
+				matches = append(matches, FormMatch{Word: w, Method: LookupSuffixRule, Tags: c.Tags})
+			}
+		}
+	}
+	return matches, nil
 }
 
-func (r *GormRepository) ListExampleSentences(translationID uint) ([]models.ExampleSentence, error) {
-	var sentences []models.ExampleSentence
+// listWordsByPolishWords finds all words with any of the given Polish words ordered by ID.
+// Preloads translations and example sentences.
+func (r *GormRepository) listWordsByPolishWords(polishWords []string) ([]models.Word, error) {
+	var words []models.Word
 	err := r.DB.
-		Where("translation_id = ?", translationID).
-		Find(&sentences).
+		Scopes(r.visible("words"), r.preloadTranslations).
+		Where("polish_word IN ?", polishWords).
+		Order("word_id").
+		Find(&words).
 		Error
 	if err != nil {
 		return nil, err
 	}
-	return sentences, nil
+	return words, nil
 }
 
-func (r *GormRepository) GetExampleSentenceByID(sentenceID uint) (*models.ExampleSentence, error) {
-	var sentence models.ExampleSentence
+// SearchWords finds words matching the query. Results are ordered by descending similarity.
+// Preloads translations and example sentences.
+func (r *GormRepository) SearchWords(query string, mode SearchMode, limit int) ([]WordSearchHit, error) {
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
 
-	if err := r.DB.First(&sentence, sentenceID).Error; err != nil {
-		return nil, err
+	stmt := r.DB.
+		Model(&models.Word{}).
+		Scopes(r.visible("words")).
+		Select("word_id, similarity(polish_word, ?) AS similarity", query)
+
+	escaped := likeEscaper.Replace(query)
+	switch mode {
+	case SearchPrefix:
+		stmt = stmt.Where("polish_word ILIKE ?", escaped+"%")
+	case SearchSubstring:
+		stmt = stmt.Where("polish_word ILIKE ?", "%"+escaped+"%")
+	case SearchFuzzy:
+		stmt = stmt.Where("similarity(polish_word, ?) >= ?", query, fuzzySimilarityThreshold)
+	default:
+		return nil, fmt.Errorf("unknown search mode: %d", mode)
 	}
-	return &sentence, nil
-}
 
-func (r *GormRepository) GetOrCreateExampleSentence(translationID uint, sentenceText string) (*models.ExampleSentence, error) {
-	sentence := models.ExampleSentence{
-		TranslationID: translationID,
-		SentenceText:  sentenceText,
+	var ranked []struct {
+		WordID     uint
+		Similarity float64
 	}
-	// Attempt to insert. On conflict, do nothing.
-	err := r.DB.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "translation_id"}, {Name: "sentence_text"}},
-		DoNothing: true,
-	}).Create(&sentence).Error
+	err := stmt.
+		Order("similarity DESC").
+		Order("polish_word").
+		Limit(limit).
+		Scan(&ranked).
+		Error
 	if err != nil {
 		return nil, err
 	}
-	// Retrieves the sentence from database.
+	if len(ranked) == 0 {
+		return []WordSearchHit{}, nil
+	}
+
+	ids := make([]uint, len(ranked))
+	for i, hit := range ranked {
+		ids[i] = hit.WordID
+	}
+	var words []models.Word
 	err = r.DB.
-		Where("translation_id = ? AND sentence_text = ?", translationID, sentenceText).
-		First(&sentence).
+		Scopes(r.preloadTranslations).
+		Find(&words, ids).
 		Error
 	if err != nil {
 		return nil, err
 	}
-	return &sentence, nil
+	byID := make(map[uint]models.Word, len(words))
+	for _, w := range words {
+		byID[w.WordID] = w
+	}
+
+	// Restore the ranking order, skipping words deleted in the meantime.
+	hits := make([]WordSearchHit, 0, len(ranked))
+	for _, hit := range ranked {
+		word, ok := byID[hit.WordID]
+		if !ok {
+			continue
+		}
+		hits = append(hits, WordSearchHit{Word: word, Similarity: hit.Similarity})
+	}
+	return hits, nil
 }
 
-func (r *GormRepository) UpdateExampleSentence(sentenceID uint, newSentenceText string) (*models.ExampleSentence, error) {
-	sentence, err := r.GetExampleSentenceByID(sentenceID)
+// GetWordByID finds a word. Preloads translations and example sentences.
+func (r *GormRepository) GetWordByID(wordID uint) (*models.Word, error) {
+	var word models.Word
+
+	err := r.DB.
+		Scopes(r.visible("words"), r.preloadTranslations).
+		First(&word, wordID).
+		Error
 	if err != nil {
 		return nil, err
 	}
+	return &word, nil
+}
 
-	sentence.SentenceText = newSentenceText
+func (r *GormRepository) UpdateWord(wordID uint, newPolishWord string) (*models.Word, error) {
+	return r.updateWord(wordID, func(word *models.Word) {
+		word.PolishWord = newPolishWord
+	})
+}
+
+// UpdateWordGrammar replaces the grammatical metadata of a word.
+func (r *GormRepository) UpdateWordGrammar(wordID uint, grammar models.Grammar) (*models.Word, error) {
+	return r.updateWord(wordID, func(word *models.Word) {
+		word.PartOfSpeech = grammar.PartOfSpeech
+		word.Gender = grammar.Gender
+		word.Aspect = grammar.Aspect
+	})
+}
+
+// UpdateWordPronunciation replaces the manual IPA transcription of a word.
+func (r *GormRepository) UpdateWordPronunciation(wordID uint, pronunciation string) (*models.Word, error) {
+	return r.updateWord(wordID, func(word *models.Word) {
+		word.PronunciationOverride = pronunciation
+	})
+}
 
-	if err := r.DB.Save(sentence).Error; err != nil {
+// updateWord applies the change to a visible word, saves it and records the revision.
+func (r *GormRepository) updateWord(wordID uint, change func(word *models.Word)) (*models.Word, error) {
+	word, err := r.GetWordByID(wordID)
+	if err != nil {
 		return nil, err
 	}
-	return sentence, nil
-}
 
-func (r *GormRepository) DeleteExampleSentence(sentenceID uint) error {
-	if err := r.DB.Delete(&models.ExampleSentence{}, sentenceID).Error; err != nil {
-		return err
+	before := snapshotWord(*word)
+	change(word)
+
+	err = r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(word).Error; err != nil {
+			return err
+		}
+		return r.recordRevision(tx, models.RevisionUpdate, models.EntryWord, wordID, before, snapshotWord(*word))
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return word, nil
 }
 
-// Transaction executes the provided function within a database transaction.
-func (r *GormRepository) Transaction(fn func(repo Repository) error) error {
-	return r.DB.Transaction(func(tx *gorm.DB) error {
-		txRepo := &GormRepository{DB: tx}
-		return fn(txRepo)
-	})
+func (r *GormRepository) DeleteWord(wordID uint) error {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+
+		if tx.Error != nil {
+			return tx.Error
+		}
+		// Only visible words can be deleted
+		var word models.Word
+		err := tx.Scopes(r.visible("words")).Preload("Translations.ExampleSentences").First(&word, wordID).Error
+		if err != nil {
+			return err
+		}
+		// Keep the deleted translations and example sentences, so that reverting restores them
+		err = r.recordRevision(tx, models.RevisionDelete, models.EntryWord, wordID, &models.EntrySnapshot{Word: &word}, nil)
+		if err != nil {
+			return err
+		}
+		if err := r.recordDeletedTranslations(tx, word.Translations); err != nil {
+			return err
+		}
+		deletedAt := time.Now()
+		// Delete all associated example sentences
+		translations := tx.Model(&models.Translation{}).Select("translation_id").Where("word_id = ?", wordID)
+		err = tx.
+			Model(&models.ExampleSentence{}).
+			Where("translation_id IN (?)", translations).
+			UpdateColumn("deleted_at", deletedAt).
+			Error
+		if err != nil {
+			return err
+		}
+		// Delete all translations of the word
+		err = tx.Model(&models.Translation{}).Where("word_id = ?", wordID).UpdateColumn("deleted_at", deletedAt).Error
+		if err != nil {
+			return err
+		}
+		// Delete the word. Its inflections, relations and audio recordings are kept until it is purged.
+		return tx.Model(&word).UpdateColumn("deleted_at", deletedAt).Error
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *GormRepository) MoveWordDependents(fromWordID uint, toWordID uint) error {
+	if fromWordID == toWordID {
+		return fmt.Errorf("cannot move the dependents of a word to itself")
+	}
+	if err := r.checkVisibleWords(fromWordID, toWordID); err != nil {
+		return err
+	}
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		// Delete the inflected forms the other word already has, then move the rest
+		err := tx.
+			Where("word_id = ?", fromWordID).
+			Where("EXISTS (SELECT 1 FROM inflections i WHERE i.word_id = ? AND i.form = inflections.form "+
+				"AND i.grammatical_case = inflections.grammatical_case AND i.number = inflections.number "+
+				"AND i.person = inflections.person AND i.tense = inflections.tense AND i.gender = inflections.gender)", toWordID).
+			Delete(&models.Inflection{}).
+			Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&models.Inflection{}).Where("word_id = ?", fromWordID).UpdateColumn("word_id", toWordID).Error
+		if err != nil {
+			return err
+		}
+
+		// Delete the relations between the two words, which would relate the other word to itself
+		err = tx.
+			Where("(word_id = ? AND related_word_id = ?) OR (word_id = ? AND related_word_id = ?)", fromWordID, toWordID, toWordID, fromWordID).
+			Delete(&models.WordRelation{}).
+			Error
+		if err != nil {
+			return err
+		}
+		var relations []models.WordRelation
+		if err := tx.Where("word_id = ? OR related_word_id = ?", fromWordID, fromWordID).Find(&relations).Error; err != nil {
+			return err
+		}
+		for _, relation := range relations {
+			if relation.WordID == fromWordID {
+				relation.WordID = toWordID
+			} else {
+				relation.RelatedWordID = toWordID
+			}
+			relation.Normalize()
+			var existing int64
+			err := tx.
+				Model(&models.WordRelation{}).
+				Where(&models.WordRelation{WordID: relation.WordID, RelatedWordID: relation.RelatedWordID, Type: relation.Type}).
+				Count(&existing).
+				Error
+			if err != nil {
+				return err
+			}
+			if existing > 0 {
+				err = tx.Delete(&models.WordRelation{}, relation.RelationID).Error
+			} else {
+				err = tx.
+					Model(&models.WordRelation{}).
+					Where("relation_id = ?", relation.RelationID).
+					UpdateColumns(map[string]any{"word_id": relation.WordID, "related_word_id": relation.RelatedWordID}).
+					Error
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.AudioRecording{}).Where("word_id = ?", fromWordID).UpdateColumn("word_id", toWordID).Error
+	})
+}
+
+// ListTranslations returns a slice of translations of a word matching the filter.
+// Preloads example sentences.
+func (r *GormRepository) ListTranslations(wordID uint, order TranslationOrder, filter TranslationFilter) ([]models.Translation, error) {
+	ks, err := r.translationKeyset(order)
+	if err != nil {
+		return nil, err
+	}
+	var translations []models.Translation
+	err = r.filterTranslations(r.DB.Model(&models.Translation{}), filter).
+		Scopes(r.visible("translations")).
+		Where("translations.word_id = ?", wordID).
+		Preload("ExampleSentences", r.visible("example_sentences")).
+		Clauses(ks.order(false)).
+		Find(&translations).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// ListTranslationsPage returns a page of translations of a word ordered by ID.
+func (r *GormRepository) ListTranslationsPage(wordID uint, page PageArgs) (*Page[models.Translation], error) {
+	ks := keyset{
+		sortExpr: "translations.translation_id",
+		sortType: "bigint",
+		idColumn: "translations.translation_id",
+	}
+	base := func() *gorm.DB {
+		return r.DB.Model(&models.Translation{}).Scopes(r.visible("translations")).Where("translations.word_id = ?", wordID)
+	}
+	load := func(ids []uint) (map[uint]models.Translation, error) {
+		var translations []models.Translation
+		if err := r.DB.Find(&translations, ids).Error; err != nil {
+			return nil, err
+		}
+		byID := make(map[uint]models.Translation, len(translations))
+		for _, t := range translations {
+			byID[t.TranslationID] = t
+		}
+		return byID, nil
+	}
+	return fetchPage(base, ks, page, load)
+}
+
+func (r *GormRepository) SampleTranslations(seed uint64, limit int, withSentences bool) ([]models.Translation, error) {
+	var translations []models.Translation
+
+	stmt := r.DB.
+		Scopes(r.visible("translations")).
+		Where("translations.word_id IN (?)", r.DB.Model(&models.Word{}).Scopes(r.visible("words")).Select("word_id"))
+	if withSentences {
+		stmt = stmt.Where(
+			"EXISTS (SELECT 1 FROM example_sentences s WHERE s.translation_id = translations.translation_id "+
+				"AND s.owner IN ? AND s.deleted_at IS NULL)",
+			r.Scope.owners(),
+		)
+	}
+	// Hashing the IDs with the seed orders the translations the same way for the same seed.
+	err := stmt.
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "md5(?::text || ':' || translations.translation_id)",
+			Vars:               []any{strconv.FormatUint(seed, 10)},
+			WithoutParentheses: true,
+		}}).
+		Limit(limit).
+		Find(&translations).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// GetTranslationByID returns a translation. Preloads example sentences.
+func (r *GormRepository) GetTranslationByID(translationID uint) (*models.Translation, error) {
+	var translation models.Translation
+	err := r.DB.
+		Scopes(r.visible("translations")).
+		Preload("ExampleSentences", r.visible("example_sentences")).
+		First(&translation, translationID).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+func (r *GormRepository) GetOrCreateTranslation(wordID uint, englishTranslation string) (*models.Translation, error) {
+	var translation models.Translation
+	// Prefer the shared translation over creating a private copy of it.
+	err := r.DB.
+		Scopes(r.reusable("translations")).
+		Where("word_id = ? AND english_translation = ?", wordID, englishTranslation).
+		Order("owner").
+		First(&translation).
+		Error
+	if err == nil {
+		return &translation, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err := r.checkParent(&models.Word{}, "words", "word_id", wordID); err != nil {
+		return nil, err
+	}
+
+	translation = models.Translation{
+		WordID:             wordID,
+		EnglishTranslation: englishTranslation,
+		Owner:              r.Scope.Owner,
+	}
+	// Attempt to insert. On conflict, do nothing.
+	err = r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "word_id"}, {Name: "english_translation"}, {Name: "owner"}},
+			TargetWhere: notDeleted,
+			DoNothing:   true,
+		}).Create(&translation)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return r.recordRevision(tx, models.RevisionCreate, models.EntryTranslation, translation.TranslationID, nil, snapshotTranslation(translation))
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Retrieve the translation from database.
+	err = r.DB.
+		Where("word_id = ? AND english_translation = ? AND owner = ?", wordID, englishTranslation, r.Scope.Owner).
+		First(&translation).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+func (r *GormRepository) UpdateTranslation(translationID uint, newEnglishTranslation string) (*models.Translation, error) {
+	translation, err := r.GetTranslationByID(translationID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := snapshotTranslation(*translation)
+	translation.EnglishTranslation = newEnglishTranslation
+
+	err = r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(translation).Error; err != nil {
+			return err
+		}
+		return r.recordRevision(tx, models.RevisionUpdate, models.EntryTranslation, translationID, before, snapshotTranslation(*translation))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+func (r *GormRepository) UpdateTranslationWord(translationID uint, wordID uint) (*models.Translation, error) {
+	translation, err := r.GetTranslationByID(translationID)
+	if err != nil {
+		return nil, err
+	}
+	var word models.Word
+	if err := r.DB.Scopes(r.visible("words")).First(&word, wordID).Error; err != nil {
+		return nil, err
+	}
+	if translation.Owner.Shared() && !word.Owner.Shared() {
+		return nil, ErrPrivateParent
+	}
+
+	before := snapshotTranslation(*translation)
+	translation.WordID = wordID
+
+	err = r.DB.Transaction(func(tx *gorm.DB) error {
+		err := checkDuplicate(tx, &models.Translation{}, map[string]any{
+			"word_id":             wordID,
+			"english_translation": translation.EnglishTranslation,
+			"owner":               translation.Owner,
+		})
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(translation).UpdateColumn("word_id", wordID).Error; err != nil {
+			return err
+		}
+		return r.recordRevision(tx, models.RevisionUpdate, models.EntryTranslation, translationID, before, snapshotTranslation(*translation))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+func (r *GormRepository) DeleteTranslation(translationID uint) error {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		// Only visible translations can be deleted
+		var translation models.Translation
+		err := tx.Scopes(r.visible("translations")).Preload("ExampleSentences").First(&translation, translationID).Error
+		if err != nil {
+			return err
+		}
+		// Keep the deleted example sentences, so that reverting restores them
+		err = r.recordRevision(tx, models.RevisionDelete, models.EntryTranslation, translationID, &models.EntrySnapshot{Translation: &translation}, nil)
+		if err != nil {
+			return err
+		}
+		if err := r.recordDeletedExampleSentences(tx, translation.ExampleSentences); err != nil {
+			return err
+		}
+		deletedAt := time.Now()
+		// Delete all associated example sentences
+		err = tx.
+			Model(&models.ExampleSentence{}).
+			Where("translation_id = ?", translationID).
+			UpdateColumn("deleted_at", deletedAt).
+			Error
+		if err != nil {
+			return err
+		}
+		// Delete the translation. Its review cards are kept until it is purged.
+		return tx.Model(&translation).UpdateColumn("deleted_at", deletedAt).Error
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *GormRepository) ListExampleSentences(translationID uint) ([]models.ExampleSentence, error) {
+	var sentences []models.ExampleSentence
+	err := r.DB.
+		Scopes(r.visible("example_sentences")).
+		Where("translation_id = ?", translationID).
+		Find(&sentences).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return sentences, nil
+}
+
+// ListExampleSentencesPage returns a page of example sentences of a translation ordered by ID.
+func (r *GormRepository) ListExampleSentencesPage(translationID uint, page PageArgs) (*Page[models.ExampleSentence], error) {
+	ks := keyset{
+		sortExpr: "example_sentences.sentence_id",
+		sortType: "bigint",
+		idColumn: "example_sentences.sentence_id",
+	}
+	base := func() *gorm.DB {
+		return r.DB.
+			Model(&models.ExampleSentence{}).
+			Scopes(r.visible("example_sentences")).
+			Where("example_sentences.translation_id = ?", translationID)
+	}
+	load := func(ids []uint) (map[uint]models.ExampleSentence, error) {
+		var sentences []models.ExampleSentence
+		if err := r.DB.Find(&sentences, ids).Error; err != nil {
+			return nil, err
+		}
+		byID := make(map[uint]models.ExampleSentence, len(sentences))
+		for _, s := range sentences {
+			byID[s.SentenceID] = s
+		}
+		return byID, nil
+	}
+	return fetchPage(base, ks, page, load)
+}
+
+func (r *GormRepository) GetExampleSentenceByID(sentenceID uint) (*models.ExampleSentence, error) {
+	var sentence models.ExampleSentence
+
+	if err := r.DB.Scopes(r.visible("example_sentences")).First(&sentence, sentenceID).Error; err != nil {
+		return nil, err
+	}
+	return &sentence, nil
+}
+
+func (r *GormRepository) GetOrCreateExampleSentence(translationID uint, sentenceText string) (*models.ExampleSentence, error) {
+	var sentence models.ExampleSentence
+	// Prefer the shared sentence over creating a private copy of it.
+	err := r.DB.
+		Scopes(r.reusable("example_sentences")).
+		Where("translation_id = ? AND sentence_text = ?", translationID, sentenceText).
+		Order("owner").
+		First(&sentence).
+		Error
+	if err == nil {
+		return &sentence, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err := r.checkParent(&models.Translation{}, "translations", "translation_id", translationID); err != nil {
+		return nil, err
+	}
+
+	sentence = models.ExampleSentence{
+		TranslationID: translationID,
+		SentenceText:  sentenceText,
+		Owner:         r.Scope.Owner,
+	}
+	// Attempt to insert. On conflict, do nothing.
+	err = r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "translation_id"}, {Name: "sentence_text"}, {Name: "owner"}},
+			TargetWhere: notDeleted,
+			DoNothing:   true,
+		}).Create(&sentence)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return r.recordRevision(tx, models.RevisionCreate, models.EntryExampleSentence, sentence.SentenceID, nil, snapshotExampleSentence(sentence))
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Retrieves the sentence from database.
+	err = r.DB.
+		Where("translation_id = ? AND sentence_text = ? AND owner = ?", translationID, sentenceText, r.Scope.Owner).
+		First(&sentence).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &sentence, nil
+}
+
+func (r *GormRepository) UpdateExampleSentence(sentenceID uint, newSentenceText string) (*models.ExampleSentence, error) {
+	sentence, err := r.GetExampleSentenceByID(sentenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := snapshotExampleSentence(*sentence)
+	sentence.SentenceText = newSentenceText
+
+	err = r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(sentence).Error; err != nil {
+			return err
+		}
+		return r.recordRevision(tx, models.RevisionUpdate, models.EntryExampleSentence, sentenceID, before, snapshotExampleSentence(*sentence))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sentence, nil
+}
+
+func (r *GormRepository) UpdateExampleSentenceTranslation(sentenceID uint, translationID uint) (*models.ExampleSentence, error) {
+	sentence, err := r.GetExampleSentenceByID(sentenceID)
+	if err != nil {
+		return nil, err
+	}
+	var translation models.Translation
+	if err := r.DB.Scopes(r.visible("translations")).First(&translation, translationID).Error; err != nil {
+		return nil, err
+	}
+	if sentence.Owner.Shared() && !translation.Owner.Shared() {
+		return nil, ErrPrivateParent
+	}
+
+	before := snapshotExampleSentence(*sentence)
+	sentence.TranslationID = translationID
+
+	err = r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(sentence).UpdateColumn("translation_id", translationID).Error; err != nil {
+			return err
+		}
+		return r.recordRevision(tx, models.RevisionUpdate, models.EntryExampleSentence, sentenceID, before, snapshotExampleSentence(*sentence))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sentence, nil
+}
+
+func (r *GormRepository) DeleteExampleSentence(sentenceID uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		// Only visible sentences can be deleted
+		var sentence models.ExampleSentence
+		if err := tx.Scopes(r.visible("example_sentences")).First(&sentence, sentenceID).Error; err != nil {
+			return err
+		}
+		err := r.recordRevision(tx, models.RevisionDelete, models.EntryExampleSentence, sentenceID, snapshotExampleSentence(sentence), nil)
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&models.ExampleSentence{}, sentenceID).Error
+	})
+}
+
+func (r *GormRepository) GetOrCreateInflection(wordID uint, form string, tags models.InflectionTags) (*models.Inflection, error) {
+	if err := r.checkVisibleWords(wordID); err != nil {
+		return nil, err
+	}
+	inflection := models.Inflection{
+		WordID: wordID,
+		Form:   form,
+	}
+	inflection.SetTags(tags)
+	// Attempt to insert. On conflict, do nothing.
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "word_id"}, {Name: "form"}, {Name: "grammatical_case"}, {Name: "number"},
+			{Name: "person"}, {Name: "tense"}, {Name: "gender"},
+		},
+		DoNothing: true,
+	}).Create(&inflection).Error
+	if err != nil {
+		return nil, err
+	}
+	// Retrieves the inflection from database.
+	err = r.DB.
+		Where(&models.Inflection{WordID: wordID, Form: form}).
+		Where(map[string]any{
+			"grammatical_case": tags.Case,
+			"number":           tags.Number,
+			"person":           tags.Person,
+			"tense":            tags.Tense,
+			"gender":           tags.Gender,
+		}).
+		First(&inflection).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &inflection, nil
+}
+
+// ListInflections returns all inflected forms of a word ordered by ID.
+func (r *GormRepository) ListInflections(wordID uint) ([]models.Inflection, error) {
+	var inflections []models.Inflection
+	err := r.DB.
+		Scopes(r.ofVisibleWords("word_id")).
+		Where("word_id = ?", wordID).
+		Order("inflection_id").
+		Find(&inflections).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return inflections, nil
+}
+
+func (r *GormRepository) GetInflectionByID(inflectionID uint) (*models.Inflection, error) {
+	var inflection models.Inflection
+
+	err := r.DB.Scopes(r.ofVisibleWords("word_id")).First(&inflection, inflectionID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &inflection, nil
+}
+
+func (r *GormRepository) UpdateInflection(inflectionID uint, newForm string, newTags models.InflectionTags) (*models.Inflection, error) {
+	inflection, err := r.GetInflectionByID(inflectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	inflection.Form = newForm
+	inflection.SetTags(newTags)
+
+	if err := r.DB.Save(inflection).Error; err != nil {
+		return nil, err
+	}
+	return inflection, nil
+}
+
+func (r *GormRepository) DeleteInflection(inflectionID uint) error {
+	// Only inflected forms of visible words can be deleted
+	inflection, err := r.GetInflectionByID(inflectionID)
+	if err != nil {
+		return err
+	}
+	if err := r.DB.Delete(inflection).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *GormRepository) GetOrCreateWordRelation(wordID uint, relatedWordID uint, relationType models.RelationType) (*models.WordRelation, error) {
+	if wordID == relatedWordID {
+		return nil, fmt.Errorf("a word cannot be related to itself")
+	}
+	if err := r.checkVisibleWords(wordID, relatedWordID); err != nil {
+		return nil, err
+	}
+	relation := models.WordRelation{
+		WordID:        wordID,
+		RelatedWordID: relatedWordID,
+		Type:          relationType,
+	}
+	relation.Normalize()
+	// Attempt to insert. On conflict, do nothing.
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "word_id"}, {Name: "related_word_id"}, {Name: "type"}},
+		DoNothing: true,
+	}).Omit("Word", "RelatedWord").Create(&relation).Error
+	if err != nil {
+		return nil, err
+	}
+	// Retrieves the relation from database.
+	err = r.DB.
+		Preload("Word").
+		Preload("RelatedWord").
+		Where(&models.WordRelation{
+			WordID:        relation.WordID,
+			RelatedWordID: relation.RelatedWordID,
+			Type:          relation.Type,
+		}).
+		First(&relation).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &relation, nil
+}
+
+// ListWordRelations returns the relations of a word ordered by ID. Preloads both related words.
+func (r *GormRepository) ListWordRelations(wordID uint, relationType *models.RelationType) ([]models.WordRelation, error) {
+	stmt := r.DB.
+		Preload("Word").
+		Preload("RelatedWord").
+		Scopes(r.visibleRelations).
+		Where("word_id = ? OR related_word_id = ?", wordID, wordID)
+	if relationType != nil {
+		stmt = stmt.Where("type = ?", *relationType)
+	}
+
+	var relations []models.WordRelation
+	if err := stmt.Order("relation_id").Find(&relations).Error; err != nil {
+		return nil, err
+	}
+	return relations, nil
+}
+
+// GetWordRelationByID finds a relation. Preloads both related words.
+func (r *GormRepository) GetWordRelationByID(relationID uint) (*models.WordRelation, error) {
+	var relation models.WordRelation
+
+	err := r.DB.
+		Preload("Word").
+		Preload("RelatedWord").
+		Scopes(r.visibleRelations).
+		First(&relation, relationID).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &relation, nil
+}
+
+// UpdateWordRelation changes the type of a relation.
+// Changing to a symmetric type may swap the words of the relation.
+func (r *GormRepository) UpdateWordRelation(relationID uint, newType models.RelationType) (*models.WordRelation, error) {
+	relation, err := r.GetWordRelationByID(relationID)
+	if err != nil {
+		return nil, err
+	}
+
+	relation.Type = newType
+	if relation.Type.Symmetric() && relation.WordID > relation.RelatedWordID {
+		relation.Normalize()
+		relation.Word, relation.RelatedWord = relation.RelatedWord, relation.Word
+	}
+	// Another relation may already have the new type
+	err = checkDuplicate(r.DB.Where("relation_id <> ?", relationID), &models.WordRelation{}, map[string]any{
+		"word_id":         relation.WordID,
+		"related_word_id": relation.RelatedWordID,
+		"type":            relation.Type,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.DB.
+		Model(relation).
+		Select("WordID", "RelatedWordID", "Type").
+		Updates(relation).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return relation, nil
+}
+
+func (r *GormRepository) DeleteWordRelation(relationID uint) error {
+	// Only relations between visible words can be deleted
+	relation, err := r.GetWordRelationByID(relationID)
+	if err != nil {
+		return err
+	}
+	if err := r.DB.Delete(&models.WordRelation{}, relation.RelationID).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *GormRepository) CreateAudioRecording(wordID uint, contentType string, data []byte) (*models.AudioRecording, error) {
+	if err := r.checkVisibleWords(wordID); err != nil {
+		return nil, err
+	}
+	recording := models.AudioRecording{
+		WordID:      wordID,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Data:        data,
+	}
+	if err := r.DB.Create(&recording).Error; err != nil {
+		return nil, err
+	}
+	return &recording, nil
+}
+
+// ListAudioRecordings returns the recordings of a word ordered by ID, leaving out the audio data.
+func (r *GormRepository) ListAudioRecordings(wordID uint) ([]models.AudioRecording, error) {
+	var recordings []models.AudioRecording
+	err := r.DB.
+		Omit("data").
+		Scopes(r.ofVisibleWords("word_id")).
+		Where("word_id = ?", wordID).
+		Order("recording_id").
+		Find(&recordings).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return recordings, nil
+}
+
+func (r *GormRepository) GetAudioRecordingByID(recordingID uint) (*models.AudioRecording, error) {
+	var recording models.AudioRecording
+
+	err := r.DB.Scopes(r.ofVisibleWords("word_id")).First(&recording, recordingID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &recording, nil
+}
+
+func (r *GormRepository) DeleteAudioRecording(recordingID uint) error {
+	// Only recordings of visible words can be deleted
+	result := r.DB.Scopes(r.ofVisibleWords("word_id")).Delete(&models.AudioRecording{}, recordingID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Transaction executes the provided function within a database transaction.
+func (r *GormRepository) Transaction(fn func(repo Repository) error) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		txRepo := &GormRepository{DB: tx, Scope: r.Scope}
+		return fn(txRepo)
+	})
+}
+
+// deleteReviewCards deletes the review cards of the given translations together with their review logs.
+// translationIDs is a slice of IDs or a subquery selecting them.
+func deleteReviewCards(tx *gorm.DB, translationIDs any) error {
+	cards := tx.Model(&models.ReviewCard{}).Select("card_id").Where("translation_id IN (?)", translationIDs)
+	if err := tx.Where("card_id IN (?)", cards).Delete(&models.ReviewLog{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("translation_id IN (?)", translationIDs).Delete(&models.ReviewCard{}).Error
+}
+
+func (r *GormRepository) GetOrCreateReviewCard(learner string, translationID uint, state srs.State) (*models.ReviewCard, error) {
+	card := models.ReviewCard{
+		Learner:       learner,
+		TranslationID: translationID,
+	}
+	card.SetState(state)
+	// Attempt to insert. On conflict, do nothing.
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "learner"}, {Name: "translation_id"}},
+		DoNothing: true,
+	}).Omit("Translation").Create(&card).Error
+	if err != nil {
+		return nil, err
+	}
+	// Retrieves the card from database.
+	err = r.DB.
+		Preload("Translation.ExampleSentences", r.visible("example_sentences")).
+		Scopes(ofExisting("translation_id", &models.Translation{}, "translation_id")).
+		Where(&models.ReviewCard{Learner: learner, TranslationID: translationID}).
+		First(&card).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+func (r *GormRepository) GetReviewCardByID(cardID uint) (*models.ReviewCard, error) {
+	var card models.ReviewCard
+	err := r.DB.
+		Preload("Translation.ExampleSentences", r.visible("example_sentences")).
+		Scopes(ofExisting("translation_id", &models.Translation{}, "translation_id")).
+		First(&card, cardID).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+func (r *GormRepository) RecordReview(cardID uint, grade srs.Grade, state srs.State, reviewedAt time.Time) (*models.ReviewCard, error) {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ReviewCard{}).
+			Where("card_id = ?", cardID).
+			Updates(map[string]any{
+				"repetitions":   state.Repetitions,
+				"interval_days": state.IntervalDays,
+				"ease_factor":   state.EaseFactor,
+				"due":           state.Due,
+				"last_reviewed": reviewedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Create(&models.ReviewLog{
+			CardID:       cardID,
+			Grade:        int(grade),
+			IntervalDays: state.IntervalDays,
+			EaseFactor:   state.EaseFactor,
+			ReviewedAt:   reviewedAt,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetReviewCardByID(cardID)
+}
+
+func (r *GormRepository) ListDueReviewCards(learner string, now time.Time, limit int) ([]models.ReviewCard, error) {
+	var cards []models.ReviewCard
+	err := r.DB.
+		Preload("Translation.ExampleSentences", r.visible("example_sentences")).
+		Scopes(ofExisting("translation_id", &models.Translation{}, "translation_id")).
+		Where("learner = ? AND due <= ?", learner, now).
+		Order("due, card_id").
+		Limit(limit).
+		Find(&cards).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+func (r *GormRepository) ListReviewLogs(cardID uint) ([]models.ReviewLog, error) {
+	var logs []models.ReviewLog
+	err := r.DB.
+		Where("card_id = ?", cardID).
+		Order("reviewed_at, log_id").
+		Find(&logs).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+func (r *GormRepository) MoveReviewCards(fromTranslationID uint, toTranslationID uint) error {
+	var count int64
+	err := r.DB.
+		Model(&models.Translation{}).
+		Scopes(r.visible("translations")).
+		Where("translation_id IN ?", []uint{fromTranslationID, toTranslationID}).
+		Count(&count).
+		Error
+	if err != nil {
+		return err
+	}
+	if count != 2 {
+		return gorm.ErrRecordNotFound
+	}
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		// Delete the cards of learners who already study the other translation, then move the rest
+		studying := tx.Model(&models.ReviewCard{}).Select("learner").Where("translation_id = ?", toTranslationID)
+		duplicates := tx.Model(&models.ReviewCard{}).Select("card_id").Where("translation_id = ? AND learner IN (?)", fromTranslationID, studying)
+		if err := tx.Where("card_id IN (?)", duplicates).Delete(&models.ReviewLog{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("card_id IN (?)", duplicates).Delete(&models.ReviewCard{}).Error; err != nil {
+			return err
+		}
+		return tx.
+			Model(&models.ReviewCard{}).
+			Where("translation_id = ?", fromTranslationID).
+			UpdateColumn("translation_id", toTranslationID).
+			Error
+	})
+}
+
+func (r *GormRepository) CreateUser(username string, passwordHash string) (*models.User, error) {
+	user := models.User{Username: username, PasswordHash: passwordHash, Role: models.RoleViewer}
+	if err := r.DB.Create(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *GormRepository) GetUserByID(userID uint) (*models.User, error) {
+	var user models.User
+	if err := r.DB.First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *GormRepository) GetUserByUsername(username string) (*models.User, error) {
+	var user models.User
+	if err := r.DB.Where("username = ?", username).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *GormRepository) ListUsers() ([]models.User, error) {
+	var users []models.User
+	if err := r.DB.Order("username").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *GormRepository) UpdateUserRole(userID uint, role models.Role) (*models.User, error) {
+	if !role.Valid() {
+		return nil, fmt.Errorf("unknown role: %q", role)
+	}
+	result := r.DB.Model(&models.User{}).Where("user_id = ?", userID).Update("role", role)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.GetUserByID(userID)
+}
+
+func (r *GormRepository) UpdateUserGroup(userID uint, group string) (*models.User, error) {
+	result := r.DB.Model(&models.User{}).Where("user_id = ?", userID).Update("user_group", group)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.GetUserByID(userID)
+}
+
+func (r *GormRepository) PromoteEntry(entryType models.EntryType, entryID uint) error {
+	entry, ok := entryTables[entryType]
+	if !ok {
+		return fmt.Errorf("unknown entry type: %q", entryType)
+	}
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var owners []models.Owner
+		err := tx.
+			Table(entry.table).
+			Where(entry.idColumn+" = ? AND deleted_at IS NULL", entryID).
+			Pluck("owner", &owners).
+			Error
+		if err != nil {
+			return err
+		}
+		if len(owners) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if owners[0].Shared() {
+			return ErrAlreadyShared
+		}
+		return r.promoteEntry(tx, entryType, entryID)
+	})
+}
+
+func (r *GormRepository) CreateChangeProposal(proposal models.ChangeProposal) (*models.ChangeProposal, error) {
+	proposal.Status = models.ProposalPending
+	if err := r.DB.Omit("Proposer", "Reviewer").Create(&proposal).Error; err != nil {
+		return nil, err
+	}
+	return r.GetChangeProposalByID(proposal.ProposalID)
+}
+
+func (r *GormRepository) GetChangeProposalByID(proposalID uint) (*models.ChangeProposal, error) {
+	var proposal models.ChangeProposal
+	err := r.DB.
+		Preload("Proposer").
+		Preload("Reviewer").
+		First(&proposal, proposalID).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+func (r *GormRepository) ListChangeProposalsPage(status models.ProposalStatus, page PageArgs) (*Page[models.ChangeProposal], error) {
+	ks := keyset{
+		sortExpr: "change_proposals.proposal_id",
+		sortType: "bigint",
+		idColumn: "change_proposals.proposal_id",
+	}
+	base := func() *gorm.DB {
+		return r.DB.Model(&models.ChangeProposal{}).Where("change_proposals.status = ?", status)
+	}
+	load := func(ids []uint) (map[uint]models.ChangeProposal, error) {
+		var proposals []models.ChangeProposal
+		if err := r.DB.Preload("Proposer").Preload("Reviewer").Find(&proposals, ids).Error; err != nil {
+			return nil, err
+		}
+		byID := make(map[uint]models.ChangeProposal, len(proposals))
+		for _, p := range proposals {
+			byID[p.ProposalID] = p
+		}
+		return byID, nil
+	}
+	return fetchPage(base, ks, page, load)
+}
+
+func (r *GormRepository) ReviewChangeProposal(proposalID uint, status models.ProposalStatus, reviewerID uint, comment string, reviewedAt time.Time) (*models.ChangeProposal, error) {
+	if status != models.ProposalApproved && status != models.ProposalRejected {
+		return nil, fmt.Errorf("invalid review status: %q", status)
+	}
+	result := r.DB.
+		Model(&models.ChangeProposal{}).
+		Where("proposal_id = ? AND status = ?", proposalID, models.ProposalPending).
+		Updates(map[string]any{
+			"status":      status,
+			"comment":     comment,
+			"reviewed_by": reviewerID,
+			"reviewed_at": reviewedAt,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		// Tell a missing proposal from a reviewed one.
+		if _, err := r.GetChangeProposalByID(proposalID); err != nil {
+			return nil, err
+		}
+		return nil, ErrProposalReviewed
+	}
+	return r.GetChangeProposalByID(proposalID)
+}
+
+// promoteEntry moves an entry to the shared dictionary together with the private word and translation
+// it belongs to, so that it can be reached from shared entries. Private entries below it stay private.
+// Each change of the owner is recorded as a revision.
+func (r *GormRepository) promoteEntry(tx *gorm.DB, entryType models.EntryType, entryID uint) error {
+	switch entryType {
+	case models.EntryExampleSentence:
+		var sentence models.ExampleSentence
+		if err := tx.First(&sentence, entryID).Error; err != nil {
+			return err
+		}
+		if !sentence.Owner.Shared() {
+			err := checkDuplicate(tx, &models.ExampleSentence{}, map[string]any{
+				"translation_id": sentence.TranslationID,
+				"sentence_text":  sentence.SentenceText,
+				"owner":          models.SharedOwner,
+			})
+			if err != nil {
+				return err
+			}
+			before := snapshotExampleSentence(sentence)
+			if err := tx.Model(&sentence).UpdateColumn("owner", models.SharedOwner).Error; err != nil {
+				return err
+			}
+			sentence.Owner = models.SharedOwner
+			err = r.recordRevision(tx, models.RevisionUpdate, entryType, entryID, before, snapshotExampleSentence(sentence))
+			if err != nil {
+				return err
+			}
+		}
+		return r.promoteEntry(tx, models.EntryTranslation, sentence.TranslationID)
+	case models.EntryTranslation:
+		var translation models.Translation
+		if err := tx.First(&translation, entryID).Error; err != nil {
+			return err
+		}
+		if !translation.Owner.Shared() {
+			err := checkDuplicate(tx, &models.Translation{}, map[string]any{
+				"word_id":             translation.WordID,
+				"english_translation": translation.EnglishTranslation,
+				"owner":               models.SharedOwner,
+			})
+			if err != nil {
+				return err
+			}
+			before := snapshotTranslation(translation)
+			if err := tx.Model(&translation).UpdateColumn("owner", models.SharedOwner).Error; err != nil {
+				return err
+			}
+			translation.Owner = models.SharedOwner
+			err = r.recordRevision(tx, models.RevisionUpdate, entryType, entryID, before, snapshotTranslation(translation))
+			if err != nil {
+				return err
+			}
+		}
+		return r.promoteEntry(tx, models.EntryWord, translation.WordID)
+	case models.EntryWord:
+		var word models.Word
+		if err := tx.First(&word, entryID).Error; err != nil {
+			return err
+		}
+		if word.Owner.Shared() {
+			return nil
+		}
+		err := checkDuplicate(tx, &models.Word{}, map[string]any{
+			"polish_word":    word.PolishWord,
+			"part_of_speech": word.PartOfSpeech,
+			"owner":          models.SharedOwner,
+		})
+		if err != nil {
+			return err
+		}
+		before := snapshotWord(word)
+		if err := tx.Model(&word).UpdateColumn("owner", models.SharedOwner).Error; err != nil {
+			return err
+		}
+		word.Owner = models.SharedOwner
+		return r.recordRevision(tx, models.RevisionUpdate, entryType, entryID, before, snapshotWord(word))
+	}
+	return fmt.Errorf("unknown entry type: %q", entryType)
+}
+
+// checkDuplicate fails with ErrDuplicateEntry if an entry of the model that is not deleted matches the conditions,
+// which repeat the columns of its unique index.
+func checkDuplicate(tx *gorm.DB, model any, conditions map[string]any) error {
+	var count int64
+	err := tx.Model(model).Where(conditions).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrDuplicateEntry
+	}
+	return nil
 }
@@ -6,13 +6,18 @@ import (
 	"os"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/sar-michal/dictionary-app/pkg/config"
+	"github.com/sar-michal/dictionary-app/pkg/merge"
 	"github.com/sar-michal/dictionary-app/pkg/models"
+	"github.com/sar-michal/dictionary-app/pkg/proposal"
 	"github.com/sar-michal/dictionary-app/pkg/repository"
+	"github.com/sar-michal/dictionary-app/pkg/srs"
 	"github.com/sar-michal/dictionary-app/pkg/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 var repo repository.Repository
@@ -63,32 +68,111 @@ func CleanupRepository(t *testing.T) {
 	gormRepo, ok := repo.(*repository.GormRepository)
 	require.True(t, ok, "Expected repository to be of type *GormRepository. Failed to cleanup database")
 
-	err := gormRepo.DB.Exec("TRUNCATE TABLE words, translations, example_sentences RESTART IDENTITY CASCADE").Error
+	err := gormRepo.DB.Exec("TRUNCATE TABLE words, translations, example_sentences, inflections, word_relations, audio_recordings, review_cards, review_logs, users, change_proposals, revisions RESTART IDENTITY CASCADE").Error
 	require.NoError(t, err, "Failed to cleanup database")
 }
 
 func TestGetOrCreateWord(t *testing.T) {
 	withTransaction(t, func(txRepo repository.Repository) {
-		word, err := txRepo.GetOrCreateWord("kot")
+		word, err := txRepo.GetOrCreateWord("kot", models.Grammar{})
 		require.NoError(t, err, "GetOrCreateWord should not error")
 		assert.Equal(t, "kot", word.PolishWord, "PolishWord should match")
 		firstID := word.WordID
 
-		sameWord, err := txRepo.GetOrCreateWord("kot")
+		sameWord, err := txRepo.GetOrCreateWord("kot", models.Grammar{})
 		require.NoError(t, err, "Second GetOrCreateWord should not error")
 		assert.Equal(t, firstID, sameWord.WordID, "WordID should be consistent")
 	})
 }
 
+func TestGetOrCreateWordHomonyms(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		noun, err := txRepo.GetOrCreateWord("piec", models.Grammar{
+			PartOfSpeech: models.PartOfSpeechNoun,
+			Gender:       models.GenderMasculineInanimate,
+		})
+		require.NoError(t, err, "GetOrCreateWord should not error for the noun")
+		assert.Equal(t, models.GenderMasculineInanimate, noun.Gender, "Gender should be stored")
+
+		verb, err := txRepo.GetOrCreateWord("piec", models.Grammar{
+			PartOfSpeech: models.PartOfSpeechVerb,
+			Aspect:       models.AspectImperfective,
+		})
+		require.NoError(t, err, "GetOrCreateWord should not error for the verb")
+		assert.NotEqual(t, noun.WordID, verb.WordID, "Homonyms with different parts of speech should be separate words")
+
+		sameNoun, err := txRepo.GetOrCreateWord("piec", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord should not error for an existing noun")
+		assert.Equal(t, noun.WordID, sameNoun.WordID, "Expected the existing noun")
+		assert.Equal(t, models.GenderMasculineInanimate, sameNoun.Gender, "Existing gender should be kept")
+
+		partOfSpeech := models.PartOfSpeechVerb
+		retrieved, err := txRepo.GetWordByPolish("piec", &partOfSpeech)
+		require.NoError(t, err, "GetWordByPolish should not error")
+		assert.Equal(t, verb.WordID, retrieved.WordID, "Expected the verb")
+
+		verbs, err := txRepo.ListWords(repository.WordOrder{}, repository.WordFilter{StartsWith: "piec", PartOfSpeech: &partOfSpeech})
+		require.NoError(t, err, "ListWords should not error")
+		require.Equal(t, 1, len(verbs), "Expected only the verb")
+		assert.Equal(t, models.AspectImperfective, verbs[0].Aspect, "Aspect should be stored")
+	})
+}
+func TestUpdateWordGrammar(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		created, err := txRepo.GetOrCreateWord("czytać", models.Grammar{})
+		require.NoError(t, err, "Failed to create word 'czytać'")
+
+		grammar := models.Grammar{PartOfSpeech: models.PartOfSpeechVerb, Aspect: models.AspectImperfective}
+		updated, err := txRepo.UpdateWordGrammar(created.WordID, grammar)
+		require.NoError(t, err, "UpdateWordGrammar should not error")
+		assert.Equal(t, grammar, updated.Grammar(), "Grammar should be updated")
+
+		retrieved, err := txRepo.GetWordByID(created.WordID)
+		require.NoError(t, err, "GetWordByID should not error")
+		assert.Equal(t, grammar, retrieved.Grammar(), "Retrieved word should reflect the update")
+	})
+}
+func TestGetOrCreateWordPronunciation(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		word, err := txRepo.GetOrCreateWord("chleb", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+		assert.Equal(t, "xlɛp", word.Pronunciation, "Expected Generated Pronunciation")
+
+		updated, err := txRepo.UpdateWord(word.WordID, "żółw")
+		require.NoError(t, err, "UpdateWord Should Not Error")
+		assert.Equal(t, "ʐuwf", updated.Pronunciation, "Expected Pronunciation To Follow The Polish Word")
+	})
+}
+
+func TestUpdateWordPronunciation(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		word, err := txRepo.GetOrCreateWord("żółw", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+
+		updated, err := txRepo.UpdateWordPronunciation(word.WordID, "ˈʐuwf")
+		require.NoError(t, err, "UpdateWordPronunciation Should Not Error")
+		assert.Equal(t, "ˈʐuwf", updated.Transcription(), "Expected Override To Take Precedence")
+
+		retrieved, err := txRepo.GetWordByID(word.WordID)
+		require.NoError(t, err, "GetWordByID Should Not Error")
+		assert.Equal(t, "ˈʐuwf", retrieved.PronunciationOverride, "Expected Override To Be Saved")
+		assert.Equal(t, "ʐuwf", retrieved.Pronunciation, "Expected Generated Pronunciation To Be Kept")
+
+		cleared, err := txRepo.UpdateWordPronunciation(word.WordID, "")
+		require.NoError(t, err, "UpdateWordPronunciation Should Not Error")
+		assert.Equal(t, "ʐuwf", cleared.Transcription(), "Expected Generated Pronunciation After Clearing Override")
+	})
+}
+
 func TestListWords(t *testing.T) {
 	withTransaction(t, func(txRepo repository.Repository) {
-		_, err := txRepo.GetOrCreateWord("kot")
+		_, err := txRepo.GetOrCreateWord("kot", models.Grammar{})
 		require.NoError(t, err, "Failed to create word kot")
 
-		_, err = txRepo.GetOrCreateWord("pies")
+		_, err = txRepo.GetOrCreateWord("pies", models.Grammar{})
 		require.NoError(t, err, "Failed to create word pies")
 
-		words, err := txRepo.ListWords()
+		words, err := txRepo.ListWords(repository.WordOrder{}, repository.WordFilter{})
 		require.NoError(t, err, "ListWords should not error")
 
 		// Verify that the list contains both "kot" and "pies".
@@ -104,19 +188,353 @@ func TestListWords(t *testing.T) {
 		assert.True(t, foundPies, "Word 'pies' should be listed")
 	})
 }
+func TestListWordsOrderAndFilter(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		words := make(map[string]*models.Word)
+		for _, w := range []string{"bąk", "banan", "bat"} {
+			word, err := txRepo.GetOrCreateWord(w, models.Grammar{})
+			require.NoError(t, err, "Failed to create word '%s'", w)
+			words[w] = word
+		}
+		_, err := txRepo.GetOrCreateTranslation(words["bat"].WordID, "whip")
+		require.NoError(t, err, "Failed to create translation 'whip'")
+		bumblebee, err := txRepo.GetOrCreateTranslation(words["bąk"].WordID, "bumblebee")
+		require.NoError(t, err, "Failed to create translation 'bumblebee'")
+		_, err = txRepo.GetOrCreateTranslation(words["bąk"].WordID, "spinning top")
+		require.NoError(t, err, "Failed to create translation 'spinning top'")
+		_, err = txRepo.GetOrCreateExampleSentence(bumblebee.TranslationID, "A bumblebee is buzzing.")
+		require.NoError(t, err, "Failed to create example sentence")
+
+		collect := func(words []models.Word) []string {
+			var polishWords []string
+			for _, w := range words {
+				polishWords = append(polishWords, w.PolishWord)
+			}
+			return polishWords
+		}
+		startsWithB := repository.WordFilter{StartsWith: "b"}
+
+		listed, err := txRepo.ListWords(repository.WordOrder{Field: repository.WordOrderByPolishWord}, startsWithB)
+		require.NoError(t, err, "ListWords should not error")
+		assert.Equal(t, []string{"banan", "bat", "bąk"}, collect(listed), "Expected Polish alphabetical order")
+
+		listed, err = txRepo.ListWords(
+			repository.WordOrder{Field: repository.WordOrderByTranslationCount, Descending: true},
+			startsWithB,
+		)
+		require.NoError(t, err, "ListWords should not error")
+		assert.Equal(t, []string{"bąk", "bat", "banan"}, collect(listed), "Expected order by translation count")
+
+		noTranslations := false
+		listed, err = txRepo.ListWords(repository.WordOrder{}, repository.WordFilter{StartsWith: "b", HasTranslations: &noTranslations})
+		require.NoError(t, err, "ListWords should not error")
+		assert.Equal(t, []string{"banan"}, collect(listed), "Expected only the word without translations")
+
+		withSentences := true
+		listed, err = txRepo.ListWords(repository.WordOrder{}, repository.WordFilter{StartsWith: "b", HasExampleSentences: &withSentences})
+		require.NoError(t, err, "ListWords should not error")
+		assert.Equal(t, []string{"bąk"}, collect(listed), "Expected only the word with example sentences")
+
+		// Private translations of other users are not counted
+		owner := models.UserOwner(7)
+		private := txRepo.WithScope(repository.Scope{Owners: []models.Owner{owner}, Owner: owner})
+		for _, text := range []string{"club", "bat", "racket"} {
+			_, err = private.GetOrCreateTranslation(words["banan"].WordID, text)
+			require.NoError(t, err, "Failed to create private translation '%s'", text)
+		}
+		listed, err = txRepo.ListWords(
+			repository.WordOrder{Field: repository.WordOrderByTranslationCount, Descending: true},
+			startsWithB,
+		)
+		require.NoError(t, err, "ListWords should not error")
+		assert.Equal(t, []string{"bąk", "bat", "banan"}, collect(listed), "Expected hidden translations not to be counted")
+	})
+}
+func TestStreamWords(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		kot, err := txRepo.GetOrCreateWord("kot", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+		translation, err := txRepo.GetOrCreateTranslation(kot.WordID, "cat")
+		require.NoError(t, err, "GetOrCreateTranslation Should Not Error")
+		_, err = txRepo.GetOrCreateExampleSentence(translation.TranslationID, "Kot śpi.")
+		require.NoError(t, err, "GetOrCreateExampleSentence Should Not Error")
+		_, err = txRepo.GetOrCreateWord("biegać", models.Grammar{PartOfSpeech: models.PartOfSpeechVerb})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+
+		var streamed []models.Word
+		err = txRepo.StreamWords(repository.WordFilter{}, func(word *models.Word) error {
+			streamed = append(streamed, *word)
+			return nil
+		})
+		require.NoError(t, err, "StreamWords Should Not Error")
+		require.Equal(t, 2, len(streamed), "Expected All Words")
+		assert.Equal(t, "kot", streamed[0].PolishWord, "Expected Words In ID Order")
+		require.Equal(t, 1, len(streamed[0].Translations), "Expected Preloaded Translations")
+		assert.Equal(t, 1, len(streamed[0].Translations[0].ExampleSentences), "Expected Preloaded Example Sentences")
+
+		verb := models.PartOfSpeechVerb
+		streamed = nil
+		err = txRepo.StreamWords(repository.WordFilter{PartOfSpeech: &verb}, func(word *models.Word) error {
+			streamed = append(streamed, *word)
+			return nil
+		})
+		require.NoError(t, err, "StreamWords Should Not Error With Filter")
+		require.Equal(t, 1, len(streamed), "Expected Only Verbs")
+		assert.Equal(t, "biegać", streamed[0].PolishWord, "Expected 'biegać'")
+
+		streamed = nil
+		err = txRepo.StreamWords(repository.WordFilter{WordIDs: []uint{kot.WordID}}, func(word *models.Word) error {
+			streamed = append(streamed, *word)
+			return nil
+		})
+		require.NoError(t, err, "StreamWords Should Not Error With Word IDs")
+		require.Equal(t, 1, len(streamed), "Expected Only The Listed Word")
+		assert.Equal(t, kot.WordID, streamed[0].WordID, "Expected 'kot'")
+	})
+}
+
 func TestGetWordByPolish(t *testing.T) {
 	withTransaction(t, func(txRepo repository.Repository) {
-		created, err := txRepo.GetOrCreateWord("lis")
+		created, err := txRepo.GetOrCreateWord("lis", models.Grammar{})
 		require.NoError(t, err, "Failed to create word 'lis'")
 
-		retrieved, err := txRepo.GetWordByPolish("lis")
+		retrieved, err := txRepo.GetWordByPolish("lis", nil)
 		require.NoError(t, err, "GetWordByPolish should not error")
 		assert.Equal(t, created.WordID, retrieved.WordID, "Retrieved word should match the created word")
 	})
 }
+func TestListWordsPage(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		for _, w := range []string{"babcia", "brat", "ciocia", "brzoza", "bąk"} {
+			_, err := txRepo.GetOrCreateWord(w, models.Grammar{})
+			require.NoError(t, err, "Failed to create word '%s'", w)
+		}
+		order := repository.WordOrder{Field: repository.WordOrderByPolishWord}
+		filter := repository.WordFilter{StartsWith: "B"}
+
+		first, err := txRepo.ListWordsPage(order, filter, repository.PageArgs{First: 2})
+		require.NoError(t, err, "ListWordsPage should not error")
+		require.Equal(t, 2, len(first.Items), "Expected a full first page")
+		assert.Equal(t, int64(4), first.TotalCount, "Expected four words starting with 'b'")
+		assert.True(t, first.HasNextPage, "Expected a next page")
+		assert.False(t, first.HasPreviousPage, "Expected no previous page")
+		assert.Nil(t, first.Items[0].Translations, "Translations should not be preloaded")
+
+		second, err := txRepo.ListWordsPage(order, filter, repository.PageArgs{First: 2, After: first.Cursors[1]})
+		require.NoError(t, err, "ListWordsPage should not error for the second page")
+		require.Equal(t, 2, len(second.Items), "Expected a full second page")
+		assert.False(t, second.HasNextPage, "Expected no next page")
+		assert.True(t, second.HasPreviousPage, "Expected a previous page")
+
+		var seen []string
+		for _, w := range append(first.Items, second.Items...) {
+			seen = append(seen, w.PolishWord)
+		}
+		assert.ElementsMatch(t, []string{"babcia", "brat", "brzoza", "bąk"}, seen, "Pages should not overlap")
+
+		back, err := txRepo.ListWordsPage(order, filter, repository.PageArgs{Last: 2, Before: second.Cursors[0]})
+		require.NoError(t, err, "ListWordsPage should not error when paging backwards")
+		require.Equal(t, 2, len(back.Items), "Expected two words before the second page")
+		assert.Equal(t, first.Items[0].WordID, back.Items[0].WordID, "Backward page should match the first page")
+		assert.Equal(t, first.Items[1].WordID, back.Items[1].WordID, "Backward page should match the first page")
+
+		_, err = txRepo.ListWordsPage(order, filter, repository.PageArgs{First: 2, After: "not a cursor"})
+		assert.ErrorIs(t, err, repository.ErrInvalidCursor, "Expected an invalid cursor error")
+	})
+}
+func TestListTranslationsPage(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		word, err := txRepo.GetOrCreateWord("zamek", models.Grammar{})
+		require.NoError(t, err, "Failed to create word 'zamek'")
+
+		for _, e := range []string{"castle", "lock", "zipper"} {
+			_, err := txRepo.GetOrCreateTranslation(word.WordID, e)
+			require.NoError(t, err, "Failed to create translation '%s'", e)
+		}
+
+		page, err := txRepo.ListTranslationsPage(word.WordID, repository.PageArgs{First: 2})
+		require.NoError(t, err, "ListTranslationsPage should not error")
+		require.Equal(t, 2, len(page.Items), "Expected two translations")
+		assert.Equal(t, "castle", page.Items[0].EnglishTranslation, "Translations should be ordered by ID")
+		assert.Equal(t, int64(3), page.TotalCount, "Expected three translations in total")
+		assert.True(t, page.HasNextPage, "Expected a next page")
+	})
+}
+func TestSampleTranslations(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		word, err := txRepo.GetOrCreateWord("zamek", models.Grammar{})
+		require.NoError(t, err, "Failed to create word 'zamek'")
+
+		for _, e := range []string{"castle", "lock", "zipper", "fastener"} {
+			_, err := txRepo.GetOrCreateTranslation(word.WordID, e)
+			require.NoError(t, err, "Failed to create translation '%s'", e)
+		}
+		lock, err := txRepo.GetOrCreateTranslation(word.WordID, "lock")
+		require.NoError(t, err, "Failed to get translation 'lock'")
+		_, err = txRepo.GetOrCreateExampleSentence(lock.TranslationID, "Zamek się zaciął.")
+		require.NoError(t, err, "Failed to create example sentence")
+
+		first, err := txRepo.SampleTranslations(42, 3, false)
+		require.NoError(t, err, "SampleTranslations should not error")
+		require.Equal(t, 3, len(first), "Expected the sample to be limited")
+		second, err := txRepo.SampleTranslations(42, 3, false)
+		require.NoError(t, err, "SampleTranslations should not error")
+		assert.Equal(t, first, second, "Expected the same sample for the same seed")
+
+		withSentences, err := txRepo.SampleTranslations(7, 3, true)
+		require.NoError(t, err, "SampleTranslations should not error")
+		require.Equal(t, 1, len(withSentences), "Expected only the translation with an example sentence")
+		assert.Equal(t, "lock", withSentences[0].EnglishTranslation, "Expected translation 'lock'")
+	})
+}
+
+func TestListWordsByFoldedPolish(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		zolw, err := txRepo.GetOrCreateWord("żółw", models.Grammar{})
+		require.NoError(t, err, "Failed to create word 'żółw'")
+		assert.Equal(t, "zolw", zolw.SearchKey, "SearchKey should be folded")
+
+		_, err = txRepo.GetOrCreateWord("zołw", models.Grammar{})
+		require.NoError(t, err, "Failed to create word 'zołw'")
+
+		words, err := txRepo.ListWordsByFoldedPolish("Zolw")
+		require.NoError(t, err, "ListWordsByFoldedPolish should not error")
+		require.Equal(t, 2, len(words), "Expected both words folding to 'zolw'")
+		assert.Equal(t, zolw.WordID, words[0].WordID, "Words should be ordered by ID")
+	})
+}
+func TestGetOrCreateWordFolded(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		created, err := txRepo.GetOrCreateWord("łódź", models.Grammar{})
+		require.NoError(t, err, "Failed to create word 'łódź'")
+
+		candidates, err := txRepo.GetOrCreateWordFolded("lodz", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWordFolded should not error")
+		require.Equal(t, 1, len(candidates), "Expected the existing word to be returned")
+		assert.Equal(t, created.WordID, candidates[0].WordID, "Expected 'łódź' to match 'lodz'")
+
+		candidates, err = txRepo.GetOrCreateWordFolded("żaba", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWordFolded should not error")
+		require.Equal(t, 1, len(candidates), "Expected a new word to be created")
+		assert.Equal(t, "żaba", candidates[0].PolishWord, "Expected the new word to keep its diacritics")
+	})
+}
+func TestUpdateWordSearchKey(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		created, err := txRepo.GetOrCreateWord("kot", models.Grammar{})
+		require.NoError(t, err, "Failed to create word 'kot'")
+
+		updated, err := txRepo.UpdateWord(created.WordID, "Żmija")
+		require.NoError(t, err, "UpdateWord should not error")
+		assert.Equal(t, "zmija", updated.SearchKey, "SearchKey should follow the updated word")
+	})
+}
+func TestListWordsByEnglish(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		kot, err := txRepo.GetOrCreateWord("kot", models.Grammar{})
+		require.NoError(t, err, "Failed to create word 'kot'")
+
+		kocur, err := txRepo.GetOrCreateWord("kocur", models.Grammar{})
+		require.NoError(t, err, "Failed to create word 'kocur'")
+
+		pies, err := txRepo.GetOrCreateWord("pies", models.Grammar{})
+		require.NoError(t, err, "Failed to create word 'pies'")
+
+		_, err = txRepo.GetOrCreateTranslation(kot.WordID, "cat")
+		require.NoError(t, err, "Failed to create translation 'cat' for 'kot'")
+
+		_, err = txRepo.GetOrCreateTranslation(kocur.WordID, "cat")
+		require.NoError(t, err, "Failed to create translation 'cat' for 'kocur'")
+
+		_, err = txRepo.GetOrCreateTranslation(pies.WordID, "dog")
+		require.NoError(t, err, "Failed to create translation 'dog' for 'pies'")
+
+		words, err := txRepo.ListWordsByEnglish("cat")
+		require.NoError(t, err, "ListWordsByEnglish should not error")
+		require.Equal(t, 2, len(words), "Expected two words translated as 'cat'")
+
+		var polishWords []string
+		for _, w := range words {
+			polishWords = append(polishWords, w.PolishWord)
+			assert.NotEmpty(t, w.Translations, "Translations should be preloaded")
+		}
+		assert.ElementsMatch(t, []string{"kot", "kocur"}, polishWords, "Expected 'kot' and 'kocur'")
+
+		words, err = txRepo.ListWordsByEnglish("Cat")
+		require.NoError(t, err, "ListWordsByEnglish should not error")
+		assert.Empty(t, words, "Expected the exact lookup to match case")
+		words, err = txRepo.ListWordsByFoldedEnglish("Cat")
+		require.NoError(t, err, "ListWordsByFoldedEnglish should not error")
+		assert.Equal(t, 2, len(words), "Expected 'Cat' to match 'cat' ignoring case")
+	})
+}
+func TestListHeadwords(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		for _, polish := range []string{"kot", "Kotek", "pies"} {
+			word, err := txRepo.GetOrCreateWord(polish, models.Grammar{})
+			require.NoError(t, err, "Failed to create word '%s'", polish)
+			_, err = txRepo.GetOrCreateTranslation(word.WordID, "cat")
+			require.NoError(t, err, "Failed to create translation 'cat' for '%s'", polish)
+		}
+		_, err := txRepo.GetOrCreateWord("kot", models.Grammar{PartOfSpeech: models.PartOfSpeechVerb})
+		require.NoError(t, err, "Failed to create homonym 'kot'")
+
+		polish, err := txRepo.ListPolishHeadwords("KO", 10)
+		require.NoError(t, err, "ListPolishHeadwords should not error")
+		assert.Equal(t, []string{"kot", "Kotek"}, polish, "Expected distinct Polish headwords starting with 'ko'")
+
+		english, err := txRepo.ListEnglishHeadwords("c", 10)
+		require.NoError(t, err, "ListEnglishHeadwords should not error")
+		assert.Equal(t, []string{"cat"}, english, "Expected 'cat' once")
+
+		limited, err := txRepo.ListPolishHeadwords("", 1)
+		require.NoError(t, err, "ListPolishHeadwords should not error")
+		assert.Equal(t, 1, len(limited), "Expected the limit to apply")
+	})
+}
+
+func TestSearchWords(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		for _, w := range []string{"kot", "kotek", "młotek", "pies"} {
+			_, err := txRepo.GetOrCreateWord(w, models.Grammar{})
+			require.NoError(t, err, "Failed to create word '%s'", w)
+		}
+
+		collect := func(hits []repository.WordSearchHit) []string {
+			var polishWords []string
+			for _, h := range hits {
+				polishWords = append(polishWords, h.Word.PolishWord)
+			}
+			return polishWords
+		}
+
+		hits, err := txRepo.SearchWords("kot", repository.SearchPrefix, 10)
+		require.NoError(t, err, "Prefix SearchWords should not error")
+		assert.Equal(t, []string{"kot", "kotek"}, collect(hits), "Exact match should rank first")
+		assert.Equal(t, 1.0, hits[0].Similarity, "Exact match should have similarity 1")
+
+		hits, err = txRepo.SearchWords("otek", repository.SearchSubstring, 10)
+		require.NoError(t, err, "Substring SearchWords should not error")
+		assert.ElementsMatch(t, []string{"kotek", "młotek"}, collect(hits), "Expected words containing 'otek'")
+
+		hits, err = txRepo.SearchWords("kotk", repository.SearchFuzzy, 10)
+		require.NoError(t, err, "Fuzzy SearchWords should not error")
+		require.NotEmpty(t, hits, "Fuzzy search should find similar words")
+		assert.Contains(t, collect(hits), "kotek", "Expected 'kotek' among fuzzy matches")
+		assert.NotContains(t, collect(hits), "pies", "Unrelated words should not match")
+
+		hits, err = txRepo.SearchWords("ko", repository.SearchPrefix, 1)
+		require.NoError(t, err, "Limited SearchWords should not error")
+		assert.Equal(t, 1, len(hits), "Limit should be respected")
+
+		hits, err = txRepo.SearchWords("k%", repository.SearchPrefix, 10)
+		require.NoError(t, err, "SearchWords should not error on wildcard characters")
+		assert.Empty(t, hits, "Wildcard characters should be matched literally")
+	})
+}
 func TestGetWordByID(t *testing.T) {
 	withTransaction(t, func(txRepo repository.Repository) {
-		created, err := txRepo.GetOrCreateWord("koń")
+		created, err := txRepo.GetOrCreateWord("koń", models.Grammar{})
 		require.NoError(t, err, "Failed to create word 'koń'")
 
 		retrieved, err := txRepo.GetWordByID(created.WordID)
@@ -126,7 +544,7 @@ func TestGetWordByID(t *testing.T) {
 }
 func TestUpdateWord(t *testing.T) {
 	withTransaction(t, func(txRepo repository.Repository) {
-		created, err := txRepo.GetOrCreateWord("koza")
+		created, err := txRepo.GetOrCreateWord("koza", models.Grammar{})
 		require.NoError(t, err, "Failed to create word 'koza'")
 
 		updated, err := txRepo.UpdateWord(created.WordID, "owca")
@@ -140,7 +558,7 @@ func TestUpdateWord(t *testing.T) {
 }
 func TestDeleteWord(t *testing.T) {
 	withTransaction(t, func(txRepo repository.Repository) {
-		word, err := txRepo.GetOrCreateWord("słoń")
+		word, err := txRepo.GetOrCreateWord("słoń", models.Grammar{})
 		require.NoError(t, err, "Failed to create word 'słoń'")
 
 		translation, err := txRepo.GetOrCreateTranslation(word.WordID, "elephant")
@@ -165,7 +583,7 @@ func TestDeleteWord(t *testing.T) {
 
 func TestListTranslations(t *testing.T) {
 	withTransaction(t, func(txRepo repository.Repository) {
-		word, err := txRepo.GetOrCreateWord("kot")
+		word, err := txRepo.GetOrCreateWord("kot", models.Grammar{})
 		require.NoError(t, err, "GetOrCreateWord should not error")
 
 		// Create two translations for "kot": "cat" and "kitty".
@@ -175,14 +593,49 @@ func TestListTranslations(t *testing.T) {
 		_, err = txRepo.GetOrCreateTranslation(word.WordID, "kitty")
 		require.NoError(t, err, "GetOrCreateTranslation should not error for 'kitty'")
 
-		translations, err := txRepo.ListTranslations(word.WordID)
+		translations, err := txRepo.ListTranslations(word.WordID, repository.TranslationOrder{}, repository.TranslationFilter{})
 		require.NoError(t, err, "ListTranslations should not error")
 		assert.Equal(t, 2, len(translations), "Expected two translations for 'kot'")
 	})
 }
+func TestListTranslationsOrderAndFilter(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		word, err := txRepo.GetOrCreateWord("zamek", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+
+		_, err = txRepo.GetOrCreateTranslation(word.WordID, "zipper")
+		require.NoError(t, err, "GetOrCreateTranslation should not error for 'zipper'")
+		lock, err := txRepo.GetOrCreateTranslation(word.WordID, "lock")
+		require.NoError(t, err, "GetOrCreateTranslation should not error for 'lock'")
+		_, err = txRepo.GetOrCreateTranslation(word.WordID, "castle")
+		require.NoError(t, err, "GetOrCreateTranslation should not error for 'castle'")
+		_, err = txRepo.GetOrCreateExampleSentence(lock.TranslationID, "The lock is broken.")
+		require.NoError(t, err, "GetOrCreateExampleSentence should not error")
+
+		translations, err := txRepo.ListTranslations(
+			word.WordID,
+			repository.TranslationOrder{Field: repository.TranslationOrderByEnglishTranslation},
+			repository.TranslationFilter{},
+		)
+		require.NoError(t, err, "ListTranslations should not error")
+		require.Equal(t, 3, len(translations), "Expected three translations")
+		assert.Equal(t, "castle", translations[0].EnglishTranslation, "Expected alphabetical order")
+		assert.Equal(t, "zipper", translations[2].EnglishTranslation, "Expected alphabetical order")
+
+		withSentences := true
+		translations, err = txRepo.ListTranslations(
+			word.WordID,
+			repository.TranslationOrder{},
+			repository.TranslationFilter{HasExampleSentences: &withSentences},
+		)
+		require.NoError(t, err, "ListTranslations should not error")
+		require.Equal(t, 1, len(translations), "Expected one translation with example sentences")
+		assert.Equal(t, lock.TranslationID, translations[0].TranslationID, "Expected 'lock'")
+	})
+}
 func TestGetTranslationByID(t *testing.T) {
 	withTransaction(t, func(txRepo repository.Repository) {
-		word, err := txRepo.GetOrCreateWord("pies")
+		word, err := txRepo.GetOrCreateWord("pies", models.Grammar{})
 		require.NoError(t, err, "GetOrCreateWord should not error")
 
 		translation, err := txRepo.GetOrCreateTranslation(word.WordID, "dog")
@@ -195,7 +648,7 @@ func TestGetTranslationByID(t *testing.T) {
 }
 func TestGetOrCreateTranslation(t *testing.T) {
 	withTransaction(t, func(txRepo repository.Repository) {
-		word, err := txRepo.GetOrCreateWord("lis")
+		word, err := txRepo.GetOrCreateWord("lis", models.Grammar{})
 		require.NoError(t, err, "GetOrCreateWord should not error")
 
 		translation, err := txRepo.GetOrCreateTranslation(word.WordID, "fox")
@@ -205,7 +658,7 @@ func TestGetOrCreateTranslation(t *testing.T) {
 }
 func TestUpdateTranslation(t *testing.T) {
 	withTransaction(t, func(txRepo repository.Repository) {
-		word, err := txRepo.GetOrCreateWord("koza")
+		word, err := txRepo.GetOrCreateWord("koza", models.Grammar{})
 		require.NoError(t, err, "GetOrCreateWord should not error")
 
 		translation, err := txRepo.GetOrCreateTranslation(word.WordID, "goat")
@@ -218,7 +671,7 @@ func TestUpdateTranslation(t *testing.T) {
 }
 func TestDeleteTranslation(t *testing.T) {
 	withTransaction(t, func(txRepo repository.Repository) {
-		word, err := txRepo.GetOrCreateWord("słoń")
+		word, err := txRepo.GetOrCreateWord("słoń", models.Grammar{})
 		require.NoError(t, err, "GetOrCreateWord should not error")
 
 		translation, err := txRepo.GetOrCreateTranslation(word.WordID, "elephant")
@@ -239,7 +692,7 @@ func TestDeleteTranslation(t *testing.T) {
 }
 func TestListExampleSentences(t *testing.T) {
 	withTransaction(t, func(txRepo repository.Repository) {
-		word, err := txRepo.GetOrCreateWord("kot")
+		word, err := txRepo.GetOrCreateWord("kot", models.Grammar{})
 		require.NoError(t, err, "GetOrCreateWord should not error")
 
 		translation, err := txRepo.GetOrCreateTranslation(word.WordID, "cat")
@@ -259,7 +712,7 @@ func TestListExampleSentences(t *testing.T) {
 }
 func TestGetExampleSentenceByID(t *testing.T) {
 	withTransaction(t, func(txRepo repository.Repository) {
-		word, err := txRepo.GetOrCreateWord("pies")
+		word, err := txRepo.GetOrCreateWord("pies", models.Grammar{})
 		require.NoError(t, err, "GetOrCreateWord should not error")
 
 		translation, err := txRepo.GetOrCreateTranslation(word.WordID, "dog")
@@ -275,7 +728,7 @@ func TestGetExampleSentenceByID(t *testing.T) {
 }
 func TestGetOrCreateExampleSentence(t *testing.T) {
 	withTransaction(t, func(txRepo repository.Repository) {
-		word, err := txRepo.GetOrCreateWord("lis")
+		word, err := txRepo.GetOrCreateWord("lis", models.Grammar{})
 		require.NoError(t, err, "GetOrCreateWord Should Not Error")
 
 		translation, err := txRepo.GetOrCreateTranslation(word.WordID, "fox")
@@ -289,7 +742,7 @@ func TestGetOrCreateExampleSentence(t *testing.T) {
 }
 func TestUpdateExampleSentence(t *testing.T) {
 	withTransaction(t, func(txRepo repository.Repository) {
-		word, err := txRepo.GetOrCreateWord("koza")
+		word, err := txRepo.GetOrCreateWord("koza", models.Grammar{})
 		require.NoError(t, err, "GetOrCreateWord Should Not Error")
 
 		translation, err := txRepo.GetOrCreateTranslation(word.WordID, "goat")
@@ -306,7 +759,7 @@ func TestUpdateExampleSentence(t *testing.T) {
 }
 func TestDeleteExampleSentence(t *testing.T) {
 	withTransaction(t, func(txRepo repository.Repository) {
-		word, err := txRepo.GetOrCreateWord("owca")
+		word, err := txRepo.GetOrCreateWord("owca", models.Grammar{})
 		require.NoError(t, err, "GetOrCreateWord Should Not Error")
 
 		translation, err := txRepo.GetOrCreateTranslation(word.WordID, "sheep")
@@ -322,6 +775,1038 @@ func TestDeleteExampleSentence(t *testing.T) {
 		assert.Error(t, err, "Expected Error Retrieving Deleted Example Sentence")
 	})
 }
+func TestGetOrCreateInflection(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		word, err := txRepo.GetOrCreateWord("kot", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+
+		tags := models.InflectionTags{Case: models.CaseGenitive, Number: models.NumberSingular}
+		inflection, err := txRepo.GetOrCreateInflection(word.WordID, "kota", tags)
+		require.NoError(t, err, "GetOrCreateInflection Should Not Error")
+		assert.Equal(t, "kota", inflection.Form, "Expected Form To Be 'kota'")
+		assert.Equal(t, tags, inflection.Tags(), "Expected Tags To Match")
+
+		same, err := txRepo.GetOrCreateInflection(word.WordID, "kota", tags)
+		require.NoError(t, err, "Second GetOrCreateInflection Should Not Error")
+		assert.Equal(t, inflection.InflectionID, same.InflectionID, "InflectionID Should Be Consistent")
+
+		// The same form can fill several cells of the table.
+		accusative := models.InflectionTags{Case: models.CaseAccusative, Number: models.NumberSingular}
+		other, err := txRepo.GetOrCreateInflection(word.WordID, "kota", accusative)
+		require.NoError(t, err, "GetOrCreateInflection Should Not Error For Accusative")
+		assert.NotEqual(t, inflection.InflectionID, other.InflectionID, "Expected A Separate Inflection")
+
+		inflections, err := txRepo.ListInflections(word.WordID)
+		require.NoError(t, err, "ListInflections Should Not Error")
+		assert.Equal(t, 2, len(inflections), "Expected Two Inflections")
+	})
+}
+func TestUpdateInflection(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		word, err := txRepo.GetOrCreateWord("pies", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+
+		inflection, err := txRepo.GetOrCreateInflection(word.WordID, "piesa", models.InflectionTags{Case: models.CaseGenitive})
+		require.NoError(t, err, "GetOrCreateInflection Should Not Error")
+
+		tags := models.InflectionTags{Case: models.CaseGenitive, Number: models.NumberSingular}
+		updated, err := txRepo.UpdateInflection(inflection.InflectionID, "psa", tags)
+		require.NoError(t, err, "UpdateInflection Should Not Error")
+		assert.Equal(t, "psa", updated.Form, "Expected Updated Form To Be 'psa'")
+
+		retrieved, err := txRepo.GetInflectionByID(inflection.InflectionID)
+		require.NoError(t, err, "GetInflectionByID Should Not Error")
+		assert.Equal(t, tags, retrieved.Tags(), "Expected Updated Tags")
+	})
+}
+func TestDeleteInflection(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		word, err := txRepo.GetOrCreateWord("lis", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+
+		inflection, err := txRepo.GetOrCreateInflection(word.WordID, "lisy", models.InflectionTags{Number: models.NumberPlural})
+		require.NoError(t, err, "GetOrCreateInflection Should Not Error")
+
+		err = txRepo.DeleteInflection(inflection.InflectionID)
+		require.NoError(t, err, "DeleteInflection Should Not Error")
+
+		_, err = txRepo.GetInflectionByID(inflection.InflectionID)
+		assert.Error(t, err, "Expected Error Retrieving Deleted Inflection")
+	})
+}
+func TestDeleteWordRemovesInflections(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		word, err := txRepo.GetOrCreateWord("czytać", models.Grammar{PartOfSpeech: models.PartOfSpeechVerb})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+
+		tags := models.InflectionTags{Person: models.PersonFirst, Number: models.NumberSingular, Tense: models.TensePresent}
+		inflection, err := txRepo.GetOrCreateInflection(word.WordID, "czytam", tags)
+		require.NoError(t, err, "GetOrCreateInflection Should Not Error")
+
+		err = txRepo.DeleteWord(word.WordID)
+		require.NoError(t, err, "DeleteWord Should Not Error")
+
+		_, err = txRepo.GetInflectionByID(inflection.InflectionID)
+		assert.Error(t, err, "Expected Error Retrieving Inflection Of Deleted Word")
+	})
+}
+
+func TestGetOrCreateWordRelation(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		duzy, err := txRepo.GetOrCreateWord("duży", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+		wielki, err := txRepo.GetOrCreateWord("wielki", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+
+		relation, err := txRepo.GetOrCreateWordRelation(wielki.WordID, duzy.WordID, models.RelationSynonym)
+		require.NoError(t, err, "GetOrCreateWordRelation Should Not Error")
+		assert.Equal(t, duzy.WordID, relation.WordID, "Expected Symmetric Relation To Start At The Lower ID")
+		assert.Equal(t, "wielki", relation.RelatedWord.PolishWord, "Expected Related Word To Be Preloaded")
+
+		reversed, err := txRepo.GetOrCreateWordRelation(duzy.WordID, wielki.WordID, models.RelationSynonym)
+		require.NoError(t, err, "GetOrCreateWordRelation Should Not Error For Reversed Words")
+		assert.Equal(t, relation.RelationID, reversed.RelationID, "Expected Symmetric Relation To Be Stored Once")
+
+		_, err = txRepo.GetOrCreateWordRelation(duzy.WordID, duzy.WordID, models.RelationSeeAlso)
+		assert.Error(t, err, "Expected Error Relating A Word To Itself")
+	})
+}
+
+func TestListWordRelations(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		kot, err := txRepo.GetOrCreateWord("kot", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+		kotek, err := txRepo.GetOrCreateWord("kotek", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+		kocur, err := txRepo.GetOrCreateWord("kocur", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+
+		_, err = txRepo.GetOrCreateWordRelation(kotek.WordID, kot.WordID, models.RelationDiminutive)
+		require.NoError(t, err, "GetOrCreateWordRelation Should Not Error")
+		_, err = txRepo.GetOrCreateWordRelation(kot.WordID, kocur.WordID, models.RelationSeeAlso)
+		require.NoError(t, err, "GetOrCreateWordRelation Should Not Error")
+
+		relations, err := txRepo.ListWordRelations(kot.WordID, nil)
+		require.NoError(t, err, "ListWordRelations Should Not Error")
+		assert.Equal(t, 2, len(relations), "Expected Relations On Both Sides Of The Word")
+
+		diminutive := models.RelationDiminutive
+		relations, err = txRepo.ListWordRelations(kot.WordID, &diminutive)
+		require.NoError(t, err, "ListWordRelations Should Not Error With Type")
+		require.Equal(t, 1, len(relations), "Expected One Diminutive")
+		assert.Equal(t, "kotek", relations[0].Word.PolishWord, "Expected 'kotek' To Be A Diminutive Of 'kot'")
+
+		relations, err = txRepo.ListWordRelations(kocur.WordID, nil)
+		require.NoError(t, err, "ListWordRelations Should Not Error")
+		assert.Equal(t, 1, len(relations), "Expected Symmetric Relation From The Other Word")
+	})
+}
+
+func TestUpdateWordRelation(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		dom, err := txRepo.GetOrCreateWord("dom", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+		domisko, err := txRepo.GetOrCreateWord("domisko", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+
+		relation, err := txRepo.GetOrCreateWordRelation(domisko.WordID, dom.WordID, models.RelationDiminutive)
+		require.NoError(t, err, "GetOrCreateWordRelation Should Not Error")
+
+		updated, err := txRepo.UpdateWordRelation(relation.RelationID, models.RelationAugmentative)
+		require.NoError(t, err, "UpdateWordRelation Should Not Error")
+		assert.Equal(t, models.RelationAugmentative, updated.Type, "Expected Updated Relation Type")
+
+		updated, err = txRepo.UpdateWordRelation(relation.RelationID, models.RelationSeeAlso)
+		require.NoError(t, err, "UpdateWordRelation Should Not Error")
+		assert.Equal(t, dom.WordID, updated.WordID, "Expected Symmetric Relation To Be Normalized")
+
+		retrieved, err := txRepo.GetWordRelationByID(relation.RelationID)
+		require.NoError(t, err, "GetWordRelationByID Should Not Error")
+		assert.Equal(t, dom.WordID, retrieved.WordID, "Expected Normalized Relation To Be Saved")
+		assert.Equal(t, "domisko", retrieved.RelatedWord.PolishWord, "Expected Swapped Related Word")
+
+		_, err = txRepo.GetOrCreateWordRelation(dom.WordID, domisko.WordID, models.RelationAugmentative)
+		require.NoError(t, err, "GetOrCreateWordRelation Should Not Error")
+		_, err = txRepo.UpdateWordRelation(relation.RelationID, models.RelationAugmentative)
+		assert.ErrorIs(t, err, repository.ErrDuplicateEntry, "Expected Error Updating Onto An Existing Relation")
+		retrieved, err = txRepo.GetWordRelationByID(relation.RelationID)
+		require.NoError(t, err, "GetWordRelationByID Should Not Error")
+		assert.Equal(t, models.RelationSeeAlso, retrieved.Type, "Expected Relation To Be Unchanged")
+	})
+}
+
+func TestDeleteWordRelation(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		cieply, err := txRepo.GetOrCreateWord("ciepły", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+		zimny, err := txRepo.GetOrCreateWord("zimny", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+
+		relation, err := txRepo.GetOrCreateWordRelation(cieply.WordID, zimny.WordID, models.RelationAntonym)
+		require.NoError(t, err, "GetOrCreateWordRelation Should Not Error")
+
+		err = txRepo.DeleteWordRelation(relation.RelationID)
+		require.NoError(t, err, "DeleteWordRelation Should Not Error")
+
+		_, err = txRepo.GetWordRelationByID(relation.RelationID)
+		assert.Error(t, err, "Expected Error Retrieving Deleted Relation")
+	})
+}
+
+func TestDeleteWordRemovesRelations(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		pisac, err := txRepo.GetOrCreateWord("pisać", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+		pisarz, err := txRepo.GetOrCreateWord("pisarz", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+
+		relation, err := txRepo.GetOrCreateWordRelation(pisarz.WordID, pisac.WordID, models.RelationDerivedFrom)
+		require.NoError(t, err, "GetOrCreateWordRelation Should Not Error")
+
+		err = txRepo.DeleteWord(pisac.WordID)
+		require.NoError(t, err, "DeleteWord Should Not Error")
+
+		_, err = txRepo.GetWordRelationByID(relation.RelationID)
+		assert.Error(t, err, "Expected Error Retrieving Relation Of Deleted Word")
+
+		relations, err := txRepo.ListWordRelations(pisarz.WordID, nil)
+		require.NoError(t, err, "ListWordRelations Should Not Error")
+		assert.Empty(t, relations, "Expected No Relations Left For 'pisarz'")
+	})
+}
+
+func TestAudioRecordings(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		word, err := txRepo.GetOrCreateWord("kot", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+
+		recording, err := txRepo.CreateAudioRecording(word.WordID, "audio/ogg", []byte("OggS"))
+		require.NoError(t, err, "CreateAudioRecording Should Not Error")
+		assert.Equal(t, int64(4), recording.Size, "Expected Size Of The Data")
+
+		recordings, err := txRepo.ListAudioRecordings(word.WordID)
+		require.NoError(t, err, "ListAudioRecordings Should Not Error")
+		require.Equal(t, 1, len(recordings), "Expected One Recording")
+		assert.Empty(t, recordings[0].Data, "Expected Data To Be Left Out When Listing")
+		assert.Equal(t, "audio/ogg", recordings[0].ContentType, "Expected Content Type")
+
+		retrieved, err := txRepo.GetAudioRecordingByID(recording.RecordingID)
+		require.NoError(t, err, "GetAudioRecordingByID Should Not Error")
+		assert.Equal(t, []byte("OggS"), retrieved.Data, "Expected Data Of The Recording")
+
+		err = txRepo.DeleteAudioRecording(recording.RecordingID)
+		require.NoError(t, err, "DeleteAudioRecording Should Not Error")
+		_, err = txRepo.GetAudioRecordingByID(recording.RecordingID)
+		assert.Error(t, err, "Expected Error Retrieving Deleted Recording")
+	})
+}
+
+func TestDeleteWordRemovesAudioRecordings(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		word, err := txRepo.GetOrCreateWord("pies", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+
+		recording, err := txRepo.CreateAudioRecording(word.WordID, "audio/mpeg", []byte("ID3"))
+		require.NoError(t, err, "CreateAudioRecording Should Not Error")
+
+		err = txRepo.DeleteWord(word.WordID)
+		require.NoError(t, err, "DeleteWord Should Not Error")
+
+		_, err = txRepo.GetAudioRecordingByID(recording.RecordingID)
+		assert.Error(t, err, "Expected Error Retrieving Recording Of Deleted Word")
+	})
+}
+
+func TestReviewCards(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		word, err := txRepo.GetOrCreateWord("kot", models.Grammar{})
+		require.NoError(t, err, "Failed to create word 'kot'")
+		translation, err := txRepo.GetOrCreateTranslation(word.WordID, "cat")
+		require.NoError(t, err, "Failed to create translation 'cat'")
+		_, err = txRepo.GetOrCreateExampleSentence(translation.TranslationID, "Kot śpi.")
+		require.NoError(t, err, "Failed to create example sentence")
+		now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+		scheduler := srs.Scheduler{Clock: srs.ClockFunc(func() time.Time { return now })}
+
+		card, err := txRepo.GetOrCreateReviewCard("ania", translation.TranslationID, scheduler.New())
+		require.NoError(t, err, "GetOrCreateReviewCard should not error")
+		assert.Equal(t, "cat", card.Translation.EnglishTranslation, "Translation should be preloaded")
+		assert.Equal(t, 1, len(card.Translation.ExampleSentences), "Example sentences should be preloaded")
+
+		again, err := txRepo.GetOrCreateReviewCard("ania", translation.TranslationID, scheduler.New())
+		require.NoError(t, err, "GetOrCreateReviewCard should not error")
+		assert.Equal(t, card.CardID, again.CardID, "Expected the existing card")
+
+		due, err := txRepo.ListDueReviewCards("ania", now, 10)
+		require.NoError(t, err, "ListDueReviewCards should not error")
+		require.Equal(t, 1, len(due), "Expected the new card to be due")
+		due, err = txRepo.ListDueReviewCards("tomek", now, 10)
+		require.NoError(t, err, "ListDueReviewCards should not error")
+		assert.Empty(t, due, "Expected no cards of another learner")
+
+		state, err := scheduler.Review(card.State(), 4)
+		require.NoError(t, err, "Review should not error")
+		reviewed, err := txRepo.RecordReview(card.CardID, 4, state, now)
+		require.NoError(t, err, "RecordReview should not error")
+		assert.Equal(t, 1, reviewed.IntervalDays, "Expected one day interval")
+		require.NotNil(t, reviewed.LastReviewed, "Expected review time")
+
+		due, err = txRepo.ListDueReviewCards("ania", now, 10)
+		require.NoError(t, err, "ListDueReviewCards should not error")
+		assert.Empty(t, due, "Expected the reviewed card not to be due")
+
+		logs, err := txRepo.ListReviewLogs(card.CardID)
+		require.NoError(t, err, "ListReviewLogs should not error")
+		require.Equal(t, 1, len(logs), "Expected one review log")
+		assert.Equal(t, 4, logs[0].Grade, "Expected grade 4")
+
+		_, err = txRepo.RecordReview(card.CardID+1000, 4, state, now)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected error for a missing card")
+
+		err = txRepo.DeleteTranslation(translation.TranslationID)
+		require.NoError(t, err, "DeleteTranslation should not error")
+		_, err = txRepo.GetReviewCardByID(card.CardID)
+		assert.Error(t, err, "Expected the card to be hidden with its translation")
+
+		_, err = txRepo.PurgeDeleted(time.Now().Add(time.Minute))
+		require.NoError(t, err, "PurgeDeleted should not error")
+		logs, err = txRepo.ListReviewLogs(card.CardID)
+		require.NoError(t, err, "ListReviewLogs should not error")
+		assert.Empty(t, logs, "Expected the review logs to be purged")
+	})
+}
+
+func TestUsers(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		user, err := txRepo.CreateUser("ania", "hash")
+		require.NoError(t, err, "CreateUser should not error")
+
+		byName, err := txRepo.GetUserByUsername("ania")
+		require.NoError(t, err, "GetUserByUsername should not error")
+		assert.Equal(t, user.UserID, byName.UserID, "Expected the created user")
+
+		byID, err := txRepo.GetUserByID(user.UserID)
+		require.NoError(t, err, "GetUserByID should not error")
+		assert.Equal(t, "hash", byID.PasswordHash, "Expected the password hash")
+
+		assert.Equal(t, models.RoleViewer, byID.Role, "Expected new users to be viewers")
+
+		_, err = txRepo.GetUserByUsername("tomek")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected unknown user to be not found")
+
+		updated, err := txRepo.UpdateUserRole(user.UserID, models.RoleEditor)
+		require.NoError(t, err, "UpdateUserRole should not error")
+		assert.Equal(t, models.RoleEditor, updated.Role, "Expected the editor role")
+		_, err = txRepo.UpdateUserRole(user.UserID, "owner")
+		assert.Error(t, err, "Expected error for an unknown role")
+
+		users, err := txRepo.ListUsers()
+		require.NoError(t, err, "ListUsers should not error")
+		assert.Equal(t, 1, len(users), "Expected one user")
+
+		grouped, err := txRepo.UpdateUserGroup(user.UserID, "3b")
+		require.NoError(t, err, "UpdateUserGroup should not error")
+		assert.Equal(t, []models.Owner{models.UserOwner(user.UserID), models.GroupOwner("3b")}, grouped.Owners(),
+			"Expected the user and group owners")
+	})
+}
+
+func TestScopes(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		user, err := txRepo.CreateUser("ania", "hash")
+		require.NoError(t, err, "CreateUser should not error")
+		ania := models.UserOwner(user.UserID)
+		private := txRepo.WithScope(repository.Scope{Owners: []models.Owner{ania}, Owner: ania})
+		stranger := txRepo.WithScope(repository.Scope{Owners: []models.Owner{models.UserOwner(user.UserID + 1)}})
+
+		shared, err := txRepo.GetOrCreateWord("kot", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		_, err = txRepo.GetOrCreateTranslation(shared.WordID, "cat")
+		require.NoError(t, err, "GetOrCreateTranslation should not error")
+
+		reused, err := private.GetOrCreateWord("kot", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord should not error in a private scope")
+		assert.Equal(t, shared.WordID, reused.WordID, "Expected the shared word to be reused")
+		note, err := private.GetOrCreateTranslation(shared.WordID, "kitty")
+		require.NoError(t, err, "GetOrCreateTranslation should not error in a private scope")
+		assert.Equal(t, ania, note.Owner, "Expected a private translation")
+
+		merged, err := private.GetWordByID(shared.WordID)
+		require.NoError(t, err, "GetWordByID should not error")
+		assert.Equal(t, 2, len(merged.Translations), "Expected shared and private translations")
+		public, err := txRepo.GetWordByID(shared.WordID)
+		require.NoError(t, err, "GetWordByID should not error")
+		assert.Equal(t, 1, len(public.Translations), "Expected only the shared translation")
+		_, err = stranger.GetTranslationByID(note.TranslationID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected private translation to be hidden from others")
+
+		kocur, err := private.GetOrCreateWord("kocur", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		assert.Equal(t, ania, kocur.Owner, "Expected a private word")
+		words, err := txRepo.ListWords(repository.WordOrder{}, repository.WordFilter{})
+		require.NoError(t, err, "ListWords should not error")
+		assert.Equal(t, 1, len(words), "Expected only the shared word")
+		_, err = txRepo.WithScope(repository.Scope{Owners: []models.Owner{ania}}).
+			GetOrCreateTranslation(kocur.WordID, "tomcat")
+		assert.ErrorIs(t, err, repository.ErrPrivateParent, "Expected shared translation of a private word to fail")
+
+		tomcat, err := private.GetOrCreateTranslation(kocur.WordID, "tomcat")
+		require.NoError(t, err, "GetOrCreateTranslation should not error")
+		err = private.PromoteEntry(models.EntryWord, shared.WordID)
+		assert.ErrorIs(t, err, repository.ErrAlreadyShared, "Expected shared word not to be promoted")
+		err = txRepo.PromoteEntry(models.EntryTranslation, tomcat.TranslationID)
+		require.NoError(t, err, "PromoteEntry should not error")
+		promoted, err := txRepo.GetWordByID(kocur.WordID)
+		require.NoError(t, err, "Expected the word of the translation to be promoted")
+		assert.Equal(t, "tomcat", promoted.Translations[0].EnglishTranslation, "Expected the promoted translation")
+
+		_, err = txRepo.GetOrCreateTranslation(shared.WordID, "kitty")
+		require.NoError(t, err, "GetOrCreateTranslation should not error")
+		err = txRepo.PromoteEntry(models.EntryTranslation, note.TranslationID)
+		assert.ErrorIs(t, err, repository.ErrDuplicateEntry, "Expected a translation equal to a shared one not to be promoted")
+		pies, err := private.GetOrCreateWord("pies", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		_, err = txRepo.GetOrCreateWord("pies", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		err = txRepo.PromoteEntry(models.EntryWord, pies.WordID)
+		assert.ErrorIs(t, err, repository.ErrDuplicateEntry, "Expected a word equal to a shared one not to be promoted")
+	})
+}
+
+func TestScopedRelationsAndAudio(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		user, err := txRepo.CreateUser("ania", "hash")
+		require.NoError(t, err, "CreateUser should not error")
+		ania := models.UserOwner(user.UserID)
+		private := txRepo.WithScope(repository.Scope{Owners: []models.Owner{ania}, Owner: ania})
+
+		kot, err := txRepo.GetOrCreateWord("kot", models.Grammar{})
+		require.NoError(t, err, "Failed to create word 'kot'")
+		kocur, err := private.GetOrCreateWord("kocur", models.Grammar{})
+		require.NoError(t, err, "Failed to create private word 'kocur'")
+
+		_, err = txRepo.GetOrCreateWordRelation(kot.WordID, kocur.WordID, models.RelationSynonym)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected relation to a hidden word to fail")
+		_, err = txRepo.CreateAudioRecording(kocur.WordID, "audio/ogg", []byte("OggS"))
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected audio of a hidden word to fail")
+
+		_, err = private.GetOrCreateWordRelation(kot.WordID, kocur.WordID, models.RelationSynonym)
+		require.NoError(t, err, "GetOrCreateWordRelation should not error in a private scope")
+		_, err = private.CreateAudioRecording(kocur.WordID, "audio/ogg", []byte("OggS"))
+		require.NoError(t, err, "CreateAudioRecording should not error in a private scope")
+
+		relations, err := private.ListWordRelations(kot.WordID, nil)
+		require.NoError(t, err, "ListWordRelations should not error")
+		assert.Equal(t, 1, len(relations), "Expected the relation to be visible to its owner")
+		relations, err = txRepo.ListWordRelations(kot.WordID, nil)
+		require.NoError(t, err, "ListWordRelations should not error")
+		assert.Empty(t, relations, "Expected the relation to a private word to be hidden")
+		recordings, err := txRepo.ListAudioRecordings(kocur.WordID)
+		require.NoError(t, err, "ListAudioRecordings should not error")
+		assert.Empty(t, recordings, "Expected audio of a private word to be hidden")
+
+		tags := models.InflectionTags{Case: models.CaseGenitive, Number: models.NumberSingular}
+		_, err = txRepo.GetOrCreateInflection(kocur.WordID, "kocura", tags)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected inflection of a hidden word to fail")
+		inflection, err := private.GetOrCreateInflection(kocur.WordID, "kocura", tags)
+		require.NoError(t, err, "GetOrCreateInflection should not error in a private scope")
+		_, err = txRepo.GetInflectionByID(inflection.InflectionID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected inflection of a private word to be hidden")
+		_, err = txRepo.UpdateInflection(inflection.InflectionID, "kocurowi", tags)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected inflection of a private word not to be updated")
+		err = txRepo.DeleteInflection(inflection.InflectionID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected inflection of a private word not to be deleted")
+		inflections, err := txRepo.ListInflections(kocur.WordID)
+		require.NoError(t, err, "ListInflections should not error")
+		assert.Empty(t, inflections, "Expected inflections of a private word to be hidden")
+
+		relations, err = private.ListWordRelations(kot.WordID, nil)
+		require.NoError(t, err, "ListWordRelations should not error")
+		err = txRepo.DeleteWordRelation(relations[0].RelationID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected the relation to a private word not to be deleted")
+		recordings, err = private.ListAudioRecordings(kocur.WordID)
+		require.NoError(t, err, "ListAudioRecordings should not error")
+		err = txRepo.DeleteAudioRecording(recordings[0].RecordingID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected audio of a private word not to be deleted")
+		err = private.DeleteAudioRecording(recordings[0].RecordingID)
+		assert.NoError(t, err, "DeleteAudioRecording should not error in a private scope")
+	})
+}
+
+func TestChangeProposals(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		user, err := txRepo.CreateUser("ania", "hash")
+		require.NoError(t, err, "CreateUser should not error")
+		editor, err := txRepo.CreateUser("ola", "hash")
+		require.NoError(t, err, "CreateUser should not error")
+
+		var ids []uint
+		for _, text := range []string{"kot", "pies", "dom"} {
+			proposal, err := txRepo.CreateChangeProposal(models.ChangeProposal{
+				Action:     models.ActionCreate,
+				EntryType:  models.EntryWord,
+				Change:     `{"action":"create","entryType":"word","fields":{"polishWord":"` + text + `"}}`,
+				Diff:       []models.FieldChange{{Field: "polishWord", After: text}},
+				ProposedBy: user.UserID,
+			})
+			require.NoError(t, err, "CreateChangeProposal should not error")
+			assert.Equal(t, models.ProposalPending, proposal.Status, "Expected a pending proposal")
+			assert.Equal(t, "ania", proposal.Proposer.Username, "Expected the proposer")
+			ids = append(ids, proposal.ProposalID)
+		}
+
+		found, err := txRepo.GetChangeProposalByID(ids[0])
+		require.NoError(t, err, "GetChangeProposalByID should not error")
+		assert.Equal(t, []models.FieldChange{{Field: "polishWord", After: "kot"}}, found.Diff, "Expected the stored diff")
+
+		rejected, err := txRepo.ReviewChangeProposal(ids[1], models.ProposalRejected, editor.UserID, "Duplicate", time.Now())
+		require.NoError(t, err, "ReviewChangeProposal should not error")
+		assert.Equal(t, models.ProposalRejected, rejected.Status, "Expected the proposal to be rejected")
+		assert.Equal(t, "Duplicate", rejected.Comment, "Expected the comment of the reviewer")
+		require.NotNil(t, rejected.Reviewer, "Expected the reviewer to be preloaded")
+		assert.Equal(t, "ola", rejected.Reviewer.Username, "Expected the reviewer")
+
+		_, err = txRepo.ReviewChangeProposal(ids[1], models.ProposalApproved, editor.UserID, "", time.Now())
+		assert.ErrorIs(t, err, repository.ErrProposalReviewed, "Expected reviewed proposal not to be reviewed again")
+		_, err = txRepo.ReviewChangeProposal(ids[0], models.ProposalPending, editor.UserID, "", time.Now())
+		assert.Error(t, err, "Expected pending status to be rejected")
+
+		page, err := txRepo.ListChangeProposalsPage(models.ProposalPending, repository.PageArgs{First: 1})
+		require.NoError(t, err, "ListChangeProposalsPage should not error")
+		assert.Equal(t, int64(2), page.TotalCount, "Expected two pending proposals")
+		require.Equal(t, 1, len(page.Items), "Expected one proposal on the page")
+		assert.Equal(t, ids[0], page.Items[0].ProposalID, "Expected the oldest proposal first")
+		next, err := txRepo.ListChangeProposalsPage(models.ProposalPending, repository.PageArgs{First: 1, After: page.Cursors[0]})
+		require.NoError(t, err, "ListChangeProposalsPage should not error")
+		require.Equal(t, 1, len(next.Items), "Expected one proposal on the next page")
+		assert.Equal(t, ids[2], next.Items[0].ProposalID, "Expected the next pending proposal")
+
+		// Diffs compare proposed changes with the dictionary
+		kot, err := txRepo.GetOrCreateWord("kot", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun, Gender: models.GenderMasculineAnimate})
+		require.NoError(t, err, "Failed to create word 'kot'")
+		cat, err := txRepo.GetOrCreateTranslation(kot.WordID, "cat")
+		require.NoError(t, err, "Failed to create translation 'cat'")
+		sentence, err := txRepo.GetOrCreateExampleSentence(cat.TranslationID, "Widzę kota.")
+		require.NoError(t, err, "Failed to create example sentence")
+
+		polishWord := "kot"
+		diff, err := proposal.Diff(txRepo, proposal.Change{
+			Action:    models.ActionUpdate,
+			EntryType: models.EntryWord,
+			EntryID:   kot.WordID,
+			Fields: proposal.Fields{
+				PolishWord: &polishWord,
+				Grammar:    &models.Grammar{PartOfSpeech: models.PartOfSpeechNoun, Gender: models.GenderFeminine},
+			},
+		})
+		require.NoError(t, err, "Diff should not error")
+		assert.Equal(t, []models.FieldChange{{Field: "gender", Before: "masculine_animate", After: "feminine"}}, diff,
+			"Expected only the changed fields")
+		same := "cat"
+		_, err = proposal.Diff(txRepo, proposal.Change{
+			Action:    models.ActionUpdate,
+			EntryType: models.EntryTranslation,
+			EntryID:   cat.TranslationID,
+			Fields:    proposal.Fields{EnglishTranslation: &same},
+		})
+		assert.ErrorIs(t, err, proposal.ErrNoChange, "Expected an update without changes to fail")
+		diff, err = proposal.Diff(txRepo, proposal.Change{Action: models.ActionDelete, EntryType: models.EntryWord, EntryID: kot.WordID})
+		require.NoError(t, err, "Diff should not error")
+		assert.Equal(t, []models.FieldChange{
+			{Field: "polishWord", Before: "kot"},
+			{Field: "englishTranslation", Before: "cat"},
+			{Field: "exampleSentence", Before: "Widzę kota."},
+		}, diff, "Expected the deleted word with its translations and example sentences")
+
+		// Approved proposals are replayed, rejected ones are not
+		pies, dog := "pies", "dog"
+		create := proposal.Change{
+			Action:    models.ActionCreate,
+			EntryType: models.EntryTranslation,
+			Fields: proposal.Fields{
+				PolishWord:         &pies,
+				Grammar:            &models.Grammar{PartOfSpeech: models.PartOfSpeechNoun},
+				EnglishTranslation: &dog,
+				ExampleSentences:   []string{"Pies szczeka."},
+			},
+		}
+		created, err := proposal.Propose(txRepo, create, user.UserID)
+		require.NoError(t, err, "Propose should not error")
+		assert.Equal(t, []models.FieldChange{
+			{Field: "polishWord", After: "pies"},
+			{Field: "partOfSpeech", After: "noun"},
+			{Field: "englishTranslation", After: "dog"},
+			{Field: "exampleSentence", After: "Pies szczeka."},
+		}, created.Diff, "Expected the added word, translation and example sentence")
+		decoded, err := proposal.Decode(created)
+		require.NoError(t, err, "Decode should not error")
+		assert.Equal(t, create, decoded, "Expected the stored change")
+		_, err = txRepo.GetWordByPolish("pies", nil)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected proposing not to change the dictionary")
+
+		approved, err := proposal.Review(txRepo, created.ProposalID, editor.UserID, true, "Looks good", time.Now())
+		require.NoError(t, err, "Review should not error")
+		assert.Equal(t, models.ProposalApproved, approved.Status, "Expected the proposal to be approved")
+		word, err := txRepo.GetWordByPolish("pies", nil)
+		require.NoError(t, err, "Expected the approved word to be created")
+		require.Equal(t, 1, len(word.Translations), "Expected the approved translation")
+		assert.Equal(t, "dog", word.Translations[0].EnglishTranslation, "Expected translation 'dog'")
+		require.Equal(t, 1, len(word.Translations[0].ExampleSentences), "Expected the approved example sentence")
+		_, err = proposal.Review(txRepo, created.ProposalID, editor.UserID, false, "", time.Now())
+		assert.ErrorIs(t, err, repository.ErrProposalReviewed, "Expected reviewed proposal not to be reviewed again")
+
+		kitty := "kitty"
+		update, err := proposal.Propose(txRepo, proposal.Change{
+			Action:    models.ActionUpdate,
+			EntryType: models.EntryTranslation,
+			EntryID:   cat.TranslationID,
+			Fields:    proposal.Fields{EnglishTranslation: &kitty},
+		}, user.UserID)
+		require.NoError(t, err, "Propose should not error")
+		assert.Equal(t, []models.FieldChange{{Field: "englishTranslation", Before: "cat", After: "kitty"}}, update.Diff, "Expected the diff")
+		_, err = proposal.Review(txRepo, update.ProposalID, editor.UserID, false, "Too informal", time.Now())
+		require.NoError(t, err, "Review should not error")
+		unchanged, err := txRepo.GetTranslationByID(cat.TranslationID)
+		require.NoError(t, err, "GetTranslationByID should not error")
+		assert.Equal(t, "cat", unchanged.EnglishTranslation, "Expected the rejected change not to be applied")
+
+		// Word creations ignoring diacritics reuse the existing spelling
+		_, err = txRepo.GetOrCreateWord("żółw", models.Grammar{})
+		require.NoError(t, err, "Failed to create word 'żółw'")
+		zolw := "zolw"
+		folded, err := proposal.Propose(txRepo, proposal.Change{
+			Action:    models.ActionCreate,
+			EntryType: models.EntryWord,
+			Fields:    proposal.Fields{PolishWord: &zolw, IgnoreDiacritics: true},
+		}, user.UserID)
+		require.NoError(t, err, "Propose should not error")
+		_, err = proposal.Review(txRepo, folded.ProposalID, editor.UserID, true, "", time.Now())
+		require.NoError(t, err, "Review should not error")
+		words, err := txRepo.ListWordsByFoldedPolish("zolw")
+		require.NoError(t, err, "ListWordsByFoldedPolish should not error")
+		require.Equal(t, 1, len(words), "Expected no new word")
+		assert.Equal(t, "żółw", words[0].PolishWord, "Expected the existing spelling")
+
+		// Promotions list the private entries they share
+		ania := models.UserOwner(user.UserID)
+		private := txRepo.WithScope(repository.Scope{Owners: []models.Owner{ania}, Owner: ania})
+		_, err = proposal.Diff(private, proposal.Change{Action: models.ActionPromote, EntryType: models.EntryExampleSentence, EntryID: sentence.SentenceID})
+		assert.ErrorIs(t, err, repository.ErrAlreadyShared, "Expected shared sentence not to be promoted")
+		puss, err := private.GetOrCreateTranslation(kot.WordID, "puss")
+		require.NoError(t, err, "Failed to create private translation 'puss'")
+		note, err := private.GetOrCreateExampleSentence(puss.TranslationID, "Kot mruczy.")
+		require.NoError(t, err, "Failed to create private example sentence")
+		promotion, err := proposal.Propose(private, proposal.Change{
+			Action:    models.ActionPromote,
+			EntryType: models.EntryExampleSentence,
+			EntryID:   note.SentenceID,
+		}, user.UserID)
+		require.NoError(t, err, "Propose should not error")
+		assert.Equal(t, []models.FieldChange{
+			{Field: "englishTranslation", After: "puss"},
+			{Field: "sentenceText", After: "Kot mruczy."},
+		}, promotion.Diff, "Expected the private translation and example sentence")
+		_, err = proposal.Review(txRepo, promotion.ProposalID, editor.UserID, true, "", time.Now())
+		require.NoError(t, err, "Review should not error")
+		promoted, err := txRepo.GetTranslationByID(puss.TranslationID)
+		require.NoError(t, err, "Expected the promoted translation to be shared")
+		assert.True(t, promoted.Owner.Shared(), "Expected a shared translation")
+	})
+}
+
+func TestRevisions(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		user, err := txRepo.CreateUser("ania", "hash")
+		require.NoError(t, err, "CreateUser should not error")
+		repo := txRepo.WithScope(repository.Scope{Author: user.UserID})
+
+		word, err := repo.GetOrCreateWord("kot", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		_, err = repo.GetOrCreateWord("kot", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		translation, err := repo.GetOrCreateTranslation(word.WordID, "cat")
+		require.NoError(t, err, "GetOrCreateTranslation should not error")
+		sentence, err := repo.GetOrCreateExampleSentence(translation.TranslationID, "Widzę kota.")
+		require.NoError(t, err, "GetOrCreateExampleSentence should not error")
+		_, err = repo.UpdateTranslation(translation.TranslationID, "kitty")
+		require.NoError(t, err, "UpdateTranslation should not error")
+
+		revisions, err := repo.ListRevisions(models.EntryWord, word.WordID)
+		require.NoError(t, err, "ListRevisions should not error")
+		require.Equal(t, 1, len(revisions), "Expected an existing word not to be recorded again")
+		assert.Equal(t, models.RevisionCreate, revisions[0].Action, "Expected the creation of the word")
+		require.NotNil(t, revisions[0].Author, "Expected the author to be preloaded")
+		assert.Equal(t, "ania", revisions[0].Author.Username, "Expected the author")
+
+		revisions, err = repo.ListRevisions(models.EntryTranslation, translation.TranslationID)
+		require.NoError(t, err, "ListRevisions should not error")
+		require.Equal(t, 2, len(revisions), "Expected the creation and the update")
+		assert.Equal(t, models.RevisionUpdate, revisions[0].Action, "Expected the newest revision first")
+		assert.Equal(t, "cat", revisions[0].Before.Translation.EnglishTranslation, "Expected the previous text")
+		assert.Equal(t, "kitty", revisions[0].After.Translation.EnglishTranslation, "Expected the new text")
+
+		reverted, err := repo.RevertToRevision(revisions[1].RevisionID)
+		require.NoError(t, err, "RevertToRevision should not error")
+		assert.Equal(t, models.RevisionUpdate, reverted.Action, "Expected the revert to be recorded as an update")
+		restored, err := repo.GetTranslationByID(translation.TranslationID)
+		require.NoError(t, err, "GetTranslationByID should not error")
+		assert.Equal(t, "cat", restored.EnglishTranslation, "Expected the text of the created translation")
+
+		require.NoError(t, repo.DeleteWord(word.WordID), "DeleteWord should not error")
+		revisions, err = repo.ListRevisions(models.EntryWord, word.WordID)
+		require.NoError(t, err, "ListRevisions should not error")
+		assert.Equal(t, models.RevisionDelete, revisions[0].Action, "Expected the deletion of the word")
+		assert.Nil(t, revisions[0].After, "Expected no state after the deletion")
+		for entryType, entryID := range map[models.EntryType]uint{
+			models.EntryTranslation:     translation.TranslationID,
+			models.EntryExampleSentence: sentence.SentenceID,
+		} {
+			cascaded, err := repo.ListRevisions(entryType, entryID)
+			require.NoError(t, err, "ListRevisions should not error")
+			assert.Equal(t, models.RevisionDelete, cascaded[0].Action, "Expected the deletion of the %s with the word", entryType)
+		}
+
+		reverted, err = repo.RevertToRevision(revisions[0].RevisionID)
+		require.NoError(t, err, "RevertToRevision should not error")
+		assert.Equal(t, models.RevisionRestore, reverted.Action, "Expected the deleted word to be restored from the trash")
+
+		require.NoError(t, repo.DeleteWord(word.WordID), "DeleteWord should not error")
+		_, err = repo.PurgeDeleted(time.Now().Add(time.Minute))
+		require.NoError(t, err, "PurgeDeleted should not error")
+		revisions, err = repo.ListRevisions(models.EntryWord, word.WordID)
+		require.NoError(t, err, "ListRevisions should not error")
+		reverted, err = repo.RevertToRevision(revisions[0].RevisionID)
+		require.NoError(t, err, "RevertToRevision should not error")
+		assert.Equal(t, models.RevisionCreate, reverted.Action, "Expected the purged word to be recreated")
+		recreated, err := repo.GetWordByID(word.WordID)
+		require.NoError(t, err, "Expected the word to be recreated with its ID")
+		require.Equal(t, 1, len(recreated.Translations), "Expected the deleted translation to be recreated")
+		assert.Equal(t, "cat", recreated.Translations[0].EnglishTranslation, "Expected the recreated translation")
+		assert.Equal(t, 1, len(recreated.Translations[0].ExampleSentences), "Expected the deleted sentence to be recreated")
+		for entryType, entryID := range map[models.EntryType]uint{
+			models.EntryTranslation:     translation.TranslationID,
+			models.EntryExampleSentence: sentence.SentenceID,
+		} {
+			recreatedRevisions, err := repo.ListRevisions(entryType, entryID)
+			require.NoError(t, err, "ListRevisions should not error")
+			assert.Equal(t, models.RevisionCreate, recreatedRevisions[0].Action, "Expected the recreation of the %s with the word", entryType)
+			assert.NotNil(t, recreatedRevisions[0].After, "Expected the state of the recreated %s", entryType)
+		}
+
+		private, err := txRepo.WithScope(repository.Scope{Owner: models.UserOwner(user.UserID), Owners: user.Owners()}).
+			GetOrCreateWord("kocur", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		_, err = txRepo.ListRevisions(models.EntryWord, private.WordID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected the history of private words to be hidden")
+		revisions, err = txRepo.WithScope(repository.Scope{Owners: user.Owners()}).ListRevisions(models.EntryWord, private.WordID)
+		require.NoError(t, err, "ListRevisions should not error for the owner")
+		_, err = txRepo.GetRevisionByID(revisions[0].RevisionID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected the revisions of private words to be hidden")
+
+		require.NoError(t, txRepo.PromoteEntry(models.EntryWord, private.WordID), "PromoteEntry should not error")
+		_, err = txRepo.GetRevisionByID(revisions[0].RevisionID)
+		assert.NoError(t, err, "Expected the private revisions of a promoted word to be visible")
+	})
+}
+
+func TestTrash(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		word, err := txRepo.GetOrCreateWord("koń", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		horse, err := txRepo.GetOrCreateTranslation(word.WordID, "horse")
+		require.NoError(t, err, "GetOrCreateTranslation should not error")
+		sentence, err := txRepo.GetOrCreateExampleSentence(horse.TranslationID, "Koń biegnie.")
+		require.NoError(t, err, "GetOrCreateExampleSentence should not error")
+		steed, err := txRepo.GetOrCreateTranslation(word.WordID, "steed")
+		require.NoError(t, err, "GetOrCreateTranslation should not error")
+		inflection, err := txRepo.GetOrCreateInflection(word.WordID, "konia", models.InflectionTags{Case: models.CaseGenitive})
+		require.NoError(t, err, "GetOrCreateInflection should not error")
+
+		require.NoError(t, txRepo.DeleteTranslation(steed.TranslationID), "DeleteTranslation should not error")
+		require.NoError(t, txRepo.DeleteWord(word.WordID), "DeleteWord should not error")
+		_, err = txRepo.GetInflectionByID(inflection.InflectionID)
+		assert.Error(t, err, "Expected the inflection of a deleted word to be hidden")
+
+		trash, err := txRepo.ListTrash()
+		require.NoError(t, err, "ListTrash should not error")
+		require.Equal(t, 1, len(trash.Words), "Expected the deleted word in the trash")
+		assert.Equal(t, word.WordID, trash.Words[0].WordID, "Expected the deleted word")
+		require.Equal(t, 1, len(trash.Translations), "Expected only the translation deleted on its own")
+		assert.Equal(t, steed.TranslationID, trash.Translations[0].TranslationID, "Expected the translation deleted first")
+		assert.Empty(t, trash.ExampleSentences, "Expected the sentence to be restored with its word")
+
+		_, err = txRepo.RestoreTranslation(steed.TranslationID)
+		assert.ErrorIs(t, err, repository.ErrDeletedParent, "Expected the word to be restored first")
+
+		restored, err := txRepo.RestoreWord(word.WordID)
+		require.NoError(t, err, "RestoreWord should not error")
+		require.Equal(t, 1, len(restored.Translations), "Expected the translation deleted with the word to be restored")
+		assert.Equal(t, "horse", restored.Translations[0].EnglishTranslation, "Expected the translation deleted with the word")
+		_, err = txRepo.GetExampleSentenceByID(sentence.SentenceID)
+		assert.NoError(t, err, "Expected the sentence deleted with the word to be restored")
+		_, err = txRepo.GetInflectionByID(inflection.InflectionID)
+		assert.NoError(t, err, "Expected the inflection to be visible again")
+
+		revisions, err := txRepo.ListRevisions(models.EntryWord, word.WordID)
+		require.NoError(t, err, "ListRevisions should not error")
+		assert.Equal(t, models.RevisionRestore, revisions[0].Action, "Expected the restore to be recorded")
+
+		// A deleted entry does not block creating it again.
+		require.NoError(t, txRepo.DeleteExampleSentence(sentence.SentenceID), "DeleteExampleSentence should not error")
+		recreated, err := txRepo.GetOrCreateExampleSentence(horse.TranslationID, "Koń biegnie.")
+		require.NoError(t, err, "GetOrCreateExampleSentence should not error")
+		assert.NotEqual(t, sentence.SentenceID, recreated.SentenceID, "Expected a new sentence")
+		_, err = txRepo.RestoreExampleSentence(sentence.SentenceID)
+		assert.ErrorIs(t, err, repository.ErrDuplicateEntry, "Expected the equal sentence to block the restore")
+		_, err = txRepo.GetOrCreateTranslation(word.WordID, "steed")
+		require.NoError(t, err, "GetOrCreateTranslation should not error")
+		_, err = txRepo.RestoreTranslation(steed.TranslationID)
+		assert.ErrorIs(t, err, repository.ErrDuplicateEntry, "Expected the equal translation to block the restore")
+
+		purged, err := txRepo.PurgeDeleted(time.Now().Add(-time.Hour))
+		require.NoError(t, err, "PurgeDeleted should not error")
+		assert.Equal(t, int64(0), purged, "Expected recently deleted entries to be kept")
+		purged, err = txRepo.PurgeDeleted(time.Now().Add(time.Minute))
+		require.NoError(t, err, "PurgeDeleted should not error")
+		assert.Equal(t, int64(2), purged, "Expected the deleted translation and sentence to be purged")
+
+		trash, err = txRepo.ListTrash()
+		require.NoError(t, err, "ListTrash should not error")
+		assert.Empty(t, trash.Translations, "Expected the trash to be empty")
+		assert.Empty(t, trash.ExampleSentences, "Expected the trash to be empty")
+
+		require.NoError(t, txRepo.DeleteWord(word.WordID), "DeleteWord should not error")
+		_, err = txRepo.GetOrCreateWord("koń", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		_, err = txRepo.RestoreWord(word.WordID)
+		assert.ErrorIs(t, err, repository.ErrDuplicateEntry, "Expected the equal word to block the restore")
+	})
+}
+
+func TestMoveEntries(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		kot, err := txRepo.GetOrCreateWord("kot", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		kocur, err := txRepo.GetOrCreateWord("kocur", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		cat, err := txRepo.GetOrCreateTranslation(kot.WordID, "cat")
+		require.NoError(t, err, "GetOrCreateTranslation should not error")
+		sentence, err := txRepo.GetOrCreateExampleSentence(cat.TranslationID, "Kot śpi.")
+		require.NoError(t, err, "GetOrCreateExampleSentence should not error")
+		tomcat, err := txRepo.GetOrCreateTranslation(kocur.WordID, "tomcat")
+		require.NoError(t, err, "GetOrCreateTranslation should not error")
+
+		moved, err := txRepo.UpdateTranslationWord(cat.TranslationID, kocur.WordID)
+		require.NoError(t, err, "UpdateTranslationWord should not error")
+		assert.Equal(t, kocur.WordID, moved.WordID, "Expected the translation to belong to the target word")
+		retrieved, err := txRepo.GetWordByID(kocur.WordID)
+		require.NoError(t, err, "GetWordByID should not error")
+		assert.Equal(t, 2, len(retrieved.Translations), "Expected the moved translation on the target word")
+
+		movedSentence, err := txRepo.UpdateExampleSentenceTranslation(sentence.SentenceID, tomcat.TranslationID)
+		require.NoError(t, err, "UpdateExampleSentenceTranslation should not error")
+		assert.Equal(t, tomcat.TranslationID, movedSentence.TranslationID, "Expected the sentence to belong to the target translation")
+
+		revisions, err := txRepo.ListRevisions(models.EntryTranslation, cat.TranslationID)
+		require.NoError(t, err, "ListRevisions should not error")
+		assert.Equal(t, models.RevisionUpdate, revisions[0].Action, "Expected the move to be recorded")
+		assert.Equal(t, kot.WordID, revisions[0].Before.Translation.WordID, "Expected the previous word")
+
+		owner := models.UserOwner(7)
+		private, err := txRepo.WithScope(repository.Scope{Owner: owner, Owners: []models.Owner{owner}}).
+			GetOrCreateWord("kiciuś", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		_, err = txRepo.WithScope(repository.Scope{Owners: []models.Owner{owner}}).UpdateTranslationWord(cat.TranslationID, private.WordID)
+		assert.ErrorIs(t, err, repository.ErrPrivateParent, "Expected shared translations not to be moved to private words")
+
+		kotCat, err := txRepo.GetOrCreateTranslation(kot.WordID, "cat")
+		require.NoError(t, err, "GetOrCreateTranslation should not error")
+		_, err = txRepo.UpdateTranslationWord(kotCat.TranslationID, kocur.WordID)
+		assert.ErrorIs(t, err, repository.ErrDuplicateEntry, "Expected moving onto an equal translation to fail")
+	})
+}
+
+func TestMergeWords(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		kot, err := txRepo.GetOrCreateWord("kot", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		kocur, err := txRepo.GetOrCreateWord("kocur", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		kotek, err := txRepo.GetOrCreateWord("kotek", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		pies, err := txRepo.GetOrCreateWord("pies", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+
+		cat, err := txRepo.GetOrCreateTranslation(kot.WordID, "cat")
+		require.NoError(t, err, "GetOrCreateTranslation should not error")
+		for _, text := range []string{"Kot śpi.", "Kot je."} {
+			_, err = txRepo.GetOrCreateExampleSentence(cat.TranslationID, text)
+			require.NoError(t, err, "GetOrCreateExampleSentence should not error")
+		}
+		tomcat, err := txRepo.GetOrCreateTranslation(kot.WordID, "tomcat")
+		require.NoError(t, err, "GetOrCreateTranslation should not error")
+		_, err = txRepo.GetOrCreateExampleSentence(tomcat.TranslationID, "Kot miauczy.")
+		require.NoError(t, err, "GetOrCreateExampleSentence should not error")
+		targetCat, err := txRepo.GetOrCreateTranslation(kocur.WordID, "cat")
+		require.NoError(t, err, "GetOrCreateTranslation should not error")
+		_, err = txRepo.GetOrCreateExampleSentence(targetCat.TranslationID, "Kot śpi.")
+		require.NoError(t, err, "GetOrCreateExampleSentence should not error")
+
+		genitive := models.InflectionTags{Case: models.CaseGenitive, Number: models.NumberSingular}
+		dative := models.InflectionTags{Case: models.CaseDative, Number: models.NumberSingular}
+		_, err = txRepo.GetOrCreateInflection(kot.WordID, "kota", genitive)
+		require.NoError(t, err, "GetOrCreateInflection should not error")
+		_, err = txRepo.GetOrCreateInflection(kot.WordID, "kotu", dative)
+		require.NoError(t, err, "GetOrCreateInflection should not error")
+		_, err = txRepo.GetOrCreateInflection(kocur.WordID, "kota", genitive)
+		require.NoError(t, err, "GetOrCreateInflection should not error")
+
+		relations := []struct {
+			wordID, relatedWordID uint
+			relationType          models.RelationType
+		}{
+			{kot.WordID, kocur.WordID, models.RelationSynonym},
+			{kotek.WordID, kot.WordID, models.RelationDiminutive},
+			{kot.WordID, pies.WordID, models.RelationAntonym},
+			{kocur.WordID, pies.WordID, models.RelationAntonym},
+		}
+		for _, relation := range relations {
+			_, err = txRepo.GetOrCreateWordRelation(relation.wordID, relation.relatedWordID, relation.relationType)
+			require.NoError(t, err, "GetOrCreateWordRelation should not error")
+		}
+		_, err = txRepo.CreateAudioRecording(kot.WordID, "audio/ogg", []byte("OggS"))
+		require.NoError(t, err, "CreateAudioRecording should not error")
+
+		state := srs.Scheduler{}.New()
+		_, err = txRepo.GetOrCreateReviewCard("ania", cat.TranslationID, state)
+		require.NoError(t, err, "GetOrCreateReviewCard should not error")
+		_, err = txRepo.GetOrCreateReviewCard("ania", targetCat.TranslationID, state)
+		require.NoError(t, err, "GetOrCreateReviewCard should not error")
+		olaCard, err := txRepo.GetOrCreateReviewCard("ola", cat.TranslationID, state)
+		require.NoError(t, err, "GetOrCreateReviewCard should not error")
+
+		summary, err := merge.MergeWords(txRepo, kot.WordID, kocur.WordID)
+		require.NoError(t, err, "MergeWords should not error")
+		assert.Equal(t, merge.Summary{
+			MovedTranslations:      1,
+			MergedTranslations:     1,
+			MovedExampleSentences:  2,
+			MergedExampleSentences: 1,
+		}, *summary, "Expected the moved and merged counts")
+
+		_, err = txRepo.GetWordByID(kot.WordID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "Expected the source word to be deleted")
+		merged, err := txRepo.GetWordByID(kocur.WordID)
+		require.NoError(t, err, "GetWordByID should not error")
+		require.Equal(t, 2, len(merged.Translations), "Expected the equal translation to be merged")
+		var texts []string
+		for _, translation := range merged.Translations {
+			for _, sentence := range translation.ExampleSentences {
+				texts = append(texts, translation.EnglishTranslation+": "+sentence.SentenceText)
+			}
+		}
+		assert.ElementsMatch(t, []string{"cat: Kot śpi.", "cat: Kot je.", "tomcat: Kot miauczy."}, texts,
+			"Expected the duplicate sentence to be merged")
+
+		inflections, err := txRepo.ListInflections(kocur.WordID)
+		require.NoError(t, err, "ListInflections should not error")
+		assert.Equal(t, 2, len(inflections), "Expected the duplicate inflection to be dropped")
+		movedRelations, err := txRepo.ListWordRelations(kocur.WordID, nil)
+		require.NoError(t, err, "ListWordRelations should not error")
+		var related []string
+		for _, relation := range movedRelations {
+			related = append(related, fmt.Sprintf("%s %s %s", relation.Word.PolishWord, relation.Type, relation.RelatedWord.PolishWord))
+		}
+		assert.ElementsMatch(t, []string{"kotek diminutive kocur", "kocur antonym pies"}, related,
+			"Expected the relations to be moved without duplicates or self-relations")
+		recordings, err := txRepo.ListAudioRecordings(kocur.WordID)
+		require.NoError(t, err, "ListAudioRecordings should not error")
+		assert.Equal(t, 1, len(recordings), "Expected the audio recording to be moved")
+
+		future := time.Now().AddDate(1, 0, 0)
+		cards, err := txRepo.ListDueReviewCards("ania", future, 10)
+		require.NoError(t, err, "ListDueReviewCards should not error")
+		assert.Equal(t, 1, len(cards), "Expected the duplicate card to be deleted")
+		olaCard, err = txRepo.GetReviewCardByID(olaCard.CardID)
+		require.NoError(t, err, "GetReviewCardByID should not error")
+		assert.Equal(t, targetCat.TranslationID, olaCard.TranslationID, "Expected the card to be moved to the equal translation")
+
+		_, err = merge.MergeWords(txRepo, kocur.WordID, kocur.WordID)
+		assert.ErrorIs(t, err, merge.ErrSameWord, "Expected merging a word into itself to fail")
+	})
+}
+
+func TestMergeWordsWithHiddenEntries(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		ania := models.UserOwner(7)
+		private := txRepo.WithScope(repository.Scope{Owners: []models.Owner{ania}, Owner: ania})
+		kocur, err := txRepo.GetOrCreateWord("kocur", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		_, err = txRepo.GetOrCreateTranslation(kocur.WordID, "cat")
+		require.NoError(t, err, "GetOrCreateTranslation should not error")
+
+		kociak, err := txRepo.GetOrCreateWord("kociak", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		_, err = private.GetOrCreateTranslation(kociak.WordID, "kitty")
+		require.NoError(t, err, "GetOrCreateTranslation should not error")
+		_, err = merge.MergeWords(txRepo, kociak.WordID, kocur.WordID)
+		assert.ErrorIs(t, err, merge.ErrHiddenEntries, "Expected a word with hidden translations not to be merged")
+		retrieved, err := private.GetWordByID(kociak.WordID)
+		require.NoError(t, err, "Expected the source word to be kept")
+		assert.Equal(t, 1, len(retrieved.Translations), "Expected the hidden translation to be kept")
+
+		kotka, err := txRepo.GetOrCreateWord("kotka", models.Grammar{})
+		require.NoError(t, err, "GetOrCreateWord should not error")
+		cat, err := txRepo.GetOrCreateTranslation(kotka.WordID, "cat")
+		require.NoError(t, err, "GetOrCreateTranslation should not error")
+		_, err = private.GetOrCreateExampleSentence(cat.TranslationID, "Kotka śpi.")
+		require.NoError(t, err, "GetOrCreateExampleSentence should not error")
+		_, err = merge.MergeWords(txRepo, kotka.WordID, kocur.WordID)
+		assert.ErrorIs(t, err, merge.ErrHiddenEntries, "Expected a word with hidden sentences not to be merged")
+		_, err = merge.MoveTranslation(txRepo, cat.TranslationID, kocur.WordID)
+		assert.ErrorIs(t, err, merge.ErrHiddenEntries, "Expected a translation with hidden sentences not to be merged")
+
+		summary, err := merge.MergeWords(private, kociak.WordID, kocur.WordID)
+		require.NoError(t, err, "MergeWords should not error when all entries are visible")
+		assert.Equal(t, 1, summary.MovedTranslations, "Expected the private translation to be moved")
+	})
+}
+
+func TestLookupForm(t *testing.T) {
+	withTransaction(t, func(txRepo repository.Repository) {
+		pies, err := txRepo.GetOrCreateWord("pies", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+		tags := models.InflectionTags{Case: models.CaseGenitive, Number: models.NumberSingular}
+		_, err = txRepo.GetOrCreateInflection(pies.WordID, "psa", tags)
+		require.NoError(t, err, "GetOrCreateInflection Should Not Error")
+
+		kot, err := txRepo.GetOrCreateWord("kot", models.Grammar{PartOfSpeech: models.PartOfSpeechNoun})
+		require.NoError(t, err, "GetOrCreateWord Should Not Error")
+
+		matches, err := txRepo.LookupForm("pies")
+		require.NoError(t, err, "LookupForm Should Not Error For Headword")
+		require.Equal(t, 1, len(matches), "Expected One Headword Match")
+		assert.Equal(t, repository.LookupHeadword, matches[0].Method, "Expected Headword Method")
+
+		matches, err = txRepo.LookupForm("Psa")
+		require.NoError(t, err, "LookupForm Should Not Error For Stored Inflection")
+		require.Equal(t, 1, len(matches), "Expected One Inflection Match")
+		assert.Equal(t, repository.LookupInflection, matches[0].Method, "Expected Inflection Method")
+		assert.Equal(t, pies.WordID, matches[0].Word.WordID, "Expected 'psa' To Resolve To 'pies'")
+		assert.Equal(t, tags, matches[0].Tags, "Expected Genitive Singular")
+
+		matches, err = txRepo.LookupForm("kotami")
+		require.NoError(t, err, "LookupForm Should Not Error For Suffix Rule")
+		require.NotEmpty(t, matches, "Expected A Suffix Rule Match")
+		assert.Equal(t, repository.LookupSuffixRule, matches[0].Method, "Expected Suffix Rule Method")
+		assert.Equal(t, kot.WordID, matches[0].Word.WordID, "Expected 'kotami' To Resolve To 'kot'")
+		assert.Equal(t, models.CaseInstrumental, matches[0].Tags.Case, "Expected Instrumental Case")
+
+		matches, err = txRepo.LookupForm("xyzzy")
+		require.NoError(t, err, "LookupForm Should Not Error For Unknown Form")
+		assert.Empty(t, matches, "Expected No Matches")
+	})
+}
 func TestConcurrentGetOrCreateWords(t *testing.T) {
 	// Clean up the database
 	CleanupRepository(t)
@@ -356,7 +1841,7 @@ func TestConcurrentGetOrCreateWords(t *testing.T) {
 			}
 			mu.Unlock()
 
-			_, err := repo.GetOrCreateWord(word)
+			_, err := repo.GetOrCreateWord(word, models.Grammar{})
 			if err != nil {
 				errCh <- err
 			}
@@ -370,7 +1855,7 @@ func TestConcurrentGetOrCreateWords(t *testing.T) {
 		require.NoError(t, err, "GetOrCreateWord should not error in concurrent execution")
 	}
 
-	createdWords, err := repo.ListWords()
+	createdWords, err := repo.ListWords(repository.WordOrder{}, repository.WordFilter{})
 	require.NoError(t, err, "ListWords should not error")
 	assert.Equal(t, expectedUnique, len(createdWords), "Expected number of words to match")
 }
@@ -379,7 +1864,7 @@ func TestConcurrentGetOrCreateTranslations(t *testing.T) {
 	CleanupRepository(t)
 	defer CleanupRepository(t)
 
-	word, err := repo.GetOrCreateWord("kot")
+	word, err := repo.GetOrCreateWord("kot", models.Grammar{})
 	require.NoError(t, err, "GetOrCreateWord should not error")
 
 	translationsToCreate := []string{
@@ -425,7 +1910,7 @@ func TestConcurrentGetOrCreateTranslations(t *testing.T) {
 		require.NoError(t, err, "GetOrCreateTranslation should not error in concurrent execution")
 	}
 
-	createdTranslations, err := repo.ListTranslations(word.WordID)
+	createdTranslations, err := repo.ListTranslations(word.WordID, repository.TranslationOrder{}, repository.TranslationFilter{})
 	require.NoError(t, err, "ListTranslations should not error")
 	assert.Equal(t, expectedUnique, len(createdTranslations), "Expected number of translations to match")
 }
@@ -434,7 +1919,7 @@ func TestConcurrentGetOrCreateExampleSentences(t *testing.T) {
 	CleanupRepository(t)
 	defer CleanupRepository(t)
 
-	word, err := repo.GetOrCreateWord("pies")
+	word, err := repo.GetOrCreateWord("pies", models.Grammar{})
 	require.NoError(t, err, "GetOrCreateWord should not error")
 
 	translation, err := repo.GetOrCreateTranslation(word.WordID, "dog")
@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"gorm.io/gorm"
+)
+
+// snapshotWord returns a snapshot of a word without its translations and other associations.
+func snapshotWord(word models.Word) *models.EntrySnapshot {
+	word.Translations, word.Inflections, word.AudioRecordings = nil, nil, nil
+	return &models.EntrySnapshot{Word: &word}
+}
+
+// snapshotTranslation returns a snapshot of a translation without its example sentences.
+func snapshotTranslation(translation models.Translation) *models.EntrySnapshot {
+	translation.ExampleSentences = nil
+	return &models.EntrySnapshot{Translation: &translation}
+}
+
+func snapshotExampleSentence(sentence models.ExampleSentence) *models.EntrySnapshot {
+	return &models.EntrySnapshot{ExampleSentence: &sentence}
+}
+
+// recordRevision stores a revision of an entry authored by the user of the scope.
+func (r *GormRepository) recordRevision(tx *gorm.DB, action models.RevisionAction, entryType models.EntryType, entryID uint, before, after *models.EntrySnapshot) error {
+	revision := models.Revision{
+		EntryType: entryType,
+		EntryID:   entryID,
+		Action:    action,
+		Before:    before,
+		After:     after,
+	}
+	if r.Scope.Author != 0 {
+		author := r.Scope.Author
+		revision.AuthorID = &author
+	}
+	return tx.Omit("Author").Create(&revision).Error
+}
+
+// recordDeletedTranslations records the deletion of translations and their example sentences
+// deleted together with the word they belong to.
+func (r *GormRepository) recordDeletedTranslations(tx *gorm.DB, translations []models.Translation) error {
+	for _, translation := range translations {
+		if err := r.recordDeletedExampleSentences(tx, translation.ExampleSentences); err != nil {
+			return err
+		}
+		err := r.recordRevision(tx, models.RevisionDelete, models.EntryTranslation, translation.TranslationID, snapshotTranslation(translation), nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordDeletedExampleSentences records the deletion of example sentences deleted together with
+// the translation they belong to.
+func (r *GormRepository) recordDeletedExampleSentences(tx *gorm.DB, sentences []models.ExampleSentence) error {
+	for _, sentence := range sentences {
+		err := r.recordRevision(tx, models.RevisionDelete, models.EntryExampleSentence, sentence.SentenceID, snapshotExampleSentence(sentence), nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordRecreatedTranslations records the creation of translations and their example sentences
+// recreated together with the word they belong to.
+func (r *GormRepository) recordRecreatedTranslations(tx *gorm.DB, translations []models.Translation) error {
+	for _, translation := range translations {
+		err := r.recordRevision(tx, models.RevisionCreate, models.EntryTranslation, translation.TranslationID, nil, snapshotTranslation(translation))
+		if err != nil {
+			return err
+		}
+		if err := r.recordRecreatedExampleSentences(tx, translation.ExampleSentences); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordRecreatedExampleSentences records the creation of example sentences recreated together with
+// the translation they belong to.
+func (r *GormRepository) recordRecreatedExampleSentences(tx *gorm.DB, sentences []models.ExampleSentence) error {
+	for _, sentence := range sentences {
+		err := r.recordRevision(tx, models.RevisionCreate, models.EntryExampleSentence, sentence.SentenceID, nil, snapshotExampleSentence(sentence))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// visibleSnapshot reports whether the entry of a snapshot is visible in the scope.
+func (r *GormRepository) visibleSnapshot(snapshot *models.EntrySnapshot) bool {
+	return snapshot != nil && slices.Contains(r.Scope.owners(), snapshot.Owner())
+}
+
+func (r *GormRepository) ListRevisions(entryType models.EntryType, entryID uint) ([]models.Revision, error) {
+	if _, ok := entryTables[entryType]; !ok {
+		return nil, fmt.Errorf("unknown entry type: %q", entryType)
+	}
+	var revisions []models.Revision
+	err := r.DB.
+		Preload("Author").
+		Where("entry_type = ? AND entry_id = ?", entryType, entryID).
+		Order("revision_id DESC").
+		Find(&revisions).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return revisions, nil
+	}
+	// The newest revision holds the current owner of the entry, or the last one of a deleted entry.
+	if !r.visibleSnapshot(revisions[0].State()) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return revisions, nil
+}
+
+func (r *GormRepository) GetRevisionByID(revisionID uint) (*models.Revision, error) {
+	var revision models.Revision
+	if err := r.DB.Preload("Author").First(&revision, revisionID).Error; err != nil {
+		return nil, err
+	}
+	// The entry may have changed owner since, so the newest revision decides as in ListRevisions.
+	var newest models.Revision
+	err := r.DB.
+		Where("entry_type = ? AND entry_id = ?", revision.EntryType, revision.EntryID).
+		Order("revision_id DESC").
+		First(&newest).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	if !r.visibleSnapshot(newest.State()) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &revision, nil
+}
+
+func (r *GormRepository) RevertToRevision(revisionID uint) (*models.Revision, error) {
+	var reverted models.Revision
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		txRepo := &GormRepository{DB: tx, Scope: r.Scope}
+		revision, err := txRepo.GetRevisionByID(revisionID)
+		if err != nil {
+			return err
+		}
+		entry := entryTables[revision.EntryType]
+		var deleted []gorm.DeletedAt
+		err = tx.Table(entry.table).Where(entry.idColumn+" = ?", revision.EntryID).Pluck("deleted_at", &deleted).Error
+		if err != nil {
+			return err
+		}
+		state := revision.State()
+		switch {
+		case len(deleted) == 0:
+			// The entry was purged from the trash
+			err = txRepo.recreateEntry(revision.EntryType, state)
+		case deleted[0].Valid:
+			err = txRepo.restoreDeleted(revision.EntryType, revision.EntryID)
+			if err == nil {
+				err = txRepo.restoreEntry(revision.EntryType, revision.EntryID, state)
+			}
+		default:
+			err = txRepo.restoreEntry(revision.EntryType, revision.EntryID, state)
+		}
+		if err != nil {
+			return err
+		}
+		return tx.
+			Preload("Author").
+			Where("entry_type = ? AND entry_id = ?", revision.EntryType, revision.EntryID).
+			Order("revision_id DESC").
+			First(&reverted).
+			Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reverted, nil
+}
+
+// restoreDeleted restores an entry from the trash.
+func (r *GormRepository) restoreDeleted(entryType models.EntryType, entryID uint) error {
+	var err error
+	switch entryType {
+	case models.EntryWord:
+		_, err = r.RestoreWord(entryID)
+	case models.EntryTranslation:
+		_, err = r.RestoreTranslation(entryID)
+	case models.EntryExampleSentence:
+		_, err = r.RestoreExampleSentence(entryID)
+	default:
+		err = fmt.Errorf("unknown entry type: %q", entryType)
+	}
+	return err
+}
+
+// restoreEntry sets the values of an existing entry to the ones in the snapshot. The owner is kept.
+// Nothing is recorded if the entry already has these values.
+func (r *GormRepository) restoreEntry(entryType models.EntryType, entryID uint, state *models.EntrySnapshot) error {
+	switch {
+	case entryType == models.EntryWord && state.Word != nil:
+		var word models.Word
+		if err := r.DB.First(&word, entryID).Error; err != nil {
+			return err
+		}
+		if word.PolishWord == state.Word.PolishWord && word.Grammar() == state.Word.Grammar() &&
+			word.PronunciationOverride == state.Word.PronunciationOverride {
+			return nil
+		}
+		before := snapshotWord(word)
+		word.PolishWord = state.Word.PolishWord
+		word.PartOfSpeech = state.Word.PartOfSpeech
+		word.Gender = state.Word.Gender
+		word.Aspect = state.Word.Aspect
+		word.PronunciationOverride = state.Word.PronunciationOverride
+		if err := r.DB.Save(&word).Error; err != nil {
+			return err
+		}
+		return r.recordRevision(r.DB, models.RevisionUpdate, entryType, entryID, before, snapshotWord(word))
+	case entryType == models.EntryTranslation && state.Translation != nil:
+		var translation models.Translation
+		if err := r.DB.First(&translation, entryID).Error; err != nil {
+			return err
+		}
+		if translation.EnglishTranslation == state.Translation.EnglishTranslation {
+			return nil
+		}
+		before := snapshotTranslation(translation)
+		translation.EnglishTranslation = state.Translation.EnglishTranslation
+		if err := r.DB.Save(&translation).Error; err != nil {
+			return err
+		}
+		return r.recordRevision(r.DB, models.RevisionUpdate, entryType, entryID, before, snapshotTranslation(translation))
+	case entryType == models.EntryExampleSentence && state.ExampleSentence != nil:
+		var sentence models.ExampleSentence
+		if err := r.DB.First(&sentence, entryID).Error; err != nil {
+			return err
+		}
+		if sentence.SentenceText == state.ExampleSentence.SentenceText {
+			return nil
+		}
+		before := snapshotExampleSentence(sentence)
+		sentence.SentenceText = state.ExampleSentence.SentenceText
+		if err := r.DB.Save(&sentence).Error; err != nil {
+			return err
+		}
+		return r.recordRevision(r.DB, models.RevisionUpdate, entryType, entryID, before, snapshotExampleSentence(sentence))
+	}
+	return fmt.Errorf("revision does not hold a %s", entryType)
+}
+
+// recreateEntry inserts a purged entry again with its original ID. Translations and example sentences
+// deleted together with it are recreated as well. The word or translation it belongs to must exist.
+func (r *GormRepository) recreateEntry(entryType models.EntryType, state *models.EntrySnapshot) error {
+	switch {
+	case entryType == models.EntryWord && state.Word != nil:
+		word := *state.Word
+		if err := r.DB.Create(&word).Error; err != nil {
+			return err
+		}
+		if err := r.recordRevision(r.DB, models.RevisionCreate, entryType, word.WordID, nil, snapshotWord(word)); err != nil {
+			return err
+		}
+		return r.recordRecreatedTranslations(r.DB, word.Translations)
+	case entryType == models.EntryTranslation && state.Translation != nil:
+		translation := *state.Translation
+		if err := r.DB.First(&models.Word{}, translation.WordID).Error; err != nil {
+			return fmt.Errorf("failed to find word of translation: %w", err)
+		}
+		if err := r.DB.Create(&translation).Error; err != nil {
+			return err
+		}
+		err := r.recordRevision(r.DB, models.RevisionCreate, entryType, translation.TranslationID, nil, snapshotTranslation(translation))
+		if err != nil {
+			return err
+		}
+		return r.recordRecreatedExampleSentences(r.DB, translation.ExampleSentences)
+	case entryType == models.EntryExampleSentence && state.ExampleSentence != nil:
+		sentence := *state.ExampleSentence
+		if err := r.DB.First(&models.Translation{}, sentence.TranslationID).Error; err != nil {
+			return fmt.Errorf("failed to find translation of example sentence: %w", err)
+		}
+		if err := r.DB.Create(&sentence).Error; err != nil {
+			return err
+		}
+		return r.recordRevision(r.DB, models.RevisionCreate, entryType, sentence.SentenceID, nil, snapshotExampleSentence(sentence))
+	}
+	return fmt.Errorf("revision does not hold a %s", entryType)
+}
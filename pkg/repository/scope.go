@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"gorm.io/gorm"
+)
+
+// ErrPrivateParent is returned when a shared entry is added to a private word or translation.
+var ErrPrivateParent = errors.New("shared entries cannot be added to private entries")
+
+// ErrAlreadyShared is returned when promoting an entry of the shared dictionary.
+var ErrAlreadyShared = errors.New("entry is already in the shared dictionary")
+
+// ErrDeletedParent is returned when restoring a translation or example sentence whose word or translation is deleted.
+var ErrDeletedParent = errors.New("the entry it belongs to is deleted, restore it first")
+
+// ErrDuplicateEntry is returned when a change would make an entry equal to another entry that is not deleted.
+var ErrDuplicateEntry = errors.New("an equal entry already exists")
+
+// ErrProposalReviewed is returned when reviewing a proposal that is no longer pending.
+var ErrProposalReviewed = errors.New("proposal was already reviewed")
+
+// Scope selects the private entries visible next to the shared dictionary, the owner of entries
+// created through the repository and the author of their revisions.
+// The zero Scope sees and creates shared entries only.
+type Scope struct {
+	// Owners whose private words, translations and example sentences are visible.
+	Owners []models.Owner
+	// Owner owns created entries. Existing shared entries and entries of the Owner are reused instead.
+	Owner models.Owner
+	// Author is the user recorded in revisions. Zero for changes made by the system.
+	Author uint
+}
+
+// owners returns the owners of all visible entries, the shared dictionary first.
+func (s Scope) owners() []models.Owner {
+	return append([]models.Owner{models.SharedOwner}, s.Owners...)
+}
+
+// reusable returns the owners of entries that GetOrCreate methods return instead of creating new ones.
+func (s Scope) reusable() []models.Owner {
+	if s.Owner.Shared() {
+		return []models.Owner{models.SharedOwner}
+	}
+	return []models.Owner{models.SharedOwner, s.Owner}
+}
+
+// entryTables maps the types of dictionary entries to their tables and ID columns.
+var entryTables = map[models.EntryType]struct{ table, idColumn string }{
+	models.EntryWord:            {"words", "word_id"},
+	models.EntryTranslation:     {"translations", "translation_id"},
+	models.EntryExampleSentence: {"example_sentences", "sentence_id"},
+}
+
+// visible limits a statement to the entries of the table visible in the scope.
+// It is meant for db.Scopes and Preload conditions.
+func (r *GormRepository) visible(table string) func(db *gorm.DB) *gorm.DB {
+	owners := r.Scope.owners()
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(table+".owner IN ?", owners)
+	}
+}
+
+// hidden limits a statement to the entries of the table not visible in the scope.
+func (r *GormRepository) hidden(table string) func(db *gorm.DB) *gorm.DB {
+	owners := r.Scope.owners()
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(table+".owner NOT IN ?", owners)
+	}
+}
+
+// ofVisibleWords limits a statement to the rows whose column references a visible word that is not deleted.
+// It is meant for db.Scopes on inflections, relations and audio recordings.
+func (r *GormRepository) ofVisibleWords(column string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		words := db.Session(&gorm.Session{NewDB: true}).Model(&models.Word{}).Scopes(r.visible("words")).Select("word_id")
+		return db.Where(column+" IN (?)", words)
+	}
+}
+
+// visibleRelations limits a statement on word relations to the ones between visible words that are not deleted.
+func (r *GormRepository) visibleRelations(db *gorm.DB) *gorm.DB {
+	return db.Scopes(r.ofVisibleWords("word_id"), r.ofVisibleWords("related_word_id"))
+}
+
+// reusable limits a statement to the entries of the table that can be reused instead of creating new ones.
+// Shared entries come first when ordered by owner.
+func (r *GormRepository) reusable(table string) func(db *gorm.DB) *gorm.DB {
+	owners := r.Scope.reusable()
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(table+".owner IN ?", owners)
+	}
+}
+
+// preloadTranslations preloads the visible translations of words and their visible example sentences.
+func (r *GormRepository) preloadTranslations(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Translations", r.visible("translations")).
+		Preload("Translations.ExampleSentences", r.visible("example_sentences"))
+}
+
+// checkParent checks that an entry owned by the scope can be added to the parent entry with the given ID.
+// The parent must be visible, and shared entries can only be added to shared parents.
+func (r *GormRepository) checkParent(parent any, table string, idColumn string, id uint) error {
+	var owners []models.Owner
+	err := r.DB.
+		Model(parent).
+		Scopes(r.visible(table)).
+		Where(table+"."+idColumn+" = ?", id).
+		Pluck(table+".owner", &owners).
+		Error
+	if err != nil {
+		return err
+	}
+	if len(owners) == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	if r.Scope.Owner.Shared() && !owners[0].Shared() {
+		return ErrPrivateParent
+	}
+	return nil
+}
+
+// checkVisibleWords checks that the words with the given IDs are visible and not deleted.
+func (r *GormRepository) checkVisibleWords(wordIDs ...uint) error {
+	var count int64
+	err := r.DB.Model(&models.Word{}).Scopes(r.visible("words")).Where("word_id IN ?", wordIDs).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count != int64(len(wordIDs)) {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *GormRepository) CountHiddenEntries(entryType models.EntryType, entryID uint) (int64, error) {
+	var hiddenTranslations int64
+	var translations *gorm.DB
+	switch entryType {
+	case models.EntryWord:
+		if err := r.checkVisibleWords(entryID); err != nil {
+			return 0, err
+		}
+		err := r.DB.
+			Model(&models.Translation{}).
+			Scopes(r.hidden("translations")).
+			Where("word_id = ?", entryID).
+			Count(&hiddenTranslations).
+			Error
+		if err != nil {
+			return 0, err
+		}
+		translations = r.DB.Model(&models.Translation{}).Select("translation_id").Where("word_id = ?", entryID)
+	case models.EntryTranslation:
+		var translation models.Translation
+		if err := r.DB.Scopes(r.visible("translations")).First(&translation, entryID).Error; err != nil {
+			return 0, err
+		}
+		translations = r.DB.Model(&models.Translation{}).Select("translation_id").Where("translation_id = ?", entryID)
+	default:
+		return 0, fmt.Errorf("unknown entry type: %q", entryType)
+	}
+	var hiddenSentences int64
+	err := r.DB.
+		Model(&models.ExampleSentence{}).
+		Scopes(r.hidden("example_sentences")).
+		Where("translation_id IN (?)", translations).
+		Count(&hiddenSentences).
+		Error
+	if err != nil {
+		return 0, err
+	}
+	return hiddenTranslations + hiddenSentences, nil
+}
+
+func (r *GormRepository) WithScope(scope Scope) Repository {
+	return &GormRepository{DB: r.DB, Scope: scope}
+}
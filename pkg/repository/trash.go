@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/sar-michal/dictionary-app/pkg/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// notDeleted is the predicate of the unique indexes of words, translations and example sentences,
+// which leave out deleted entries. ON CONFLICT clauses must repeat it to match the indexes.
+var notDeleted = clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "deleted_at IS NULL"}}}
+
+// Trash holds the deleted entries that can still be restored, most recently deleted first.
+// Translations and example sentences deleted together with their word or translation are left out,
+// since restoring it restores them as well.
+type Trash struct {
+	Words            []models.Word
+	Translations     []models.Translation
+	ExampleSentences []models.ExampleSentence
+}
+
+// ofExisting limits a statement to the rows whose column references an entry of the model that is not deleted.
+// It is meant for db.Scopes on inflections, audio recordings and review cards,
+// which are kept while the entry they belong to is in the trash.
+func ofExisting(column string, model any, idColumn string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		existing := db.Session(&gorm.Session{NewDB: true}).Model(model).Select(idColumn)
+		return db.Where(column+" IN (?)", existing)
+	}
+}
+
+func (r *GormRepository) ListTrash() (*Trash, error) {
+	var trash Trash
+	err := r.DB.
+		Unscoped().
+		Scopes(r.visible("words")).
+		Where("words.deleted_at IS NOT NULL").
+		Order("words.deleted_at DESC, words.word_id").
+		Find(&trash.Words).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	err = r.DB.
+		Unscoped().
+		Scopes(r.visible("translations")).
+		Where("translations.deleted_at IS NOT NULL").
+		Where("NOT EXISTS (SELECT 1 FROM words w WHERE w.word_id = translations.word_id AND w.deleted_at = translations.deleted_at)").
+		Order("translations.deleted_at DESC, translations.translation_id").
+		Find(&trash.Translations).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	err = r.DB.
+		Unscoped().
+		Scopes(r.visible("example_sentences")).
+		Where("example_sentences.deleted_at IS NOT NULL").
+		Where("NOT EXISTS (SELECT 1 FROM translations t " +
+			"WHERE t.translation_id = example_sentences.translation_id AND t.deleted_at = example_sentences.deleted_at)").
+		Order("example_sentences.deleted_at DESC, example_sentences.sentence_id").
+		Find(&trash.ExampleSentences).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &trash, nil
+}
+
+func (r *GormRepository) RestoreWord(wordID uint) (*models.Word, error) {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		// Only visible words can be restored
+		var word models.Word
+		err := tx.
+			Unscoped().
+			Scopes(r.visible("words")).
+			Where("words.deleted_at IS NOT NULL").
+			First(&word, wordID).
+			Error
+		if err != nil {
+			return err
+		}
+		err = checkDuplicate(tx, &models.Word{}, map[string]any{
+			"polish_word":    word.PolishWord,
+			"part_of_speech": word.PartOfSpeech,
+			"owner":          word.Owner,
+		})
+		if err != nil {
+			return err
+		}
+		// The translations and sentences deleted with the word cannot collide,
+		// since no entries can be added to a deleted word.
+		deletedAt := word.DeletedAt.Time
+		// Restore the example sentences and translations deleted together with the word
+		translations := tx.
+			Unscoped().
+			Model(&models.Translation{}).
+			Select("translation_id").
+			Where("word_id = ? AND deleted_at = ?", wordID, deletedAt)
+		err = tx.
+			Unscoped().
+			Model(&models.ExampleSentence{}).
+			Where("translation_id IN (?) AND deleted_at = ?", translations, deletedAt).
+			UpdateColumn("deleted_at", nil).
+			Error
+		if err != nil {
+			return err
+		}
+		err = tx.
+			Unscoped().
+			Model(&models.Translation{}).
+			Where("word_id = ? AND deleted_at = ?", wordID, deletedAt).
+			UpdateColumn("deleted_at", nil).
+			Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&word).UpdateColumn("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		word.DeletedAt = gorm.DeletedAt{}
+		return r.recordRevision(tx, models.RevisionRestore, models.EntryWord, wordID, nil, snapshotWord(word))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetWordByID(wordID)
+}
+
+func (r *GormRepository) RestoreTranslation(translationID uint) (*models.Translation, error) {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		// Only visible translations can be restored
+		var translation models.Translation
+		err := tx.
+			Unscoped().
+			Scopes(r.visible("translations")).
+			Where("translations.deleted_at IS NOT NULL").
+			First(&translation, translationID).
+			Error
+		if err != nil {
+			return err
+		}
+		var words int64
+		if err := tx.Model(&models.Word{}).Where("word_id = ?", translation.WordID).Count(&words).Error; err != nil {
+			return err
+		}
+		if words == 0 {
+			return ErrDeletedParent
+		}
+		err = checkDuplicate(tx, &models.Translation{}, map[string]any{
+			"word_id":             translation.WordID,
+			"english_translation": translation.EnglishTranslation,
+			"owner":               translation.Owner,
+		})
+		if err != nil {
+			return err
+		}
+		// Restore the example sentences deleted together with the translation
+		err = tx.
+			Unscoped().
+			Model(&models.ExampleSentence{}).
+			Where("translation_id = ? AND deleted_at = ?", translationID, translation.DeletedAt.Time).
+			UpdateColumn("deleted_at", nil).
+			Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&translation).UpdateColumn("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		translation.DeletedAt = gorm.DeletedAt{}
+		return r.recordRevision(tx, models.RevisionRestore, models.EntryTranslation, translationID, nil, snapshotTranslation(translation))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetTranslationByID(translationID)
+}
+
+func (r *GormRepository) RestoreExampleSentence(sentenceID uint) (*models.ExampleSentence, error) {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		// Only visible sentences can be restored
+		var sentence models.ExampleSentence
+		err := tx.
+			Unscoped().
+			Scopes(r.visible("example_sentences")).
+			Where("example_sentences.deleted_at IS NOT NULL").
+			First(&sentence, sentenceID).
+			Error
+		if err != nil {
+			return err
+		}
+		var translations int64
+		err = tx.Model(&models.Translation{}).Where("translation_id = ?", sentence.TranslationID).Count(&translations).Error
+		if err != nil {
+			return err
+		}
+		if translations == 0 {
+			return ErrDeletedParent
+		}
+		err = checkDuplicate(tx, &models.ExampleSentence{}, map[string]any{
+			"translation_id": sentence.TranslationID,
+			"sentence_text":  sentence.SentenceText,
+			"owner":          sentence.Owner,
+		})
+		if err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&sentence).UpdateColumn("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		sentence.DeletedAt = gorm.DeletedAt{}
+		return r.recordRevision(tx, models.RevisionRestore, models.EntryExampleSentence, sentenceID, nil, snapshotExampleSentence(sentence))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetExampleSentenceByID(sentenceID)
+}
+
+func (r *GormRepository) PurgeDeleted(deletedBefore time.Time) (int64, error) {
+	var purged int64
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		// Children are deleted no later than the entry they belong to, so they are purged with it.
+		expired := func(model any, idColumn string) *gorm.DB {
+			return tx.Unscoped().Model(model).Select(idColumn).Where("deleted_at < ?", deletedBefore)
+		}
+		words := expired(&models.Word{}, "word_id")
+		translations := expired(&models.Translation{}, "translation_id")
+
+		result := tx.Unscoped().Where("deleted_at < ?", deletedBefore).Delete(&models.ExampleSentence{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged += result.RowsAffected
+		// Delete the review cards of the purged translations
+		if err := deleteReviewCards(tx, translations); err != nil {
+			return err
+		}
+		result = tx.Unscoped().Where("deleted_at < ?", deletedBefore).Delete(&models.Translation{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged += result.RowsAffected
+		// Delete the inflected forms, relations and audio recordings of the purged words
+		if err := tx.Where("word_id IN (?)", words).Delete(&models.Inflection{}).Error; err != nil {
+			return err
+		}
+		err := tx.
+			Where("word_id IN (?) OR related_word_id IN (?)", words, words).
+			Delete(&models.WordRelation{}).
+			Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("word_id IN (?)", words).Delete(&models.AudioRecording{}).Error; err != nil {
+			return err
+		}
+		result = tx.Unscoped().Where("deleted_at < ?", deletedBefore).Delete(&models.Word{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged += result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
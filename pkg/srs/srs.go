@@ -0,0 +1,108 @@
+package srs
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Grade rates how well a card was recalled, from 0 (complete blackout) to 5 (perfect response).
+type Grade int
+
+const (
+	MinGrade Grade = 0
+	MaxGrade Grade = 5
+	// PassingGrade is the lowest grade counted as a correct answer.
+	PassingGrade Grade = 3
+)
+
+const (
+	// InitialEaseFactor is the ease factor of a new card.
+	InitialEaseFactor = 2.5
+	// MinEaseFactor keeps intervals of difficult cards from shrinking further.
+	MinEaseFactor = 1.3
+)
+
+// Valid reports whether the grade is within range.
+func (g Grade) Valid() bool {
+	return g >= MinGrade && g <= MaxGrade
+}
+
+// State is the scheduling state of a card.
+type State struct {
+	// Repetitions counts consecutive correct reviews.
+	Repetitions  int
+	IntervalDays int
+	EaseFactor   float64
+	Due          time.Time
+}
+
+// Clock tells the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to the Clock interface.
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock tells the time of the system.
+var SystemClock Clock = ClockFunc(time.Now)
+
+// Scheduler computes review states with the SM-2 spaced repetition algorithm.
+// A nil Clock uses the system clock.
+type Scheduler struct {
+	Clock Clock
+}
+
+func (s Scheduler) now() time.Time {
+	if s.Clock == nil {
+		return time.Now()
+	}
+	return s.Clock.Now()
+}
+
+// New returns the state of a card that has never been reviewed. It is due immediately.
+func (s Scheduler) New() State {
+	return State{EaseFactor: InitialEaseFactor, Due: s.now()}
+}
+
+// Review returns the state after a review with the given grade. A passing grade
+// schedules the card 1 day, then 6 days, then the previous interval times the
+// ease factor ahead. A failing grade starts the repetitions over.
+func (s Scheduler) Review(state State, grade Grade) (State, error) {
+	if !grade.Valid() {
+		return state, fmt.Errorf("grade must be between %d and %d", MinGrade, MaxGrade)
+	}
+	if state.EaseFactor == 0 {
+		state.EaseFactor = InitialEaseFactor
+	}
+
+	if grade >= PassingGrade {
+		switch state.Repetitions {
+		case 0:
+			state.IntervalDays = 1
+		case 1:
+			state.IntervalDays = 6
+		default:
+			state.IntervalDays = int(math.Round(float64(state.IntervalDays) * state.EaseFactor))
+		}
+		state.Repetitions++
+	} else {
+		state.Repetitions = 0
+		state.IntervalDays = 1
+	}
+
+	miss := float64(MaxGrade - grade)
+	state.EaseFactor = max(MinEaseFactor, state.EaseFactor+0.1-miss*(0.08+miss*0.02))
+	state.Due = s.now().AddDate(0, 0, state.IntervalDays)
+	return state, nil
+}
+
+// IsDue reports whether the card should be reviewed now.
+func (s Scheduler) IsDue(state State) bool {
+	return !state.Due.After(s.now())
+}
@@ -0,0 +1,101 @@
+package srs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sar-michal/dictionary-app/pkg/srs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a clock that only moves when told to.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) advanceDays(days int) {
+	c.now = c.now.AddDate(0, 0, days)
+}
+
+func newScheduler() (srs.Scheduler, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)}
+	return srs.Scheduler{Clock: clock}, clock
+}
+
+func TestNew(t *testing.T) {
+	scheduler, clock := newScheduler()
+	state := scheduler.New()
+
+	assert.Equal(t, srs.InitialEaseFactor, state.EaseFactor, "Expected Initial Ease Factor")
+	assert.Equal(t, clock.now, state.Due, "Expected New Card To Be Due Now")
+	assert.True(t, scheduler.IsDue(state), "Expected New Card To Be Due")
+}
+
+func TestReviewIntervals(t *testing.T) {
+	scheduler, clock := newScheduler()
+	state := scheduler.New()
+
+	var intervals []int
+	for range 4 {
+		var err error
+		state, err = scheduler.Review(state, 4)
+		require.NoError(t, err, "Review Should Not Error")
+		intervals = append(intervals, state.IntervalDays)
+		assert.False(t, scheduler.IsDue(state), "Expected Reviewed Card Not To Be Due")
+		clock.advanceDays(state.IntervalDays)
+		assert.True(t, scheduler.IsDue(state), "Expected Card To Be Due After Its Interval")
+	}
+
+	assert.Equal(t, []int{1, 6, 15, 38}, intervals, "Expected SM-2 Intervals")
+	assert.Equal(t, 4, state.Repetitions, "Expected Four Repetitions")
+	assert.InDelta(t, srs.InitialEaseFactor, state.EaseFactor, 1e-9, "Expected Grade 4 To Keep The Ease Factor")
+}
+
+func TestReviewEaseFactor(t *testing.T) {
+	scheduler, _ := newScheduler()
+
+	easy, err := scheduler.Review(scheduler.New(), 5)
+	require.NoError(t, err, "Review Should Not Error")
+	assert.InDelta(t, 2.6, easy.EaseFactor, 1e-9, "Expected Grade 5 To Raise The Ease Factor")
+
+	hard, err := scheduler.Review(scheduler.New(), 3)
+	require.NoError(t, err, "Review Should Not Error")
+	assert.InDelta(t, 2.36, hard.EaseFactor, 1e-9, "Expected Grade 3 To Lower The Ease Factor")
+
+	state := scheduler.New()
+	for range 10 {
+		state, err = scheduler.Review(state, 0)
+		require.NoError(t, err, "Review Should Not Error")
+	}
+	assert.Equal(t, srs.MinEaseFactor, state.EaseFactor, "Expected Ease Factor Floor")
+}
+
+func TestReviewLapse(t *testing.T) {
+	scheduler, clock := newScheduler()
+	state := scheduler.New()
+	for _, grade := range []srs.Grade{5, 5, 5} {
+		state, _ = scheduler.Review(state, grade)
+	}
+	require.Equal(t, 3, state.Repetitions, "Expected Three Repetitions")
+
+	state, err := scheduler.Review(state, 2)
+	require.NoError(t, err, "Review Should Not Error")
+	assert.Equal(t, 0, state.Repetitions, "Expected Repetitions To Reset")
+	assert.Equal(t, 1, state.IntervalDays, "Expected One Day Interval")
+	assert.Equal(t, clock.now.AddDate(0, 0, 1), state.Due, "Expected Card Due Tomorrow")
+}
+
+func TestReviewInvalidGrade(t *testing.T) {
+	scheduler, _ := newScheduler()
+	state := scheduler.New()
+
+	_, err := scheduler.Review(state, 6)
+	assert.Error(t, err, "Expected Error For Grade 6")
+	_, err = scheduler.Review(state, -1)
+	assert.Error(t, err, "Expected Error For Grade -1")
+}
@@ -0,0 +1,82 @@
+package stardict
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// chunkSize is the amount of uncompressed data in a dictzip chunk. It is the
+// value used by dictzip, which keeps every compressed chunk below 64 KiB.
+const chunkSize = 58315
+
+// writeDictzip compresses data into the dictzip format: a gzip file whose
+// "RA" extra field lists independently compressed chunks, so readers can
+// decompress an article without inflating the whole file. Standard gzip
+// tools read it like any other gzip file.
+func writeDictzip(w io.Writer, data []byte) error {
+	var compressed bytes.Buffer
+	var sizes []uint16
+	for start := 0; ; start += chunkSize {
+		end := min(start+chunkSize, len(data))
+		before := compressed.Len()
+		// A fresh compressor per chunk keeps back-references inside the chunk,
+		// and Flush byte-aligns the output so chunks can be concatenated.
+		fw, err := flate.NewWriter(&compressed, flate.BestCompression)
+		if err != nil {
+			return fmt.Errorf("failed to create compressor: %w", err)
+		}
+		if _, err := fw.Write(data[start:end]); err != nil {
+			return fmt.Errorf("failed to compress chunk: %w", err)
+		}
+		if end == len(data) {
+			err = fw.Close()
+		} else {
+			err = fw.Flush()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to compress chunk: %w", err)
+		}
+		size := compressed.Len() - before
+		if size > 0xffff {
+			return fmt.Errorf("compressed chunk too large: %d bytes", size)
+		}
+		sizes = append(sizes, uint16(size))
+		if end == len(data) {
+			break
+		}
+	}
+
+	extra := []byte{'R', 'A', 0, 0}
+	extra = binary.LittleEndian.AppendUint16(extra, 1) // version
+	extra = binary.LittleEndian.AppendUint16(extra, chunkSize)
+	extra = binary.LittleEndian.AppendUint16(extra, uint16(len(sizes)))
+	for _, size := range sizes {
+		extra = binary.LittleEndian.AppendUint16(extra, size)
+	}
+	binary.LittleEndian.PutUint16(extra[2:], uint16(len(extra)-4))
+
+	header := []byte{
+		0x1f, 0x8b, // magic
+		8,          // deflate
+		4,          // FEXTRA
+		0, 0, 0, 0, // modification time
+		2,   // maximum compression
+		255, // unknown OS
+	}
+	header = binary.LittleEndian.AppendUint16(header, uint16(len(extra)))
+	header = append(header, extra...)
+
+	trailer := binary.LittleEndian.AppendUint32(nil, crc32.ChecksumIEEE(data))
+	trailer = binary.LittleEndian.AppendUint32(trailer, uint32(len(data)))
+
+	for _, part := range [][]byte{header, compressed.Bytes(), trailer} {
+		if _, err := w.Write(part); err != nil {
+			return fmt.Errorf("failed to write dictzip file: %w", err)
+		}
+	}
+	return nil
+}
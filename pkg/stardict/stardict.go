@@ -0,0 +1,112 @@
+package stardict
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sar-michal/dictionary-app/pkg/glossary"
+)
+
+// Write creates the .ifo, .idx and .dict.dz files of a StarDict dictionary in dir.
+// Articles are HTML; the date is recorded in the .ifo file.
+func Write(dir string, g *glossary.Glossary, date time.Time) error {
+	headwords := g.Headwords()
+	slices.SortFunc(headwords, compare)
+
+	var idx, dict bytes.Buffer
+	for _, headword := range headwords {
+		article := formatArticle(g.Entries[headword])
+		idx.WriteString(headword)
+		idx.WriteByte(0)
+		binary.Write(&idx, binary.BigEndian, uint32(dict.Len()))
+		binary.Write(&idx, binary.BigEndian, uint32(len(article)))
+		dict.WriteString(article)
+	}
+
+	base := filepath.Join(dir, g.Direction.FileName())
+	ifo := fmt.Sprintf("StarDict's dict ifo file\n"+
+		"version=2.4.2\n"+
+		"wordcount=%d\n"+
+		"idxfilesize=%d\n"+
+		"bookname=%s\n"+
+		"date=%s\n"+
+		"sametypesequence=h\n",
+		len(headwords), idx.Len(), g.Direction.Title(), date.Format("2006.01.02"))
+	if err := os.WriteFile(base+".ifo", []byte(ifo), 0o644); err != nil {
+		return fmt.Errorf("failed to write ifo file: %w", err)
+	}
+	if err := os.WriteFile(base+".idx", idx.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write idx file: %w", err)
+	}
+
+	file, err := os.Create(base + ".dict.dz")
+	if err != nil {
+		return fmt.Errorf("failed to create dict file: %w", err)
+	}
+	defer file.Close()
+	if err := writeDictzip(file, dict.Bytes()); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// compare orders headwords like StarDict does: ASCII case-insensitively, then byte-wise.
+func compare(a, b string) int {
+	if c := strings.Compare(asciiLower(a), asciiLower(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+func asciiLower(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return r
+	}, s)
+}
+
+// formatArticle renders the entries of a headword as HTML.
+func formatArticle(entries []glossary.Entry) string {
+	var b strings.Builder
+	for i, entry := range entries {
+		if i > 0 {
+			b.WriteString("<br>")
+		}
+		b.WriteString("<b>" + html.EscapeString(entry.Headword) + "</b>")
+		if entry.Pronunciation != "" {
+			b.WriteString(" [" + html.EscapeString(entry.Pronunciation) + "]")
+		}
+		if entry.Grammar != "" {
+			b.WriteString(" <i>" + html.EscapeString(entry.Grammar) + "</i>")
+		}
+		if len(entry.Senses) == 0 {
+			continue
+		}
+		b.WriteString("<ol>")
+		for _, sense := range entry.Senses {
+			b.WriteString("<li>" + html.EscapeString(sense.Text))
+			if sense.Grammar != "" {
+				b.WriteString(" <i>" + html.EscapeString(sense.Grammar) + "</i>")
+			}
+			if len(sense.Examples) > 0 {
+				b.WriteString("<ul>")
+				for _, example := range sense.Examples {
+					b.WriteString("<li><i>" + html.EscapeString(example) + "</i></li>")
+				}
+				b.WriteString("</ul>")
+			}
+			b.WriteString("</li>")
+		}
+		b.WriteString("</ol>")
+	}
+	return b.String()
+}
@@ -0,0 +1,87 @@
+package stardict
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sar-michal/dictionary-app/pkg/glossary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	g := &glossary.Glossary{Direction: glossary.PolishEnglish, Entries: map[string][]glossary.Entry{
+		"kot": {{Headword: "kot", Pronunciation: "kɔt", Grammar: "noun", Senses: []glossary.Sense{
+			{Text: "cat", Examples: []string{"Kot śpi."}},
+		}}},
+		"Anna":  {{Headword: "Anna", Senses: []glossary.Sense{{Text: "Anne"}}}},
+		"album": {{Headword: "album", Senses: []glossary.Sense{{Text: "album & scrapbook"}}}},
+	}}
+	dir := t.TempDir()
+	require.NoError(t, Write(dir, g, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)), "Write Should Not Error")
+	base := filepath.Join(dir, "dictionary-pl-en")
+
+	ifo, err := os.ReadFile(base + ".ifo")
+	require.NoError(t, err, "Reading ifo Should Not Error")
+	idx, err := os.ReadFile(base + ".idx")
+	require.NoError(t, err, "Reading idx Should Not Error")
+	assert.True(t, strings.HasPrefix(string(ifo), "StarDict's dict ifo file\nversion=2.4.2\n"), "Expected ifo Magic Line")
+	assert.Contains(t, string(ifo), "wordcount=3\n", "Expected Word Count")
+	assert.Contains(t, string(ifo), "idxfilesize="+strconv.Itoa(len(idx))+"\n", "Expected idx File Size")
+	assert.Contains(t, string(ifo), "date=2024.03.01\n", "Expected Date")
+
+	file, err := os.Open(base + ".dict.dz")
+	require.NoError(t, err, "Opening dict.dz Should Not Error")
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	require.NoError(t, err, "dict.dz Should Be A gzip File")
+	dict, err := io.ReadAll(gz)
+	require.NoError(t, err, "Decompressing dict.dz Should Not Error")
+
+	var headwords, articles []string
+	for len(idx) > 0 {
+		end := bytes.IndexByte(idx, 0)
+		require.True(t, end >= 0 && len(idx) >= end+9, "Expected Complete idx Entry")
+		offset := binary.BigEndian.Uint32(idx[end+1:])
+		size := binary.BigEndian.Uint32(idx[end+5:])
+		headwords = append(headwords, string(idx[:end]))
+		articles = append(articles, string(dict[offset:offset+size]))
+		idx = idx[end+9:]
+	}
+	assert.Equal(t, []string{"album", "Anna", "kot"}, headwords, "Expected Case-Insensitive Order")
+	assert.Equal(t, "<b>album</b><ol><li>album &amp; scrapbook</li></ol>", articles[0], "Expected Escaped Article")
+	assert.Equal(t, "<b>kot</b> [kɔt] <i>noun</i><ol><li>cat<ul><li><i>Kot śpi.</i></li></ul></li></ol>", articles[2], "Expected Article With Example")
+}
+
+func TestDictzipChunks(t *testing.T) {
+	data := bytes.Repeat([]byte("zamek – castle; zamek – lock\n"), 5000)
+	var buf bytes.Buffer
+	require.NoError(t, writeDictzip(&buf, data), "writeDictzip Should Not Error")
+
+	gz, err := gzip.NewReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err, "Expected gzip Header")
+	whole, err := io.ReadAll(gz)
+	require.NoError(t, err, "Expected Valid gzip Stream")
+	assert.Equal(t, data, whole, "Expected Original Data")
+
+	extra := gz.Header.Extra
+	require.Equal(t, "RA", string(extra[:2]), "Expected RA Subfield")
+	chunkLen := int(binary.LittleEndian.Uint16(extra[6:]))
+	count := int(binary.LittleEndian.Uint16(extra[8:]))
+	require.Equal(t, (len(data)+chunkLen-1)/chunkLen, count, "Expected Chunk Count")
+
+	// The second chunk must inflate on its own.
+	offset := 10 + 2 + len(extra) + int(binary.LittleEndian.Uint16(extra[10:]))
+	size := int(binary.LittleEndian.Uint16(extra[12:]))
+	chunk, _ := io.ReadAll(flate.NewReader(bytes.NewReader(buf.Bytes()[offset : offset+size])))
+	assert.Equal(t, data[chunkLen:2*chunkLen], chunk, "Expected Second Chunk To Decompress Independently")
+}